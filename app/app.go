@@ -37,6 +37,7 @@ import (
 	upgradeclient "github.com/cosmos/cosmos-sdk/x/upgrade/client"
 
 	"github.com/trinhtan/cosmos-hackathon/x/sunchain"
+	sunchainclient "github.com/trinhtan/cosmos-hackathon/x/sunchain/client"
 )
 
 const appName = "BandConsumerApp"
@@ -58,7 +59,10 @@ var (
 		staking.AppModuleBasic{},
 		mint.AppModuleBasic{},
 		distr.AppModuleBasic{},
-		gov.NewAppModuleBasic(paramsclient.ProposalHandler, distr.ProposalHandler, upgradeclient.ProposalHandler),
+		gov.NewAppModuleBasic(
+			paramsclient.ProposalHandler, distr.ProposalHandler, upgradeclient.ProposalHandler,
+			sunchainclient.ProposalHandler,
+		),
 		params.AppModuleBasic{},
 		crisis.AppModuleBasic{},
 		slashing.AppModuleBasic{},
@@ -210,17 +214,6 @@ func NewBandConsumerApp(
 
 	app.evidenceKeeper = *evidenceKeeper
 
-	// register the proposal types
-	govRouter := gov.NewRouter()
-	govRouter.AddRoute(gov.RouterKey, gov.ProposalHandler).
-		AddRoute(paramsproposal.RouterKey, params.NewParamChangeProposalHandler(app.paramsKeeper)).
-		AddRoute(distr.RouterKey, distr.NewCommunityPoolSpendProposalHandler(app.distrKeeper)).
-		AddRoute(upgrade.RouterKey, upgrade.NewSoftwareUpgradeProposalHandler(app.upgradeKeeper))
-	app.govKeeper = gov.NewKeeper(
-		appCodec, keys[gov.StoreKey], app.subspaces[gov.ModuleName],
-		app.supplyKeeper, &stakingKeeper, govRouter,
-	)
-
 	// create IBC keeper
 	app.ibcKeeper = ibc.NewKeeper(app.cdc, keys[ibc.StoreKey], stakingKeeper)
 
@@ -231,6 +224,28 @@ func NewBandConsumerApp(
 	app.sunchainKeeper = sunchain.NewKeeper(
 		cdc, keys[sunchain.StoreKey], app.bankKeeper, app.ibcKeeper.ChannelKeeper,
 	)
+	govCodec := newGovCodec(appCodec, cdc)
+	app.upgradeKeeper.SetUpgradeHandler(sunchain.ProductMetadataUpgrade, func(ctx sdk.Context, plan upgrade.Plan) {
+		proposals := govCodec.migrateProposals(ctx, keys[gov.StoreKey])
+		ctx.Logger().Info("migrated governance proposals to amino", "count", proposals)
+		if err := app.sunchainKeeper.AddDefaultCategories(ctx); err != nil {
+			panic(err)
+		}
+		migrated := app.sunchainKeeper.MigrateProductsV0(ctx)
+		ctx.Logger().Info("migrated products to structured metadata", "count", migrated)
+	})
+
+	// register the proposal types
+	govRouter := gov.NewRouter()
+	govRouter.AddRoute(gov.RouterKey, gov.ProposalHandler).
+		AddRoute(paramsproposal.RouterKey, params.NewParamChangeProposalHandler(app.paramsKeeper)).
+		AddRoute(distr.RouterKey, distr.NewCommunityPoolSpendProposalHandler(app.distrKeeper)).
+		AddRoute(upgrade.RouterKey, upgrade.NewSoftwareUpgradeProposalHandler(app.upgradeKeeper)).
+		AddRoute(sunchain.RouterKey, sunchain.NewCategoryChangeProposalHandler(app.sunchainKeeper))
+	app.govKeeper = gov.NewKeeper(
+		govCodec, keys[gov.StoreKey], app.subspaces[gov.ModuleName],
+		app.supplyKeeper, &stakingKeeper, govRouter,
+	)
 
 	// register the staking hooks
 	// NOTE: stakingKeeper above is passed by reference, so that it will contain these hooks
@@ -262,14 +277,14 @@ func NewBandConsumerApp(
 	// CanWithdrawInvariant invariant.
 
 	app.mm.SetOrderBeginBlockers(upgrade.ModuleName, mint.ModuleName, distr.ModuleName, slashing.ModuleName, staking.ModuleName)
-	app.mm.SetOrderEndBlockers(crisis.ModuleName, gov.ModuleName, staking.ModuleName)
+	app.mm.SetOrderEndBlockers(crisis.ModuleName, gov.ModuleName, staking.ModuleName, sunchain.ModuleName)
 
 	// NOTE: The genutils module must occur after staking so that pools are
 	// properly initialized with tokens from genesis accounts.
 	app.mm.SetOrderInitGenesis(
 		distr.ModuleName, staking.ModuleName, auth.ModuleName, bank.ModuleName,
 		slashing.ModuleName, gov.ModuleName, mint.ModuleName, supply.ModuleName,
-		crisis.ModuleName, genutil.ModuleName, evidence.ModuleName,
+		crisis.ModuleName, genutil.ModuleName, evidence.ModuleName, sunchain.ModuleName,
 	)
 
 	app.mm.RegisterInvariants(&app.crisisKeeper)
@@ -3,13 +3,16 @@ package app
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/libs/log"
 	db "github.com/tendermint/tm-db"
 
 	"github.com/cosmos/cosmos-sdk/codec"
+	codecstd "github.com/cosmos/cosmos-sdk/codec/std"
 	"github.com/cosmos/cosmos-sdk/simapp"
+	"github.com/cosmos/cosmos-sdk/x/gov"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 )
@@ -36,6 +39,28 @@ func TestBlackListedAddrs(t *testing.T) {
 	}
 }
 
+// ensure that the proposals stored with the application codec are read by the gov keeper once migrated
+func TestMigrateProposals(t *testing.T) {
+	db := db.NewMemDB()
+	bcapp := NewBandConsumerApp(log.NewTMLogger(log.NewSyncWriter(os.Stdout)), db, nil, true, 0, map[int64]bool{}, "")
+	require.NoError(t, setGenesis(bcapp))
+	ctx := bcapp.BaseApp.NewContext(true, abci.Header{})
+
+	appCodec := codecstd.NewAppCodec(bcapp.Codec())
+	now := time.Now().UTC()
+	proposal := gov.NewProposal(gov.NewTextProposal("title", "description"), 1, now, now)
+	bz, err := appCodec.MarshalProposal(proposal)
+	require.NoError(t, err)
+	ctx.KVStore(bcapp.keys[gov.StoreKey]).Set(gov.ProposalKey(1), bz)
+
+	govCodec := newGovCodec(appCodec, bcapp.Codec())
+	require.Equal(t, 1, govCodec.migrateProposals(ctx, bcapp.keys[gov.StoreKey]))
+	migrated, ok := bcapp.govKeeper.GetProposal(ctx, 1)
+	require.True(t, ok)
+	require.Equal(t, proposal.Content, migrated.Content)
+	require.Equal(t, proposal.ProposalID, migrated.ProposalID)
+}
+
 func setGenesis(bcapp *BandConsumerApp) error {
 	genesisState := simapp.NewDefaultGenesisState()
 	stateBytes, err := codec.MarshalJSONIndent(bcapp.Codec(), genesisState)
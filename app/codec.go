@@ -0,0 +1,68 @@
+package app
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	codecstd "github.com/cosmos/cosmos-sdk/codec/std"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+)
+
+var _ gov.Codec = (*govCodec)(nil)
+
+// govCodec is the codec of the gov keeper. The application codec can only store the proposal
+// contents of the SDK modules, so proposals are stored with amino, which knows every content type
+// registered by the modules of the app, such as the sunchain category change proposals. Chains
+// started before stored the proposals with the application codec, and their proposals are moved
+// to amino by migrateProposals at the upgrade.
+type govCodec struct {
+	*codecstd.Codec
+	amino *codec.Codec
+}
+
+func newGovCodec(appCodec *codecstd.Codec, amino *codec.Codec) *govCodec {
+	return &govCodec{Codec: appCodec, amino: amino}
+}
+
+// MarshalProposal marshals a Proposal with amino.
+func (c *govCodec) MarshalProposal(p gov.Proposal) ([]byte, error) {
+	return c.amino.MarshalBinaryBare(p)
+}
+
+// UnmarshalProposal decodes a Proposal marshalled with amino.
+func (c *govCodec) UnmarshalProposal(bz []byte) (gov.Proposal, error) {
+	var proposal gov.Proposal
+	if err := c.amino.UnmarshalBinaryBare(bz, &proposal); err != nil {
+		return gov.Proposal{}, err
+	}
+	return proposal, nil
+}
+
+// migrateProposals re-encodes with amino the proposals the gov keeper stored with the application
+// codec before the govCodec, and returns the number of proposals migrated. It must run once, at
+// the upgrade which brings the govCodec, before the gov keeper reads any proposal.
+func (c *govCodec) migrateProposals(ctx sdk.Context, key sdk.StoreKey) int {
+	store := ctx.KVStore(key)
+	iterator := sdk.KVStorePrefixIterator(store, gov.ProposalsKeyPrefix)
+
+	proposals := make(map[string]gov.Proposal)
+	keys := []string{}
+	for ; iterator.Valid(); iterator.Next() {
+		proposal, err := c.Codec.UnmarshalProposal(iterator.Value())
+		if err != nil {
+			panic(err)
+		}
+		key := string(iterator.Key())
+		keys = append(keys, key)
+		proposals[key] = proposal
+	}
+	iterator.Close()
+
+	for _, key := range keys {
+		bz, err := c.MarshalProposal(proposals[key])
+		if err != nil {
+			panic(err)
+		}
+		store.Set([]byte(key), bz)
+	}
+	return len(keys)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/lcd"
 	"github.com/cosmos/cosmos-sdk/client/rpc"
 	codecstd "github.com/cosmos/cosmos-sdk/codec/std"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/version"
 	"github.com/cosmos/cosmos-sdk/x/auth"
@@ -25,6 +26,7 @@ import (
 	"github.com/tendermint/tendermint/libs/cli"
 
 	"github.com/trinhtan/cosmos-hackathon/app"
+	sunchainrest "github.com/trinhtan/cosmos-hackathon/x/sunchain/client/rest"
 )
 
 var (
@@ -69,7 +71,7 @@ func main() {
 		queryCmd(cdc),
 		txCmd(cdc),
 		flags.LineBreak,
-		lcd.ServeCommand(cdc, registerRoutes),
+		restServerCmd(cdc),
 		flags.LineBreak,
 		keys.Commands(),
 		flags.LineBreak,
@@ -145,10 +147,42 @@ func txCmd(cdc *amino.Codec) *cobra.Command {
 	return txCmd
 }
 
+// restServerCmd returns the LCD command with the flags that select the keyring used to resolve key names
+// and the CORS settings of the sunchain routes.
+func restServerCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := lcd.ServeCommand(cdc, registerRoutes)
+	cmd.Flags().String(flags.FlagKeyringBackend, "", "Keyring backend used to resolve key names (os|file|kwallet|pass|test), none if empty")
+	cmd.Flags().StringSlice(sunchainrest.FlagCORSAllowedOrigins, sunchainrest.DefaultCORSAllowedOrigins, "Origins allowed to make cross-origin requests to the sunchain routes")
+	cmd.Flags().StringSlice(sunchainrest.FlagCORSAllowedMethods, sunchainrest.DefaultCORSAllowedMethods, "Methods allowed in cross-origin requests to the sunchain routes")
+	cmd.Flags().StringSlice(sunchainrest.FlagCORSAllowedHeaders, sunchainrest.DefaultCORSAllowedHeaders, "Headers allowed in cross-origin requests to the sunchain routes")
+	cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
+		for _, flag := range []string{
+			flags.FlagKeyringBackend,
+			sunchainrest.FlagCORSAllowedOrigins,
+			sunchainrest.FlagCORSAllowedMethods,
+			sunchainrest.FlagCORSAllowedHeaders,
+		} {
+			if err := viper.BindPFlag(flag, cmd.Flags().Lookup(flag)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return cmd
+}
+
 // registerRoutes registers the routes from the different modules for the LCD.
 // NOTE: details on the routes added for each module are in the module documentation
 // NOTE: If making updates here you also need to update the test helper in client/lcd/test_helper.go
 func registerRoutes(rs *lcd.RestServer) {
+	if backend := viper.GetString(flags.FlagKeyringBackend); backend != "" {
+		kb, err := keyring.NewKeyring(sdk.KeyringServiceName(), backend, viper.GetString(flags.FlagHome), os.Stdin)
+		if err != nil {
+			panic(err)
+		}
+		rs.CliCtx.Keybase = kb
+	}
+
 	client.RegisterRoutes(rs.CliCtx, rs.Mux)
 	authrest.RegisterTxRoutes(rs.CliCtx, rs.Mux)
 	app.ModuleBasics.RegisterRESTRoutes(rs.CliCtx, rs.Mux)
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/snikch/goodman/hooks"
 	"github.com/snikch/goodman/transaction"
@@ -16,6 +17,9 @@ func main() {
 	})
 	h.BeforeEach(func(t *transaction.Transaction) {
 		fmt.Println("before each modification")
+		if isUnseededSunchainPath(t.FullPath) {
+			t.Skip = true
+		}
 	})
 	h.Before("/version > GET", func(t *transaction.Transaction) {
 		fmt.Println("before version TEST")
@@ -42,3 +46,23 @@ func main() {
 	defer server.Listener.Close()
 	fmt.Print(h)
 }
+
+// unseededSunchainPaths are the sunchain routes which can't be checked against the contract test
+// node: the event stream never ends, the REST server has no keyring for the key name routes and
+// no tx signed for the current sequence of the test account can be broadcast. The product, sell
+// and reservation of the other routes are seeded by setup_sunchain.sh, and the transaction routes
+// only return unsigned txs, so they leave them in place.
+var unseededSunchainPaths = []string{
+	"/sunchain/v1/events",
+	"/sunchain/v1/names/",
+	"/sunchain/v1/tx/broadcast",
+}
+
+func isUnseededSunchainPath(path string) bool {
+	for _, prefix := range unseededSunchainPaths {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -13,7 +13,8 @@ const state = {
   productDetail: {},
   myProducts: [],
   sellsProducts: [],
-  listOrdersOfSell: []
+  listOrdersOfSell: [],
+  categories: []
 };
 
 const mutations = {
@@ -37,24 +38,31 @@ const mutations = {
   },
   setOrdersOfSell(state, orders) {
     state.listOrdersOfSell = orders;
+  },
+  setCategories(state, categories) {
+    state.categories = categories;
   }
 };
 
 const actions = {
   async setCosmosAccount({ commit, state }) {
     let respone = await axios.get(
-      `${process.env.VUE_APP_API_BACKEND}/sunchain/names/${state.name}/address`
+      `${process.env.VUE_APP_API_BACKEND}/sunchain/v1/names/${state.name}/address`
     );
     let account = respone.data.result.value;
     commit('setCosmosAccount', account);
   },
+  async getCategories({ commit }) {
+    let response = await axios.get(`${process.env.VUE_APP_API_BACKEND}/sunchain/v1/categories`);
+    commit('setCategories', response.data.result || []);
+  },
   async getAllProducts({ commit }) {
     try {
-      let response = await axios.get(`${process.env.VUE_APP_API_BACKEND}/sunchain/products`);
+      let response = await axios.get(`${process.env.VUE_APP_API_BACKEND}/sunchain/v1/products`);
       let products = response.data.result;
       if (products) {
         for (let index = 0; index < products.length; index++) {
-          products[index].images = JSON.parse(products[index].images);
+          products[index].images = (products[index].images || []).map((image) => image.uri);
         }
       }
 
@@ -65,7 +73,7 @@ const actions = {
   },
   async createProduct({ commit, state }, product) {
     try {
-      let response = await axios.post(`${process.env.VUE_APP_API_BACKEND}/sunchain/products`, {
+      let response = await axios.post(`${process.env.VUE_APP_API_BACKEND}/sunchain/v1/products`, {
         base_req: {
           from: state.address,
           chain_id: 'band-consumer'
@@ -73,7 +81,7 @@ const actions = {
         title: product.asset.title,
         description: product.asset.description,
         category: product.asset.category,
-        images: JSON.stringify(product.images)
+        images: product.images
       });
       return response;
     } catch (error) {
@@ -82,43 +90,58 @@ const actions = {
   },
   async signTxt({ commit, state }, sign) {
     try {
-      let respone = await axios.post(
-        `${process.env.VUE_APP_API_BACKEND}/sunchain/tx/sign`,
+      let tx = sign.data.value;
+      let signBytes = await axios.post(
+        `${process.env.VUE_APP_API_BACKEND}/sunchain/v1/tx/sign-bytes`,
         {
-          base_req: {
-            from: state.address,
-            chain_id: 'band-consumer'
-          },
-          tx: JSON.stringify(sign.data),
-          signer: state.name,
+          tx: tx,
           sequence: state.sequence.toString(),
           accountNumber: state.account_number.toString()
         },
         null
       );
-      return respone.data.result;
+      // sign.signer signs the bytes with the key of the account and returns { pub_key, signature }.
+      // Without one, the bytes are signed by the key of the account in the keyring of the REST server.
+      let signer = sign.signer || (async (bytes) => {
+        let response = await axios.post(
+          `${process.env.VUE_APP_API_BACKEND}/sunchain/v1/names/${state.name}/sign`,
+          { sign_bytes: bytes },
+          null
+        );
+        return response.data;
+      });
+      let signature = await signer(signBytes.data.sign_bytes);
+      let respone = await axios.post(
+        `${process.env.VUE_APP_API_BACKEND}/sunchain/v1/tx/broadcast`,
+        {
+          tx: { ...tx, signatures: [signature] },
+          mode: 'block'
+        },
+        null
+      );
+      return respone.data;
     } catch (error) {
       throw error;
     }
   },
   async getDetailProduct({ commit }, productId) {
     let response = await axios.get(
-      `${process.env.VUE_APP_API_BACKEND}/sunchain/products/${productId}`
+      `${process.env.VUE_APP_API_BACKEND}/sunchain/v1/products/${productId}`
     );
     let product = response.data.result;
-    product.images = JSON.parse(product.images);
+    product.images = (product.images || []).map((image) => image.uri);
     commit('setDetailProduct', product);
   },
   async getMyProduct({ commit, state, dispatch }) {
     await dispatch('setCosmosAccount');
     try {
       let response = await axios.get(
-        `${process.env.VUE_APP_API_BACKEND}/sunchain/names/${state.name}/products`
+        `${process.env.VUE_APP_API_BACKEND}/sunchain/v1/names/${state.name}/products`
       );
       let products = response.data.result;
       if (products) {
         for (let index = 0; index < products.length; index++) {
-          products[index].images = JSON.parse(products[index].images);
+          products[index].images = (products[index].images || []).map((image) => image.uri);
         }
       }
 
@@ -129,16 +152,16 @@ const actions = {
   },
   async getSellsProducts({ commit }) {
     try {
-      let response = await axios.get(`${process.env.VUE_APP_API_BACKEND}/sunchain/sells`);
+      let response = await axios.get(`${process.env.VUE_APP_API_BACKEND}/sunchain/v1/sells`);
       let sellsProducts = response.data.result ? response.data.result : [];
       let listSellProducts = [];
       for (let i = 0; i < sellsProducts.length; i++) {
         const sell = sellsProducts[i];
         let response = await axios.get(
-          `${process.env.VUE_APP_API_BACKEND}/sunchain/products/${sell.productID}`
+          `${process.env.VUE_APP_API_BACKEND}/sunchain/v1/products/${sell.productID}`
         );
         let product = response.data.result;
-        product.images = JSON.parse(product.images);
+        product.images = (product.images || []).map((image) => image.uri);
         listSellProducts.push(product);
       }
       commit('getAllSellsProducts', listSellProducts);
@@ -148,7 +171,7 @@ const actions = {
   },
   async setPriceSell({ state }, { productID, minPrice }) {
     try {
-      let response = await axios.post(`${process.env.VUE_APP_API_BACKEND}/sunchain/sells`, {
+      let response = await axios.post(`${process.env.VUE_APP_API_BACKEND}/sunchain/v1/sells`, {
         base_req: {
           from: state.address,
           chain_id: 'band-consumer'
@@ -163,7 +186,7 @@ const actions = {
   },
   async orderProduct({ state }, { sellID, price }) {
     try {
-      let response = await axios.post(`${process.env.VUE_APP_API_BACKEND}/sunchain/reservations`, {
+      let response = await axios.post(`${process.env.VUE_APP_API_BACKEND}/sunchain/v1/reservations`, {
         base_req: {
           from: state.address,
           chain_id: 'band-consumer'
@@ -176,15 +199,15 @@ const actions = {
       throw error;
     }
   },
-  async deleteSell({ state }, { productID, sellID }) {
+  async deleteSell({ state }, { sellID }) {
     try {
-      let response = await axios.post(`${process.env.VUE_APP_API_BACKEND}/sunchain/cancelSell`, {
-        base_req: {
-          from: state.address,
-          chain_id: 'band-consumer'
-        },
-        productID,
-        sellID
+      let response = await axios.delete(`${process.env.VUE_APP_API_BACKEND}/sunchain/v1/sells/${sellID}`, {
+        data: {
+          base_req: {
+            from: state.address,
+            chain_id: 'band-consumer'
+          }
+        }
       });
       return response;
     } catch (error) {
@@ -194,7 +217,7 @@ const actions = {
   async getOrderOfSell({ commit }, sellID) {
     try {
       let response = await axios.get(
-        `${process.env.VUE_APP_API_BACKEND}/sunchain/sells/${sellID}/reservations`
+        `${process.env.VUE_APP_API_BACKEND}/sunchain/v1/sells/${sellID}/reservations`
       );
       let orders = response.data.result ? response.data.result : [];
       commit('setOrdersOfSell', orders);
@@ -23,6 +23,17 @@ bccli config indent true
 bccli config trust-node true
 bccli config keyring-backend test
 
+# let the validator register the channels to bandchain and to the collateral chain
+VALIDATOR=$(bccli keys show validator -a --keyring-backend test)
+jq --arg addr "$VALIDATOR" '.app_state.sunchain.channel_authority = $addr' ~/.bcd/config/genesis.json > ~/.bcd/config/genesis.tmp
+mv ~/.bcd/config/genesis.tmp ~/.bcd/config/genesis.json
+
+# fiat prices are quoted by the oracle script deployed on BandChain with this id
+if [ -n "$QUOTE_ORACLE_SCRIPT_ID" ]; then
+  jq --argjson id "$QUOTE_ORACLE_SCRIPT_ID" '.app_state.sunchain.quote_oracle_script_id = ($id | tostring)' ~/.bcd/config/genesis.json > ~/.bcd/config/genesis.tmp
+  mv ~/.bcd/config/genesis.tmp ~/.bcd/config/genesis.json
+fi
+
 bcd gentx --name validator --keyring-backend test
 bcd collect-gentxs
 
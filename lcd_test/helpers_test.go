@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -33,7 +34,11 @@ import (
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"gopkg.in/yaml.v2"
+
+	"github.com/trinhtan/cosmos-hackathon/x/sunchain"
 )
 
 // Request makes a test LCD test request. It returns a response object and a
@@ -1131,3 +1136,184 @@ func doWithdrawDelegatorAllRewards(
 
 	return txResp
 }
+
+// ----------------------------------------------------------------------
+// Sunchain
+// ----------------------------------------------------------------------
+
+// openAPIDoc is the part of the sunchain OpenAPI document the contract tests check responses against
+type openAPIDoc struct {
+	Paths map[string]openAPIPathItem `yaml:"paths"`
+}
+
+type openAPIPathItem struct {
+	Get    *openAPIOperation `yaml:"get"`
+	Post   *openAPIOperation `yaml:"post"`
+	Put    *openAPIOperation `yaml:"put"`
+	Delete *openAPIOperation `yaml:"delete"`
+}
+
+// operation returns the documented operation of the method, or nil if there is none
+func (item openAPIPathItem) operation(method string) *openAPIOperation {
+	return map[string]*openAPIOperation{
+		http.MethodGet:    item.Get,
+		http.MethodPost:   item.Post,
+		http.MethodPut:    item.Put,
+		http.MethodDelete: item.Delete,
+	}[method]
+}
+
+type openAPIOperation struct {
+	Responses map[string]interface{} `yaml:"responses"`
+}
+
+// GET /sunchain/v1/openapi.yaml The OpenAPI document of the sunchain routes
+func getSunchainOpenAPI(t *testing.T, port string) openAPIDoc {
+	res, body := Request(t, port, "GET", "/sunchain/v1/openapi.yaml", nil)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+
+	var doc openAPIDoc
+	require.NoError(t, yaml.Unmarshal([]byte(body), &doc))
+	require.NotEmpty(t, doc.Paths)
+	return doc
+}
+
+// sunchainRequest makes a request to the sunchain v1 route with the given path template, filled with
+// params in order, and checks that the response status is documented for the route. Error
+// responses must have the documented error shape.
+func sunchainRequest(
+	t *testing.T, port string, doc openAPIDoc, method, template string, params []string, payload []byte,
+) (*http.Response, string) {
+	path := template
+	for _, param := range params {
+		start := strings.Index(path, "{")
+		end := strings.Index(path, "}")
+		require.True(t, start >= 0 && end > start, "too many params for %s", template)
+		path = path[:start] + param + path[end+1:]
+	}
+
+	res, body := Request(t, port, method, "/sunchain/v1"+path, payload)
+
+	item, ok := doc.Paths[template]
+	require.True(t, ok, "path %s is not documented", template)
+	operation := item.operation(method)
+	require.NotNil(t, operation, "%s %s is not documented", method, template)
+	_, ok = operation.Responses[strconv.Itoa(res.StatusCode)]
+	require.True(t, ok, "status %d of %s %s is not documented: %s", res.StatusCode, method, template, body)
+
+	if res.StatusCode >= http.StatusBadRequest {
+		var errRes rest.ErrorResponse
+		require.NoError(t, json.Unmarshal([]byte(body), &errRes), body)
+		require.NotEmpty(t, errRes.Error, body)
+	}
+	return res, body
+}
+
+// sunchainSignBytesRes is the response of /sunchain/v1/tx/sign-bytes
+type sunchainSignBytesRes struct {
+	ChainID       string `json:"chain_id"`
+	AccountNumber uint64 `json:"account_number"`
+	Sequence      uint64 `json:"sequence"`
+	SignBytes     string `json:"sign_bytes"`
+}
+
+// doSunchainTx builds a tx through the sunchain route, signs it with the sign bytes returned by
+// /sunchain/v1/tx/sign-bytes and broadcasts it through /sunchain/v1/tx/broadcast. It returns the msg
+// of the tx and the result of the broadcast.
+func doSunchainTx(
+	t *testing.T, port string, doc openAPIDoc, priv crypto.PrivKey, method, template string, params []string, req interface{},
+) (sdk.Msg, sdk.TxResponse) {
+	payload, err := cdc.MarshalJSON(req)
+	require.NoError(t, err)
+
+	res, body := sunchainRequest(t, port, doc, method, template, params, payload)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	var tx auth.StdTx
+	require.NoError(t, cdc.UnmarshalJSON([]byte(body), &tx))
+	require.Len(t, tx.Msgs, 1)
+
+	return tx.Msgs[0], signAndBroadcastSunchainTx(t, port, doc, priv, tx)
+}
+
+// signAndBroadcastSunchainTx signs the unsigned tx with priv through the sign bytes route, and
+// broadcasts it in block mode. The tx is required to succeed.
+func signAndBroadcastSunchainTx(t *testing.T, port string, doc openAPIDoc, priv crypto.PrivKey, tx auth.StdTx) sdk.TxResponse {
+	signReq := struct {
+		Tx auth.StdTx `json:"tx"`
+	}{Tx: tx}
+	res, body := sunchainRequest(t, port, doc, "POST", "/tx/sign-bytes", nil, cdc.MustMarshalJSON(signReq))
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	var signBytes sunchainSignBytesRes
+	require.NoError(t, cdc.UnmarshalJSON([]byte(body), &signBytes))
+	require.Equal(t, viper.GetString(flags.FlagChainID), signBytes.ChainID)
+
+	sig, err := priv.Sign([]byte(signBytes.SignBytes))
+	require.NoError(t, err)
+	tx.Signatures = []auth.StdSignature{{PubKey: priv.PubKey().Bytes(), Signature: sig}}
+
+	broadcastReq := struct {
+		Tx   auth.StdTx `json:"tx"`
+		Mode string     `json:"mode"`
+	}{Tx: tx, Mode: flags.BroadcastBlock}
+	res, body = sunchainRequest(t, port, doc, "POST", "/tx/broadcast", nil, cdc.MustMarshalJSON(broadcastReq))
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	var txRes sdk.TxResponse
+	require.NoError(t, cdc.UnmarshalJSON([]byte(body), &txRes))
+	require.Equal(t, uint32(0), txRes.Code, txRes.RawLog)
+
+	return txRes
+}
+
+// sunchainEventAttribute returns the value of the attribute key of the first event of the given
+// type emitted by the tx.
+func sunchainEventAttribute(t *testing.T, txRes sdk.TxResponse, eventType, key string) string {
+	for _, log := range txRes.Logs {
+		for _, event := range log.Events {
+			if event.Type != eventType {
+				continue
+			}
+			for _, attribute := range event.Attributes {
+				if attribute.Key == key {
+					return attribute.Value
+				}
+			}
+		}
+	}
+	require.Fail(t, "attribute not found", "no %s attribute in %s events: %s", key, eventType, txRes.RawLog)
+	return ""
+}
+
+// newSunchainBaseReq returns the base request of a sunchain tx route for the given account.
+func newSunchainBaseReq(addr sdk.AccAddress) rest.BaseReq {
+	return rest.NewBaseReq(addr.String(), "", viper.GetString(flags.FlagChainID), "", "", 0, 0, nil, nil, false)
+}
+
+// GET /sunchain/v1/products/{product} Get a product
+func getSunchainProduct(t *testing.T, port string, doc openAPIDoc, productID string) sunchain.Product {
+	res, body := sunchainRequest(t, port, doc, "GET", "/products/{product}", []string{productID}, nil)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+
+	var product sunchain.Product
+	require.NoError(t, cdc.UnmarshalJSON(extractResultFromResponse(t, []byte(body)), &product))
+	return product
+}
+
+// GET /sunchain/v1/sells/{sell} Get a sell
+func getSunchainSell(t *testing.T, port string, doc openAPIDoc, sellID string) sunchain.Sell {
+	res, body := sunchainRequest(t, port, doc, "GET", "/sells/{sell}", []string{sellID}, nil)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+
+	var sell sunchain.Sell
+	require.NoError(t, cdc.UnmarshalJSON(extractResultFromResponse(t, []byte(body)), &sell))
+	return sell
+}
+
+// GET /sunchain/v1/reservations/{reservation} Get a reservation
+func getSunchainReservation(t *testing.T, port string, doc openAPIDoc, reservationID string) sunchain.Reservation {
+	res, body := sunchainRequest(t, port, doc, "GET", "/reservations/{reservation}", []string{reservationID}, nil)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+
+	var reservation sunchain.Reservation
+	require.NoError(t, cdc.UnmarshalJSON(extractResultFromResponse(t, []byte(body)), &reservation))
+	return reservation
+}
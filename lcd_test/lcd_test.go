@@ -2,6 +2,7 @@
 package lcdtest
 
 import (
+	"bufio"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
@@ -14,10 +15,10 @@ import (
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
 
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	mintkey "github.com/cosmos/cosmos-sdk/crypto"
-	mintkey "github.com/cosmos/cosmos-sdk/crypto/"
 	keys "github.com/cosmos/cosmos-sdk/crypto/keyring"
 	"github.com/cosmos/cosmos-sdk/tests"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -30,6 +31,8 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/cosmos/cosmos-sdk/x/mint"
 	"github.com/cosmos/cosmos-sdk/x/slashing"
+
+	"github.com/trinhtan/cosmos-hackathon/x/sunchain"
 )
 
 const (
@@ -1112,3 +1115,351 @@ func TestAccountBalanceQuery(t *testing.T) {
 	require.Contains(t, body, "[]")
 
 }
+
+func TestSunchainMarketplace(t *testing.T) {
+	sellerKey := secp256k1.GenPrivKey()
+	seller := sdk.AccAddress(sellerKey.PubKey().Address())
+	buyerKey := secp256k1.GenPrivKey()
+	buyer := sdk.AccAddress(buyerKey.PubKey().Address())
+	cleanup, _, _, port, err := InitializeLCD(1, []sdk.AccAddress{seller, buyer}, true)
+	require.NoError(t, err)
+	defer cleanup()
+
+	doc := getSunchainOpenAPI(t, port)
+
+	// create and update a product
+	type productReq struct {
+		BaseReq     rest.BaseReq                `json:"base_req"`
+		Title       string                      `json:"title"`
+		Description string                      `json:"description"`
+		Category    string                      `json:"category"`
+		Images      []sunchain.ProductImage     `json:"images"`
+		Attributes  []sunchain.ProductAttribute `json:"attributes"`
+	}
+	images := []sunchain.ProductImage{{
+		URI:  "https://ipfs.io/ipfs/QmRing",
+		Hash: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+	}}
+	attributes := []sunchain.ProductAttribute{{Key: "weight_grams", Type: "decimal", Value: "3.5"}}
+	msg, txRes := doSunchainTx(t, port, doc, sellerKey, "POST", "/products", nil,
+		productReq{newSunchainBaseReq(seller), "Ring", "Gold ring", "jewelry", images, attributes})
+	productID := sunchainEventAttribute(t, txRes, sunchain.EventTypeProduct, sunchain.AttributeKeyProductID)
+	product := getSunchainProduct(t, port, doc, productID)
+	require.Equal(t, seller, product.Owner)
+	require.Equal(t, images, product.Images)
+	require.Equal(t, attributes, product.Attributes)
+
+	doSunchainTx(t, port, doc, sellerKey, "PUT", "/products/{product}", []string{productID},
+		productReq{newSunchainBaseReq(seller), "Ring", "18k gold ring", "jewelry", images, nil})
+	product = getSunchainProduct(t, port, doc, productID)
+	require.Equal(t, "18k gold ring", product.Description)
+	require.Empty(t, product.Attributes)
+
+	for _, invalid := range []productReq{
+		{newSunchainBaseReq(seller), "Ring", "", "Jewelry", nil, nil},
+		{newSunchainBaseReq(seller), "Ring", "", "jewelry", []sunchain.ProductImage{{URI: images[0].URI, Hash: "abc"}}, nil},
+		{newSunchainBaseReq(seller), "Ring", "", "jewelry", nil, []sunchain.ProductAttribute{{Key: "size", Type: "integer", Value: "XL"}}},
+	} {
+		payload, err := cdc.MarshalJSON(invalid)
+		require.NoError(t, err)
+		res, body := sunchainRequest(t, port, doc, "POST", "/products", nil, payload)
+		require.Equal(t, http.StatusBadRequest, res.StatusCode, body)
+	}
+
+	res, body := sunchainRequest(t, port, doc, "GET", "/products", nil, nil)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	require.Contains(t, body, productID)
+	res, body = sunchainRequest(t, port, doc, "GET", "/products/{product}", []string{"unknown"}, nil)
+	require.Equal(t, http.StatusNotFound, res.StatusCode, body)
+
+	// put the product on sale
+	msg, _ = doSunchainTx(t, port, doc, sellerKey, "POST", "/sells", nil, struct {
+		BaseReq   rest.BaseReq `json:"base_req"`
+		ProductID string       `json:"productID"`
+		MinPrice  string       `json:"minPrice"`
+	}{newSunchainBaseReq(seller), productID, "10stake"})
+	sellID := msg.(sunchain.MsgCreateSell).SellID
+	require.Equal(t, productID, getSunchainSell(t, port, doc, sellID).ProductID)
+
+	doSunchainTx(t, port, doc, sellerKey, "PUT", "/sells/{sell}", []string{sellID}, struct {
+		BaseReq  rest.BaseReq `json:"base_req"`
+		MinPrice string       `json:"minPrice"`
+	}{newSunchainBaseReq(seller), "20stake"})
+	require.Equal(t, "20stake", getSunchainSell(t, port, doc, sellID).MinPrice.String())
+
+	res, body = sunchainRequest(t, port, doc, "GET", "/sells", nil, nil)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	require.Contains(t, body, sellID)
+	res, body = sunchainRequest(t, port, doc, "POST", "/sells", nil, []byte("{"))
+	require.Equal(t, http.StatusBadRequest, res.StatusCode, body)
+
+	// make, update and accept an offer, then pay it
+	msg, _ = doSunchainTx(t, port, doc, buyerKey, "POST", "/reservations", nil, struct {
+		BaseReq rest.BaseReq `json:"base_req"`
+		SellID  string       `json:"sellID"`
+		Price   string       `json:"price"`
+	}{newSunchainBaseReq(buyer), sellID, "20stake"})
+	reservationID := msg.(sunchain.MsgCreateReservation).ReservationID
+	require.Equal(t, buyer, getSunchainReservation(t, port, doc, reservationID).Buyer)
+
+	doSunchainTx(t, port, doc, buyerKey, "PUT", "/reservations/{reservation}", []string{reservationID}, struct {
+		BaseReq rest.BaseReq `json:"base_req"`
+		Price   string       `json:"price"`
+	}{newSunchainBaseReq(buyer), "25stake"})
+	require.Equal(t, "25stake", getSunchainReservation(t, port, doc, reservationID).Price.String())
+
+	res, body = sunchainRequest(t, port, doc, "GET", "/reservations", nil, nil)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	require.Contains(t, body, reservationID)
+	res, body = sunchainRequest(t, port, doc, "GET", "/sells/{sell}/reservations", []string{sellID}, nil)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	require.Contains(t, body, reservationID)
+
+	doSunchainTx(t, port, doc, sellerKey, "POST", "/sells/{sell}/decision", []string{sellID}, struct {
+		BaseReq       rest.BaseReq `json:"base_req"`
+		ReservationID string       `json:"reservationID"`
+	}{newSunchainBaseReq(seller), reservationID})
+	require.True(t, getSunchainReservation(t, port, doc, reservationID).Decide)
+
+	doSunchainTx(t, port, doc, buyerKey, "POST", "/reservations/{reservation}/payment", []string{reservationID}, struct {
+		BaseReq rest.BaseReq `json:"base_req"`
+	}{newSunchainBaseReq(buyer)})
+	require.Equal(t, buyer, getSunchainProduct(t, port, doc, productID).Owner)
+	res, body = sunchainRequest(t, port, doc, "GET", "/sells/{sell}", []string{sellID}, nil)
+	require.Equal(t, http.StatusNotFound, res.StatusCode, body)
+
+	// the new owner sells again, and cancels after the only offer is withdrawn
+	soldID := sellID
+	msg, _ = doSunchainTx(t, port, doc, buyerKey, "POST", "/sells", nil, struct {
+		BaseReq   rest.BaseReq `json:"base_req"`
+		ProductID string       `json:"productID"`
+		MinPrice  string       `json:"minPrice"`
+	}{newSunchainBaseReq(buyer), productID, "30stake"})
+	sellID = msg.(sunchain.MsgCreateSell).SellID
+	msg, _ = doSunchainTx(t, port, doc, sellerKey, "POST", "/reservations", nil, struct {
+		BaseReq rest.BaseReq `json:"base_req"`
+		SellID  string       `json:"sellID"`
+		Price   string       `json:"price"`
+	}{newSunchainBaseReq(seller), sellID, "30stake"})
+	reservationID = msg.(sunchain.MsgCreateReservation).ReservationID
+	decision, err := cdc.MarshalJSON(struct {
+		BaseReq       rest.BaseReq `json:"base_req"`
+		ReservationID string       `json:"reservationID"`
+	}{newSunchainBaseReq(buyer), reservationID})
+	require.NoError(t, err)
+	res, body = sunchainRequest(t, port, doc, "POST", "/sells/{sell}/decision", []string{soldID}, decision)
+	require.Equal(t, http.StatusBadRequest, res.StatusCode, body)
+	doSunchainTx(t, port, doc, sellerKey, "DELETE", "/reservations/{reservation}", []string{reservationID}, struct {
+		BaseReq rest.BaseReq `json:"base_req"`
+	}{newSunchainBaseReq(seller)})
+	res, body = sunchainRequest(t, port, doc, "GET", "/reservations/{reservation}", []string{reservationID}, nil)
+	require.Equal(t, http.StatusNotFound, res.StatusCode, body)
+	doSunchainTx(t, port, doc, buyerKey, "DELETE", "/sells/{sell}", []string{sellID}, struct {
+		BaseReq rest.BaseReq `json:"base_req"`
+	}{newSunchainBaseReq(buyer)})
+	require.False(t, getSunchainProduct(t, port, doc, productID).Selling)
+
+	// accounts
+	res, body = sunchainRequest(t, port, doc, "GET", "/accounts/{address}", []string{buyer.String()}, nil)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	require.Contains(t, body, buyer.String())
+	res, body = sunchainRequest(t, port, doc, "GET", "/accounts/{address}/balance", []string{seller.String()}, nil)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	var balances sdk.Coins
+	require.NoError(t, cdc.UnmarshalJSON(extractResultFromResponse(t, []byte(body)), &balances))
+	require.Equal(t, sdk.TokensFromConsensusPower(100).AddRaw(25), balances.AmountOf(sdk.DefaultBondDenom))
+	res, body = sunchainRequest(t, port, doc, "GET", "/accounts/{address}/products", []string{buyer.String()}, nil)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	require.Contains(t, body, productID)
+	res, body = sunchainRequest(t, port, doc, "GET", "/accounts/{address}", []string{"invalid"}, nil)
+	require.Equal(t, http.StatusBadRequest, res.StatusCode, body)
+
+	// the LCD runs without a keyring
+	for _, template := range []string{"/names/{name}/address", "/names/{name}/products", "/names/{name}/balance"} {
+		res, body = sunchainRequest(t, port, doc, "GET", template, []string{name1}, nil)
+		require.Equal(t, http.StatusNotImplemented, res.StatusCode, body)
+	}
+}
+
+func TestSunchainCategories(t *testing.T) {
+	sellerKey := secp256k1.GenPrivKey()
+	seller := sdk.AccAddress(sellerKey.PubKey().Address())
+	cleanup, _, _, port, err := InitializeLCD(1, []sdk.AccAddress{seller}, true)
+	require.NoError(t, err)
+	defer cleanup()
+
+	doc := getSunchainOpenAPI(t, port)
+
+	res, body := sunchainRequest(t, port, doc, "GET", "/categories", nil, nil)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	var categories []sunchain.Category
+	require.NoError(t, cdc.UnmarshalJSON(extractResultFromResponse(t, []byte(body)), &categories))
+	require.Len(t, categories, len(sunchain.DefaultCategories()))
+
+	res, body = sunchainRequest(t, port, doc, "GET", "/categories/{category}", []string{"jewelry"}, nil)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	var category sunchain.Category
+	require.NoError(t, cdc.UnmarshalJSON(extractResultFromResponse(t, []byte(body)), &category))
+	require.Equal(t, "fashion", category.Parent)
+	res, body = sunchainRequest(t, port, doc, "GET", "/categories/{category}", []string{"furniture"}, nil)
+	require.Equal(t, http.StatusNotFound, res.StatusCode, body)
+
+	// products are listed in their category and in its ancestors
+	_, txRes := doSunchainTx(t, port, doc, sellerKey, "POST", "/products", nil, struct {
+		BaseReq  rest.BaseReq `json:"base_req"`
+		Title    string       `json:"title"`
+		Category string       `json:"category"`
+	}{newSunchainBaseReq(seller), "Ring", "jewelry"})
+	productID := sunchainEventAttribute(t, txRes, sunchain.EventTypeProduct, sunchain.AttributeKeyProductID)
+	for name, listed := range map[string]bool{"jewelry": true, "fashion": true, "shoes": false} {
+		res, body = sunchainRequest(t, port, doc, "GET", "/categories/{category}/products", []string{name}, nil)
+		require.Equal(t, http.StatusOK, res.StatusCode, body)
+		require.Equal(t, listed, strings.Contains(body, productID), name)
+	}
+
+	// categories are changed by governance
+	proposal, err := cdc.MarshalJSON(struct {
+		BaseReq     rest.BaseReq        `json:"base_req"`
+		Title       string              `json:"title"`
+		Description string              `json:"description"`
+		Set         []sunchain.Category `json:"set"`
+		Proposer    sdk.AccAddress      `json:"proposer"`
+		Deposit     sdk.Coins           `json:"deposit"`
+	}{
+		newSunchainBaseReq(seller), "Watches", "Add a watches category",
+		[]sunchain.Category{sunchain.NewCategory("watches", "jewelry", "Watches", sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 10)), []string{"brand"})},
+		seller, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 10)),
+	})
+	require.NoError(t, err)
+	res, body = Request(t, port, "POST", "/gov/proposals/sunchain_category_change", proposal)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	var tx auth.StdTx
+	require.NoError(t, cdc.UnmarshalJSON([]byte(body), &tx))
+	signAndBroadcastSunchainTx(t, port, doc, sellerKey, tx)
+
+	res, body = Request(t, port, "GET", "/gov/proposals/1", nil)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	var submitted gov.Proposal
+	require.NoError(t, cdc.UnmarshalJSON(extractResultFromResponse(t, []byte(body)), &submitted))
+	require.Equal(t, sunchain.ProposalTypeCategoryChange, submitted.ProposalType())
+}
+
+func TestSunchainCORS(t *testing.T) {
+	cleanup, _, _, port, err := InitializeLCD(1, []sdk.AccAddress{}, true)
+	require.NoError(t, err)
+	defer cleanup()
+
+	req, err := http.NewRequest(http.MethodOptions, fmt.Sprintf("http://localhost:%s/sunchain/reservations", port), nil)
+	require.NoError(t, err)
+	req.Header.Set("Origin", "http://localhost:8080")
+	req.Header.Set("Access-Control-Request-Method", http.MethodDelete)
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	res.Body.Close()
+	require.Equal(t, http.StatusNoContent, res.StatusCode)
+	require.Equal(t, "*", res.Header.Get("Access-Control-Allow-Origin"))
+	require.Contains(t, res.Header.Get("Access-Control-Allow-Methods"), http.MethodDelete)
+}
+
+func TestSunchainEvents(t *testing.T) {
+	sellerKey := secp256k1.GenPrivKey()
+	seller := sdk.AccAddress(sellerKey.PubKey().Address())
+	cleanup, _, _, port, err := InitializeLCD(1, []sdk.AccAddress{seller}, true)
+	require.NoError(t, err)
+	defer cleanup()
+
+	doc := getSunchainOpenAPI(t, port)
+
+	res, err := http.Get(fmt.Sprintf("http://localhost:%s/sunchain/v1/events?owner=%s", port, seller))
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+	_, txRes := doSunchainTx(t, port, doc, sellerKey, "POST", "/products", nil, struct {
+		BaseReq  rest.BaseReq `json:"base_req"`
+		Title    string       `json:"title"`
+		Category string       `json:"category"`
+	}{newSunchainBaseReq(seller), "Ring", "jewelry"})
+	productID := sunchainEventAttribute(t, txRes, sunchain.EventTypeProduct, sunchain.AttributeKeyProductID)
+
+	events := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(res.Body)
+		for scanner.Scan() {
+			if line := scanner.Text(); strings.HasPrefix(line, "data: ") {
+				events <- strings.TrimPrefix(line, "data: ")
+				return
+			}
+		}
+	}()
+	select {
+	case event := <-events:
+		require.Contains(t, event, `"type":"product"`)
+		require.Contains(t, event, productID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event received")
+	}
+
+	// a browser reconnecting gets the events it missed since its last event id
+	req, err := http.NewRequest("GET", fmt.Sprintf("http://localhost:%s/sunchain/v1/events?owner=%s", port, seller), nil)
+	require.NoError(t, err)
+	req.Header.Set("Last-Event-ID", "0-0")
+	replay, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer replay.Body.Close()
+	scanner := bufio.NewScanner(replay.Body)
+	var id string
+	for scanner.Scan() && id == "" {
+		if line := scanner.Text(); strings.HasPrefix(line, "id: ") {
+			id = strings.TrimPrefix(line, "id: ")
+		}
+	}
+	require.Regexp(t, `^[0-9]+-0$`, id)
+}
+
+func TestSunchainDeprecatedRoutes(t *testing.T) {
+	sellerKey := secp256k1.GenPrivKey()
+	seller := sdk.AccAddress(sellerKey.PubKey().Address())
+	cleanup, _, _, port, err := InitializeLCD(1, []sdk.AccAddress{seller}, true)
+	require.NoError(t, err)
+	defer cleanup()
+
+	res, body := Request(t, port, "GET", "/sunchain/v1/products", nil)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	require.Empty(t, res.Header.Get("Deprecation"))
+
+	res, body = Request(t, port, "GET", "/sunchain/products", nil)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	require.Equal(t, "true", res.Header.Get("Deprecation"))
+	require.Equal(t, `</sunchain/v1/openapi.yaml>; rel="deprecation"`, res.Header.Get("Link"))
+
+	// routes added along with v1 have no deprecated alias
+	res, body = Request(t, port, "GET", "/sunchain/openapi.yaml", nil)
+	require.Equal(t, http.StatusNotFound, res.StatusCode, body)
+	res, body = Request(t, port, "GET", fmt.Sprintf("/sunchain/accounts/%s", seller), nil)
+	require.Equal(t, http.StatusNotFound, res.StatusCode, body)
+
+	// the deprecated update and delete routes read the id from the body
+	payload, err := cdc.MarshalJSON(struct {
+		BaseReq rest.BaseReq `json:"base_req"`
+		SellID  string       `json:"sellID"`
+	}{newSunchainBaseReq(seller), "sell-1"})
+	require.NoError(t, err)
+	res, body = Request(t, port, "POST", "/sunchain/cancelSell", payload)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	require.Equal(t, "true", res.Header.Get("Deprecation"))
+	var tx auth.StdTx
+	require.NoError(t, cdc.UnmarshalJSON([]byte(body), &tx))
+	require.Equal(t, sunchain.NewMsgDeleteSell("sell-1", seller), tx.Msgs[0])
+
+	payload, err = cdc.MarshalJSON(struct {
+		BaseReq       rest.BaseReq `json:"base_req"`
+		ReservationID string       `json:"reservationID"`
+	}{newSunchainBaseReq(seller), "reservation-1"})
+	require.NoError(t, err)
+	res, body = Request(t, port, "POST", "/sunchain/reservations/payReservation", payload)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	require.NoError(t, cdc.UnmarshalJSON([]byte(body), &tx))
+	require.Equal(t, sunchain.NewMsgPayReservation("reservation-1", nil, seller), tx.Msgs[0])
+}
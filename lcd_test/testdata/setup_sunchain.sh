@@ -0,0 +1,25 @@
+#!/usr/bin/env bash
+
+# seeds the product, sell and reservation which the ids of the examples of the sunchain OpenAPI
+# document refer to, and replaces the example product id with the id the chain assigns
+
+PASSWORD="1234567890"
+ADDR="cosmos16xyempempp92x9hyzz9wrgf94r6j9h5f06pxxv"
+CHAIN="lcd"
+HOME="/tmp/contract_tests/.gaiacli"
+SPEC='/tmp/contract_tests/sunchain.yaml'
+METADATA='/tmp/contract_tests/product.json'
+
+cat > ${METADATA} <<JSON
+{"title": "ring", "description": "contract tests", "category": "jewelry", "images": [], "attributes": []}
+JSON
+
+sleep 1s
+PRODUCT=$(echo ${PASSWORD} | ./build/bccli tx sunchain create-product ${METADATA} --min-price 100stake --sell-id sell-1 \
+  --home ${HOME} --from ${ADDR} --chain-id ${CHAIN} --broadcast-mode block --output json --yes |
+  jq -r '.logs[0].events[] | select(.type == "product") | .attributes[] | select(.key == "product_id") | .value')
+sed -i.bak -e "s/example: product-1$/example: ${PRODUCT}/" "${SPEC}"
+echo "Replaced the example product id with ${PRODUCT}"
+sleep 1s
+echo ${PASSWORD} | ./build/bccli tx sunchain create-reservation reservation-1 sell-1 100stake \
+  --home ${HOME} --from ${ADDR} --chain-id ${CHAIN} --broadcast-mode block --yes
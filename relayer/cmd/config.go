@@ -227,10 +227,10 @@ func (c *Config) ChainsFromPath(path string) (map[string]*relayer.Chain, string,
 		return nil, "", "", err
 	}
 
-	if err = chains[src].SetPath(pth.Src); err != nil {
+	if chains[src], err = chains[src].ForPath(path, pth.Src); err != nil {
 		return nil, "", "", err
 	}
-	if err = chains[dst].SetPath(pth.Dst); err != nil {
+	if chains[dst], err = chains[dst].ForPath(path, pth.Dst); err != nil {
 		return nil, "", "", err
 	}
 
@@ -256,16 +256,30 @@ func defaultConfig() []byte {
 
 // GlobalConfig describes any global relayer settings
 type GlobalConfig struct {
-	Timeout       string `yaml:"timeout" json:"timeout"`
-	LiteCacheSize int    `yaml:"lite-cache-size" json:"lite-cache-size"`
+	Timeout       string  `yaml:"timeout" json:"timeout"`
+	LiteCacheSize int     `yaml:"lite-cache-size" json:"lite-cache-size"`
+	ClientRefresh float64 `yaml:"client-refresh,omitempty" json:"client-refresh,omitempty"`
 }
 
+// defaultClientRefresh is the fraction of their trusting period after which clients are updated
+const defaultClientRefresh = 0.5
+
 // newDefaultGlobalConfig returns a global config with defaults set
 func newDefaultGlobalConfig() GlobalConfig {
 	return GlobalConfig{
 		Timeout:       "10s",
 		LiteCacheSize: 20,
+		ClientRefresh: defaultClientRefresh,
+	}
+}
+
+// clientRefresh returns the configured fraction of the trusting period after which clients are
+// updated, which defaults to defaultClientRefresh for configs without one
+func (g GlobalConfig) clientRefresh() float64 {
+	if g.ClientRefresh == 0 {
+		return defaultClientRefresh
 	}
+	return g.ClientRefresh
 }
 
 // AddChain adds an additional chain to the config
@@ -302,10 +316,16 @@ func validateConfig(c *Config) error {
 		return err
 	}
 
+	if c.Global.ClientRefresh < 0 || c.Global.ClientRefresh >= 1 {
+		return fmt.Errorf("client-refresh must be a fraction of the trusting period between 0 and 1, got %v",
+			c.Global.ClientRefresh)
+	}
+
 	for _, i := range c.Chains {
 		if err := i.Init(homePath, appCodec, cdc, to, debug); err != nil {
 			return err
 		}
+		i.SetLogger(logger)
 	}
 
 	return nil
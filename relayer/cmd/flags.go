@@ -20,6 +20,10 @@ var (
 	flagFile       = "file"
 	flagPath       = "path"
 	flagListenAddr = "listen"
+	flagAll        = "all"
+	flagMetrics    = "metrics"
+	flagLogFormat  = "log-format"
+	flagLogLevel   = "log-level"
 )
 
 func liteFlags(cmd *cobra.Command) *cobra.Command {
@@ -58,12 +62,28 @@ func yamlFlag(cmd *cobra.Command) *cobra.Command {
 	return cmd
 }
 
+func allFlag(cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().BoolP(flagAll, "a", false, "run all the configured paths")
+	if err := viper.BindPFlag(flagAll, cmd.Flags().Lookup(flagAll)); err != nil {
+		panic(err)
+	}
+	return cmd
+}
+
 func chainsAddFlags(cmd *cobra.Command) *cobra.Command {
 	fileFlag(cmd)
 	urlFlag(cmd)
 	return cmd
 }
 
+func metricsFlag(cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().StringP(flagMetrics, "m", "", "serve prometheus metrics and health checks on this address, e.g. 0.0.0.0:9090")
+	if err := viper.BindPFlag(flagMetrics, cmd.Flags().Lookup(flagMetrics)); err != nil {
+		panic(err)
+	}
+	return cmd
+}
+
 func listenFlag(cmd *cobra.Command) *cobra.Command {
 	cmd.Flags().StringP(flagListenAddr, "l", "0.0.0.0:8000", "sets the faucet listener addresss")
 	if err := viper.BindPFlag(flagListenAddr, cmd.Flags().Lookup(flagListenAddr)); err != nil {
@@ -112,6 +132,14 @@ func forceFlag(cmd *cobra.Command) *cobra.Command {
 	return cmd
 }
 
+func forceUpdateFlag(cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().BoolP(flagForce, "f", false, "update the clients regardless of their age")
+	if err := viper.BindPFlag(flagForce, cmd.Flags().Lookup(flagForce)); err != nil {
+		panic(err)
+	}
+	return cmd
+}
+
 func flagsFlag(cmd *cobra.Command) *cobra.Command {
 	cmd.Flags().BoolP(flagFlags, "f", false, "pass flag to output the flags for lite init/update")
 	if err := viper.BindPFlag(flagFlags, cmd.Flags().Lookup(flagFlags)); err != nil {
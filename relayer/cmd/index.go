@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+func indexCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "index",
+		Aliases: []string{"idx"},
+		Short:   "commands to manage the local index of the packets sent on the configured chains",
+	}
+	cmd.AddCommand(
+		indexBackfillCmd(),
+		indexPacketCmd(),
+	)
+	return cmd
+}
+
+func indexBackfillCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "backfill [chain-id] [from-height] [to-height]",
+		Aliases: []string{"bf"},
+		Short:   "Index the packets sent on a chain in a range of blocks",
+		Long: `Index the packets sent on a chain in the blocks from one height to another, both included.
+The packets are read from the results of the blocks, so this works with nodes which do not index txs.`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chain, err := config.Chains.Get(args[0])
+			if err != nil {
+				return err
+			}
+
+			from, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			to, err := strconv.ParseInt(args[2], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			if from < 1 || to < from {
+				return fmt.Errorf("invalid height range %d to %d", from, to)
+			}
+
+			indexed, err := chain.BackfillPacketIndex(from, to)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("indexed %d packets sent on %s from height %d to %d\n", indexed, chain.ChainID, from, to)
+			return nil
+		},
+	}
+	return cmd
+}
+
+func indexPacketCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "packet [chain-id] [channel-id] [port-id] [seq]",
+		Aliases: []string{"pkt"},
+		Short:   "Query the packet index for a packet sent on a chain",
+		Args:    cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chain, err := config.Chains.Get(args[0])
+			if err != nil {
+				return err
+			}
+
+			if err = chain.AddPath(dcli, dcon, args[1], args[2]); err != nil {
+				return err
+			}
+
+			seq, err := strconv.ParseUint(args[3], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			p, found, err := chain.PacketIndex().Get(chain.PathEnd, seq)
+			switch {
+			case err != nil:
+				return err
+			case !found:
+				return fmt.Errorf("packet %d sent on %s/%s is not indexed on %s", seq, args[2], args[1], chain.ChainID)
+			}
+
+			return chain.Print(p, false, false)
+		},
+	}
+	return cmd
+}
@@ -32,6 +32,7 @@ func init() {
 	queryCmd.AddCommand(queryTxs())
 	queryCmd.AddCommand(queryTx())
 	queryCmd.AddCommand(queryUnrelayed())
+	queryCmd.AddCommand(queryQueue())
 	queryCmd.AddCommand(queryFullPathCmd())
 }
 
@@ -599,10 +600,9 @@ func queryPacketAck() *cobra.Command {
 
 func queryUnrelayed() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "unrelayed [path]",
-		Aliases: []string{"queue"},
-		Short:   "Query for the packets that remain to be relayed on a given path",
-		Args:    cobra.ExactArgs(1),
+		Use:   "unrelayed [path]",
+		Short: "Query for the packets that remain to be relayed on a given path",
+		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			path, err := config.Paths.Get(args[0])
 			if err != nil {
@@ -639,6 +639,43 @@ func queryUnrelayed() *cobra.Command {
 	return cmd
 }
 
+func queryQueue() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "queue [path]",
+		Short: "Query the jobs which the relayer has queued for retrying on a given path",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := config.Paths.Get(args[0])
+			if err != nil {
+				return err
+			}
+			src, dst := path.Src.ChainID, path.Dst.ChainID
+
+			c, err := config.Chains.Gets(src, dst)
+			if err != nil {
+				return err
+			}
+
+			queue := relayer.NewRelayQueue(homePath)
+			srcJobs, err := queue.Jobs(path.Src)
+			if err != nil {
+				return err
+			}
+			dstJobs, err := queue.Jobs(path.Dst)
+			if err != nil {
+				return err
+			}
+
+			return c[src].Print(struct {
+				Src []relayer.RelayJob `json:"src" yaml:"src"`
+				Dst []relayer.RelayJob `json:"dst" yaml:"dst"`
+			}{srcJobs, dstJobs}, false, false)
+		},
+	}
+
+	return cmd
+}
+
 func queryFullPathCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "full-path [path-name]",
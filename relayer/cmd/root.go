@@ -25,14 +25,19 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	codecstd "github.com/cosmos/cosmos-sdk/codec/std"
 	"github.com/cosmos/cosmos-sdk/simapp"
+	"github.com/iqlusioninc/relayer/relayer"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/tendermint/tendermint/libs/log"
 )
 
 var (
 	cfgPath     string
 	homePath    string
 	debug       bool
+	logFormat   string
+	logLevel    string
+	logger      log.Logger
 	config      *Config
 	defaultHome = os.ExpandEnv("$HOME/.relayer")
 	cdc         *codec.Codec
@@ -51,6 +56,8 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&homePath, flags.FlagHome, defaultHome, "set home directory")
 	rootCmd.PersistentFlags().BoolVarP(&debug, "debug", "d", false, "debug output")
 	rootCmd.PersistentFlags().StringVar(&cfgPath, flagConfig, "config.yaml", "set config file")
+	rootCmd.PersistentFlags().StringVar(&logFormat, flagLogFormat, relayer.LogFormatText, "log format (text|json)")
+	rootCmd.PersistentFlags().StringVar(&logLevel, flagLogLevel, "info", "log level (debug|info|error), debug with --debug")
 	if err := viper.BindPFlag(flags.FlagHome, rootCmd.Flags().Lookup(flags.FlagHome)); err != nil {
 		panic(err)
 	}
@@ -60,6 +67,12 @@ func init() {
 	if err := viper.BindPFlag("debug", rootCmd.Flags().Lookup("debug")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag(flagLogFormat, rootCmd.Flags().Lookup(flagLogFormat)); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag(flagLogLevel, rootCmd.Flags().Lookup(flagLogLevel)); err != nil {
+		panic(err)
+	}
 
 	// Register subcommands
 	rootCmd.AddCommand(
@@ -74,6 +87,7 @@ func init() {
 		getVersionCmd(),
 		testnetsCmd(),
 		servicesCommand(),
+		indexCmd(),
 	)
 
 	// This is a bit of a cheat :shushing_face:
@@ -91,6 +105,9 @@ var rootCmd = &cobra.Command{
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
 	rootCmd.PersistentPreRunE = func(_ *cobra.Command, _ []string) error {
+		if err := initLogger(); err != nil {
+			return err
+		}
 		// reads `homeDir/config/config.yaml` into `var config *Config` before each command
 		return initConfig(rootCmd)
 	}
@@ -101,6 +118,16 @@ func Execute() {
 	}
 }
 
+// initLogger sets up the logger of the relayer from the --log-format and --log-level flags
+func initLogger() (err error) {
+	level := logLevel
+	if debug {
+		level = "debug"
+	}
+	logger, err = relayer.NewLogger(logFormat, level)
+	return err
+}
+
 // readLineFromBuf reads one line from stdin.
 func readStdin() (string, error) {
 	str, err := bufio.NewReader(os.Stdin).ReadString('\n')
@@ -19,40 +19,170 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"sort"
+	"strings"
+	"sync"
 	"syscall"
 
+	"github.com/iqlusioninc/relayer/relayer"
 	"github.com/spf13/cobra"
 )
 
 // startCmd represents the start command
-// NOTE: This is basically psuedocode
 func startCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "start [path-name]",
+		Use:     "start [path-name...]",
 		Aliases: []string{"st"},
-		Short:   "Start runs the relayer strategy associated with a path between the two chains",
-		Args:    cobra.ExactArgs(1),
-		RunE: func(cmd *cobra.Command, args []string) error {
-			c, src, dst, err := config.ChainsFromPath(args[0])
+		Short:   "Start runs the relayer strategies associated with paths between chains",
+		Long: strings.TrimSpace(`Start runs the relayer strategies of the given paths, or of all the configured paths with --all.
+The paths share one RPC client, one event subscription, one lite client database and one packet
+indexer per chain, and each path is restarted independently when it fails to start. The clients
+of each path are updated once they are older than the client-refresh fraction of their trusting
+period. The packets sent on each chain are recorded in the packet index of the relayer home,
+which first catches up on the blocks produced since the relayer last ran.
+
+With --metrics the relayer serves Prometheus metrics on /metrics, a liveness check on /healthz
+and a readiness check on /readyz, which succeeds once the strategies of all the paths are running.`),
+		Args: func(cmd *cobra.Command, args []string) error {
+			all, err := cmd.Flags().GetBool(flagAll)
 			if err != nil {
 				return err
 			}
+			switch {
+			case all && len(args) > 0:
+				return fmt.Errorf("cannot pass path names along with --%s", flagAll)
+			case !all && len(args) == 0:
+				return fmt.Errorf("pass at least one path name or --%s", flagAll)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			names := args
+			if all, _ := cmd.Flags().GetBool(flagAll); all {
+				for name := range config.Paths {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+			}
 
-			strategy, err := config.Paths.MustGet(args[0]).GetStrategy()
-			if err != nil {
-				return err
+			type pathRun struct {
+				name     string
+				strategy relayer.Strategy
+				src, dst *relayer.Chain
 			}
 
-			done, err := strategy.Run(c[src], c[dst])
+			// every path gets its own copy of its chains, which share the clients of the chains
+			runs := []pathRun{}
+			held := map[string]bool{}
+			chains := []*relayer.Chain{}
+			for _, name := range names {
+				path, err := config.Paths.Get(name)
+				if err != nil {
+					return err
+				}
+
+				strategy, err := path.GetStrategy()
+				if err != nil {
+					return err
+				}
+
+				c, err := config.Chains.Gets(path.Src.ChainID, path.Dst.ChainID)
+				if err != nil {
+					return err
+				}
+
+				for _, chain := range c {
+					if held[chain.ChainID] {
+						continue
+					}
+					release, err := chain.HoldLiteDB()
+					if err != nil {
+						return err
+					}
+					defer release()
+					releaseIndex, err := chain.HoldPacketIndex()
+					if err != nil {
+						return err
+					}
+					defer releaseIndex()
+					held[chain.ChainID] = true
+					chains = append(chains, chain)
+				}
+
+				src, err := c[path.Src.ChainID].ForPath(name, path.Src)
+				if err != nil {
+					return err
+				}
+				dst, err := c[path.Dst.ChainID].ForPath(name, path.Dst)
+				if err != nil {
+					return err
+				}
+
+				runs = append(runs, pathRun{name, strategy, src, dst})
+			}
+
+			metricsAddr, err := cmd.Flags().GetString(flagMetrics)
 			if err != nil {
 				return err
 			}
 
-			trapSignal(done)
+			// index the packets sent while the relayer was not running before relaying any
+			for _, chain := range chains {
+				if err = chain.CatchUpPacketIndex(); err != nil {
+					chain.Error(err)
+				}
+			}
+
+			stop := make(chan struct{})
+			var wg sync.WaitGroup
+			for _, chain := range chains {
+				wg.Add(1)
+				go func(chain *relayer.Chain) {
+					defer wg.Done()
+					chain.IndexPackets(stop)
+				}(chain)
+			}
+			for _, run := range runs {
+				wg.Add(1)
+				go func(run pathRun) {
+					defer wg.Done()
+					relayer.SupervisePath(run.name, run.strategy, run.src, run.dst, stop)
+				}(run)
+
+				wg.Add(1)
+				go func(run pathRun) {
+					defer wg.Done()
+					relayer.RefreshClients(run.src, run.dst, config.Global.clientRefresh(), stop)
+				}(run)
+
+				if metricsAddr != "" {
+					wg.Add(1)
+					go func(run pathRun) {
+						defer wg.Done()
+						relayer.CollectMetrics(run.src, run.dst, stop)
+					}(run)
+				}
+			}
+
+			if metricsAddr != "" {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					logger.Info("serving metrics and health checks", "address", metricsAddr)
+					if err := relayer.ServeMetrics(metricsAddr, stop); err != nil {
+						logger.Error("metrics server failed", "error", err.Error())
+					}
+				}()
+			}
+
+			trapSignal(func() {
+				close(stop)
+				wg.Wait()
+			})
 			return nil
 		},
 	}
-	return cmd
+	return metricsFlag(allFlag(cmd))
 }
 
 // trap signal waits for a SIGINT or SIGTERM and then sends down the done channel
@@ -63,7 +193,7 @@ func trapSignal(done func()) {
 
 	// wait for a signal
 	sig := <-sigCh
-	fmt.Println("Signal Recieved:", sig.String())
+	logger.Info("signal received", "signal", sig.String())
 	close(sigCh)
 
 	// call the cleanup func
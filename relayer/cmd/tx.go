@@ -30,6 +30,7 @@ func transactionCmd() *cobra.Command {
 
 	cmd.AddCommand(
 		createClientsCmd(),
+		updateClientsCmd(),
 		createConnectionCmd(),
 		createChannelCmd(),
 		closeChannelCmd(),
@@ -60,6 +61,30 @@ func createClientsCmd() *cobra.Command {
 	return cmd
 }
 
+func updateClientsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "update-clients [path-name]",
+		Aliases: []string{"update", "upd"},
+		Short:   "update the clients of a configured path which are close to expiring",
+		Long:    "Update the lite clients of both chains of the path and then the clients of the path which are older than the client-refresh fraction of their trusting period, or both clients with --force",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, src, dst, err := config.ChainsFromPath(args[0])
+			if err != nil {
+				return err
+			}
+
+			force, err := cmd.Flags().GetBool(flagForce)
+			if err != nil {
+				return err
+			}
+
+			return c[src].UpdateClients(c[dst], config.Global.clientRefresh(), force)
+		},
+	}
+	return forceUpdateFlag(cmd)
+}
+
 func createConnectionCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "connection [path-name]",
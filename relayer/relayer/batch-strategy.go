@@ -0,0 +1,504 @@
+package relayer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	chanTypes "github.com/cosmos/cosmos-sdk/x/ibc/04-channel/types"
+	tmclient "github.com/cosmos/cosmos-sdk/x/ibc/07-tendermint/types"
+)
+
+// Constraints of the batch strategy. Lists are comma separated and empty lists allow everything.
+const (
+	// BatchPorts are the ports which packets may be sent from
+	BatchPorts = "ports"
+	// BatchChannels are the channels which packets may be sent from
+	BatchChannels = "channels"
+	// BatchDenoms are the denominations which ICS-20 packets may transfer, either with or
+	// without their port/channel prefix
+	BatchDenoms = "denoms"
+	// BatchSenders are the addresses which ICS-20 packets may be sent by
+	BatchSenders = "senders"
+	// BatchMaxMsgs is the maximum number of messages in a transaction
+	BatchMaxMsgs = "max-msgs"
+	// BatchMinDelay is how long packets are collected before being relayed together
+	BatchMinDelay = "min-delay"
+)
+
+var (
+	defaultBatchMaxMsgs  = 10
+	defaultBatchMinDelay = time.Duration(0)
+)
+
+// NewBatchStrategy returns a new BatchStrategy config
+func NewBatchStrategy() *StrategyCfg {
+	return &StrategyCfg{
+		Type:        BatchStrategy{}.GetType(),
+		Constraints: map[string]string{},
+	}
+}
+
+// BatchStrategy is a relaying strategy which only relays the packets that pass its filters and
+// relays them in batches sharing a single client update
+type BatchStrategy struct {
+	Ports    []string
+	Channels []string
+	Denoms   []string
+	Senders  []string
+	MaxMsgs  int
+	MinDelay time.Duration
+}
+
+// Init implements Strategy
+func (bs BatchStrategy) Init(sc *StrategyCfg) (Strategy, error) {
+	if sc.Type != bs.GetType() {
+		return nil, fmt.Errorf("wrong type")
+	}
+
+	out := BatchStrategy{MaxMsgs: defaultBatchMaxMsgs, MinDelay: defaultBatchMinDelay}
+	for key, val := range sc.Constraints {
+		switch key {
+		case BatchPorts:
+			out.Ports = splitList(val)
+		case BatchChannels:
+			out.Channels = splitList(val)
+		case BatchDenoms:
+			out.Denoms = splitList(val)
+		case BatchSenders:
+			out.Senders = splitList(val)
+		case BatchMaxMsgs:
+			max, err := strconv.Atoi(val)
+			if err != nil || max < 2 {
+				return nil, fmt.Errorf("invalid constraint %s: %q must be an integer of at least 2", key, val)
+			}
+			out.MaxMsgs = max
+		case BatchMinDelay:
+			delay, err := time.ParseDuration(val)
+			if err != nil || delay < 0 {
+				return nil, fmt.Errorf("invalid constraint %s: %q must be a positive duration", key, val)
+			}
+			out.MinDelay = delay
+		default:
+			return nil, fmt.Errorf("invalid constraint %s", key)
+		}
+	}
+	return out, nil
+}
+
+// Cfg implements Strategy
+func (bs BatchStrategy) Cfg() *StrategyCfg {
+	return &StrategyCfg{
+		Type:        bs.GetType(),
+		Constraints: bs.GetConstraints(),
+	}
+}
+
+// GetType implements Strategy
+func (bs BatchStrategy) GetType() string {
+	return "batch"
+}
+
+// GetConstraints implements Strategy
+func (bs BatchStrategy) GetConstraints() map[string]string {
+	out := map[string]string{
+		BatchMaxMsgs:  strconv.Itoa(bs.MaxMsgs),
+		BatchMinDelay: bs.MinDelay.String(),
+	}
+	lists := map[string][]string{
+		BatchPorts:    bs.Ports,
+		BatchChannels: bs.Channels,
+		BatchDenoms:   bs.Denoms,
+		BatchSenders:  bs.Senders,
+	}
+	for key, list := range lists {
+		if len(list) > 0 {
+			out[key] = strings.Join(list, ",")
+		}
+	}
+	return out
+}
+
+// Run implements Strategy and defines what actions are taken when the relayer runs
+func (bs BatchStrategy) Run(src, dst *Chain) (func(), error) {
+	b := &batcher{BatchStrategy: bs, src: src, dst: dst, jobs: src.RelayQueue()}
+
+	// first, queue the packets which remain to be relayed
+	if err := b.queueUnrelayed(); err != nil {
+		return nil, err
+	}
+
+	// relay what was queued at startup without waiting for a block
+	go b.flush()
+
+	return startRelayLoop(src, dst, relayHandlers{
+		srcTx: func(events map[string][]string) {
+			b.queueEvents(src, dst, events)
+		},
+		dstTx: func(events map[string][]string) {
+			b.queueEvents(dst, src, events)
+		},
+		srcBlock: func(map[string][]string) {
+			go b.flush()
+			go relayTimeouts(dst, src, &b.dstTimeouts, b.Relays)
+			go relayQueued(src, dst, b.jobs, &b.retrying)
+		},
+		dstBlock: func(map[string][]string) {
+			go b.flush()
+			go relayTimeouts(src, dst, &b.srcTimeouts, b.Relays)
+			go relayQueued(src, dst, b.jobs, &b.retrying)
+		},
+	}), nil
+}
+
+// RelaysChannel returns whether the strategy relays packets sent from the port and channel of
+// the packet
+func (bs BatchStrategy) RelaysChannel(packet chanTypes.Packet) bool {
+	return allowed(bs.Ports, packet.SourcePort) && allowed(bs.Channels, packet.SourceChannel)
+}
+
+// RelaysData returns whether the strategy relays packets with the given data. When denominations
+// or senders are constrained, only ICS-20 transfers which match them are relayed.
+func (bs BatchStrategy) RelaysData(data []byte) bool {
+	if len(bs.Denoms) == 0 && len(bs.Senders) == 0 {
+		return true
+	}
+
+	// transfers are encoded with amino, which wraps the packet data with its type
+	var wrapped struct {
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &wrapped); err == nil && len(wrapped.Value) != 0 {
+		data = wrapped.Value
+	}
+
+	// the sender is kept as a string as its bech32 prefix is the one of the sending chain
+	var transfer struct {
+		Amount sdk.Coins `json:"amount"`
+		Sender string    `json:"sender"`
+	}
+	if err := json.Unmarshal(data, &transfer); err != nil || len(transfer.Amount) == 0 {
+		return false
+	}
+
+	if !allowed(bs.Senders, transfer.Sender) {
+		return false
+	}
+	for _, coin := range transfer.Amount {
+		base := coin.Denom[strings.LastIndex(coin.Denom, "/")+1:]
+		if !allowed(bs.Denoms, coin.Denom) && !allowed(bs.Denoms, base) {
+			return false
+		}
+	}
+	return true
+}
+
+// Relays returns whether the strategy relays the packet
+func (bs BatchStrategy) Relays(packet chanTypes.Packet) bool {
+	return bs.RelaysChannel(packet) && bs.RelaysData(packet.Data)
+}
+
+func allowed(list []string, val string) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, l := range list {
+		if l == val {
+			return true
+		}
+	}
+	return false
+}
+
+func splitList(val string) []string {
+	out := []string{}
+	for _, v := range strings.Split(val, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// batchPacket is a packet waiting to be relayed, either to be received or to have its
+// acknowledgement returned
+type batchPacket struct {
+	packet chanTypes.Packet
+	// ack is the acknowledgement to return, nil for packets to receive
+	ack []byte
+	// height is the height of the counterparty which the proof must be queried at or after
+	height   int64
+	queuedAt time.Time
+}
+
+// job returns the job persisting the packet in the relay queue until it is relayed to the chain
+func (bp batchPacket) job(to *Chain) RelayJob {
+	if bp.ack == nil {
+		job := NewRelayJob(to.PathEnd, RelayJobRecv, bp.packet.Sequence)
+		job.Data, job.Timeout = string(bp.packet.Data), bp.packet.TimeoutHeight
+		return job
+	}
+	job := NewRelayJob(to.PathEnd, RelayJobAck, bp.packet.Sequence)
+	job.Ack = string(bp.ack)
+	return job
+}
+
+// batcher holds the packets which the batch strategy has yet to relay to each chain. They are
+// also persisted in the relay queue, which retries the packets that fail to be relayed.
+type batcher struct {
+	BatchStrategy
+	src, dst *Chain
+	jobs     *RelayQueue
+
+	sync.Mutex
+	srcQueue []batchPacket
+	dstQueue []batchPacket
+
+	// flushing, retrying, srcTimeouts and dstTimeouts make sure only one relay runs at a time
+	flushing    int32
+	retrying    int32
+	srcTimeouts int32
+	dstTimeouts int32
+}
+
+func (b *batcher) queueUnrelayed() error {
+	hs, err := QueryLatestHeights(b.src, b.dst)
+	if err != nil {
+		return err
+	}
+
+	sp, err := UnrelayedSequences(b.src, b.dst, hs[b.src.ChainID], hs[b.dst.ChainID])
+	if err != nil {
+		return err
+	}
+
+	for _, seq := range sp.Src {
+		pd, to, err := b.src.queryPacketDataAndTimeout(uint64(hs[b.src.ChainID]), seq)
+		if err != nil {
+			return err
+		}
+		b.queue(b.dst, batchPacket{packet: b.src.PathEnd.NewPacket(b.dst.PathEnd, seq, pd, to), height: hs[b.src.ChainID]})
+	}
+
+	for _, seq := range sp.Dst {
+		pd, to, err := b.dst.queryPacketDataAndTimeout(uint64(hs[b.dst.ChainID]), seq)
+		if err != nil {
+			return err
+		}
+		b.queue(b.src, batchPacket{packet: b.dst.PathEnd.NewPacket(b.src.PathEnd, seq, pd, to), height: hs[b.dst.ChainID]})
+	}
+	return nil
+}
+
+// queueEvents queues the packets sent by from and the acknowledgements it wrote so that they are
+// relayed to to
+func (b *batcher) queueEvents(from, to *Chain, events map[string][]string) {
+	height := getEventHeight(events)
+
+	sent, err := packetsFromEvents(events, "send_packet", "packet_data")
+	if err != nil {
+		from.Error(err)
+	}
+	for _, packet := range sent {
+		if packet.SourcePort == from.PathEnd.PortID && packet.SourceChannel == from.PathEnd.ChannelID {
+			b.queue(to, batchPacket{packet: packet, height: height})
+		}
+	}
+
+	acks, err := acksFromEvents(events)
+	if err != nil {
+		from.Error(err)
+	}
+	for _, ack := range acks {
+		if ack.DestinationPort != from.PathEnd.PortID || ack.DestinationChannel != from.PathEnd.ChannelID || len(ack.Data) == 0 {
+			continue
+		}
+		// the data of the acknowledged packet is queried from its sender when relaying
+		packet := ack
+		packet.Data = nil
+		b.queue(to, batchPacket{packet: packet, ack: ack.Data, height: height})
+	}
+}
+
+// queue queues the packet to be relayed to the chain if it passes the filters, persisting it in
+// the relay queue first. The queue only retries the packet once the batch had time to relay it.
+func (b *batcher) queue(to *Chain, bp batchPacket) {
+	if !b.RelaysChannel(bp.packet) || (bp.ack == nil && !b.RelaysData(bp.packet.Data)) {
+		return
+	}
+	if err := b.jobs.PushDelayed(bp.job(to), b.MinDelay); err != nil {
+		to.Error(err, "sequence", bp.packet.Sequence)
+	}
+	bp.queuedAt = time.Now()
+
+	b.Lock()
+	defer b.Unlock()
+	if to == b.src {
+		b.srcQueue = append(b.srcQueue, bp)
+	} else {
+		b.dstQueue = append(b.dstQueue, bp)
+	}
+}
+
+// done removes the packet from the relay queue once it is relayed to the chain, or records its
+// failure so that the relay queue retries it with backoff
+func (b *batcher) done(to *Chain, bp batchPacket, relayErr error) {
+	var err error
+	if relayErr != nil {
+		to.Error(relayErr, "sequence", bp.packet.Sequence)
+		err = b.jobs.Failed(bp.job(to), relayErr)
+	} else {
+		err = b.jobs.Remove(bp.job(to))
+	}
+	if err != nil {
+		to.Error(err, "sequence", bp.packet.Sequence)
+	}
+}
+
+// flush relays the queued packets to both chains once the oldest of them has waited for the
+// minimum delay
+func (b *batcher) flush() {
+	if !atomic.CompareAndSwapInt32(&b.flushing, 0, 1) {
+		return
+	}
+	defer atomic.StoreInt32(&b.flushing, 0)
+
+	b.Lock()
+	srcQueue, dstQueue := b.take(&b.srcQueue), b.take(&b.dstQueue)
+	b.Unlock()
+
+	srcRetry := b.relayBatch(b.src, b.dst, srcQueue)
+	dstRetry := b.relayBatch(b.dst, b.src, dstQueue)
+
+	b.Lock()
+	b.srcQueue = append(srcRetry, b.srcQueue...)
+	b.dstQueue = append(dstRetry, b.dstQueue...)
+	b.Unlock()
+}
+
+// take empties the queue if its oldest packet has waited for the minimum delay
+func (b *batcher) take(queue *[]batchPacket) []batchPacket {
+	if len(*queue) == 0 || time.Since((*queue)[0].queuedAt) < b.MinDelay {
+		return nil
+	}
+	out := *queue
+	*queue = nil
+	return out
+}
+
+// relayBatch relays the packets to src with a single update of its client of dst, split into
+// transactions of at most MaxMsgs messages. It returns the packets which are not provable yet.
+// The packets which fail to be relayed are left to the relay queue to retry.
+func (b *batcher) relayBatch(src, dst *Chain, packets []batchPacket) (retry []batchPacket) {
+	if len(packets) == 0 {
+		return nil
+	}
+
+	dstH, err := dst.UpdateLiteWithHeader()
+	if err != nil {
+		dst.Error(err)
+		return packets
+	}
+
+	msgs, relayed := []sdk.Msg{}, []batchPacket{}
+	for _, bp := range packets {
+		// the proof can only be queried once the height of the event is committed
+		if dstH.Height-1 < bp.height {
+			retry = append(retry, bp)
+			continue
+		}
+		msg, err := b.packetMsg(src, dst, dstH, bp)
+		switch {
+		case err != nil:
+			b.done(src, bp, err)
+		case msg == nil:
+			// the packet does not pass the filters
+			b.done(src, bp, nil)
+		default:
+			msgs = append(msgs, msg)
+			relayed = append(relayed, bp)
+		}
+	}
+
+	if len(msgs) == 0 {
+		return retry
+	}
+
+	txs := batchTxs(src.PathEnd.UpdateClient(dstH, src.MustGetAddress()), msgs, b.MaxMsgs)
+	for i, start := 0, 0; i < len(txs); i++ {
+		end := start + len(txs[i])
+		if i == 0 {
+			end--
+		}
+
+		// later transactions rely on the client update of the first one
+		batch := &RelayMsgs{Src: txs[i], Dst: []sdk.Msg{}}
+		if err := batch.sendErr(src, dst); err != nil {
+			for _, bp := range relayed[start:] {
+				b.done(src, bp, err)
+			}
+			return retry
+		}
+		for _, bp := range relayed[start:end] {
+			b.done(src, bp, nil)
+		}
+		start = end
+	}
+	dst.Log("relayed packets", "count", len(msgs), "txs", len(txs),
+		"dst_chain_id", src.ChainID, "dst_port", src.PathEnd.PortID)
+	return retry
+}
+
+// batchTxs splits the msgs into transactions of at most maxMsgs messages, the first of which
+// starts with the client update
+func batchTxs(update sdk.Msg, msgs []sdk.Msg, maxMsgs int) [][]sdk.Msg {
+	txs := [][]sdk.Msg{}
+	for start := 0; start < len(msgs); {
+		tx, n := []sdk.Msg{}, maxMsgs
+		if start == 0 {
+			tx, n = append(tx, update), n-1
+		}
+		end := start + n
+		if end > len(msgs) {
+			end = len(msgs)
+		}
+		txs = append(txs, append(tx, msgs[start:end]...))
+		start = end
+	}
+	return txs
+}
+
+// packetMsg returns the message relaying the packet to src, proven at the height of the dst
+// header, or nil if the packet does not pass the filters
+func (b *batcher) packetMsg(src, dst *Chain, dstH *tmclient.Header, bp batchPacket) (sdk.Msg, error) {
+	seq := int64(bp.packet.Sequence)
+	if bp.ack == nil {
+		dstCommitRes, err := dst.QueryPacketCommitment(dstH.Height-1, seq)
+		if err != nil {
+			return nil, err
+		} else if dstCommitRes.Proof.Proof == nil {
+			return nil, fmt.Errorf("- [%s]@{%d} - Packet Commitment Proof is nil seq(%d)", dst.ChainID, dstH.Height-1, seq)
+		}
+		return src.PacketMsg(dst, bp.packet.Data, bp.packet.TimeoutHeight, seq, dstCommitRes)
+	}
+
+	packetData, timeout, err := src.queryPacketDataAndTimeout(0, bp.packet.Sequence)
+	if err != nil {
+		return nil, err
+	} else if !b.RelaysData(packetData) {
+		return nil, nil
+	}
+
+	dstAckRes, err := dst.QueryPacketAck(dstH.Height-1, seq)
+	if err != nil {
+		return nil, err
+	} else if dstAckRes.Data == nil || dstAckRes.Proof.Proof == nil {
+		return nil, fmt.Errorf("- [%s]@{%d} - Packet Acknowledgement Proof is nil seq(%d)", dst.ChainID, dstH.Height-1, seq)
+	}
+	return src.AckMsg(dst, packetData, bp.ack, timeout, seq, dstAckRes), nil
+}
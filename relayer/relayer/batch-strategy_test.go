@@ -0,0 +1,146 @@
+package relayer
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	tmclient "github.com/cosmos/cosmos-sdk/x/ibc/07-tendermint/types"
+	transfer "github.com/cosmos/cosmos-sdk/x/ibc/20-transfer/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchStrategyInit(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		typ         string
+		constraints map[string]string
+		expected    BatchStrategy
+		err         bool
+	}{
+		{
+			name:     "defaults",
+			expected: BatchStrategy{MaxMsgs: defaultBatchMaxMsgs, MinDelay: defaultBatchMinDelay},
+		},
+		{
+			name: "all constraints",
+			constraints: map[string]string{
+				BatchPorts: "transfer", BatchChannels: "ch-a, ch-b,", BatchDenoms: "stake,transfer/ch-a/stake",
+				BatchSenders: "cosmos1sender", BatchMaxMsgs: "5", BatchMinDelay: "30s",
+			},
+			expected: BatchStrategy{
+				Ports: []string{"transfer"}, Channels: []string{"ch-a", "ch-b"}, Denoms: []string{"stake", "transfer/ch-a/stake"},
+				Senders: []string{"cosmos1sender"}, MaxMsgs: 5, MinDelay: 30 * time.Second,
+			},
+		},
+		{name: "wrong type", typ: "naive", err: true},
+		{name: "unknown constraint", constraints: map[string]string{"max-fee": "1stake"}, err: true},
+		{name: "max-msgs not an integer", constraints: map[string]string{BatchMaxMsgs: "ten"}, err: true},
+		{name: "max-msgs leaves no room for packets", constraints: map[string]string{BatchMaxMsgs: "1"}, err: true},
+		{name: "min-delay not a duration", constraints: map[string]string{BatchMinDelay: "10"}, err: true},
+		{name: "negative min-delay", constraints: map[string]string{BatchMinDelay: "-1s"}, err: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewBatchStrategy()
+			if tc.typ != "" {
+				cfg.Type = tc.typ
+			}
+			for key, val := range tc.constraints {
+				cfg.Constraints[key] = val
+			}
+
+			strat, err := BatchStrategy{}.Init(cfg)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, strat)
+
+			// the config of the strategy initializes the same strategy
+			again, err := BatchStrategy{}.Init(strat.Cfg())
+			require.NoError(t, err)
+			require.Equal(t, strat, again)
+		})
+	}
+}
+
+func TestBatchStrategyRelaysData(t *testing.T) {
+	sender, other := sdk.AccAddress([]byte("sender")), sdk.AccAddress([]byte("other"))
+	data := func(from sdk.AccAddress, coins ...sdk.Coin) []byte {
+		return transfer.NewFungibleTokenPacketData(coins, from, other).GetBytes()
+	}
+	coin := func(denom string) sdk.Coin {
+		// vouchers are prefixed with the path they came through, which NewCoin doesn't allow
+		return sdk.Coin{Denom: denom, Amount: sdk.NewInt(10)}
+	}
+	stake, voucher, token := coin("stake"), coin("transfer/ch-a/stake"), coin("token")
+
+	for _, tc := range []struct {
+		name     string
+		strategy BatchStrategy
+		data     []byte
+		relays   bool
+	}{
+		{"no filters", BatchStrategy{}, []byte("not a transfer"), true},
+		{"denom", BatchStrategy{Denoms: []string{"stake"}}, data(sender, stake), true},
+		{"denom of a voucher", BatchStrategy{Denoms: []string{"stake"}}, data(sender, voucher), true},
+		{"voucher", BatchStrategy{Denoms: []string{"transfer/ch-a/stake"}}, data(sender, voucher), true},
+		{"voucher of another channel", BatchStrategy{Denoms: []string{"transfer/ch-b/stake"}}, data(sender, voucher), false},
+		{"other denom", BatchStrategy{Denoms: []string{"stake"}}, data(sender, token), false},
+		{"one of the denoms not allowed", BatchStrategy{Denoms: []string{"stake"}}, data(sender, stake, token), false},
+		{"sender", BatchStrategy{Senders: []string{sender.String()}}, data(sender, token), true},
+		{"other sender", BatchStrategy{Senders: []string{sender.String()}}, data(other, token), false},
+		{"denom and sender", BatchStrategy{Denoms: []string{"stake"}, Senders: []string{sender.String()}}, data(sender, stake), true},
+		{"denom but other sender", BatchStrategy{Denoms: []string{"stake"}, Senders: []string{sender.String()}}, data(other, stake), false},
+		{"not a transfer", BatchStrategy{Denoms: []string{"stake"}}, []byte(`{"price":"1"}`), false},
+		{"not json", BatchStrategy{Senders: []string{sender.String()}}, []byte("not a transfer"), false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.relays, tc.strategy.RelaysData(tc.data))
+		})
+	}
+}
+
+func TestBatchTxs(t *testing.T) {
+	update := tmclient.MsgUpdateClient{ClientID: "client"}
+	msgs := func(n int) []sdk.Msg {
+		out := []sdk.Msg{}
+		for i := 0; i < n; i++ {
+			out = append(out, tmclient.MsgUpdateClient{ClientID: string(rune('a' + i))})
+		}
+		return out
+	}
+
+	for _, tc := range []struct {
+		name    string
+		msgs    int
+		maxMsgs int
+		sizes   []int
+	}{
+		{"no msgs", 0, 10, []int{}},
+		{"one msg", 1, 10, []int{2}},
+		{"fits in one tx", 9, 10, []int{10}},
+		{"one more than fits", 10, 10, []int{10, 1}},
+		{"smallest batches", 5, 2, []int{2, 2, 2}},
+		{"several full txs", 8, 3, []int{3, 3, 3}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			in := msgs(tc.msgs)
+			txs := batchTxs(update, in, tc.maxMsgs)
+
+			sizes, out := []int{}, []sdk.Msg{}
+			for i, tx := range txs {
+				sizes = append(sizes, len(tx))
+				if i == 0 {
+					// the first tx updates the client the packets of every tx are proven against
+					require.Equal(t, update, tx[0])
+					tx = tx[1:]
+				}
+				out = append(out, tx...)
+			}
+			require.Equal(t, tc.sizes, sizes)
+			require.Equal(t, in, out)
+		})
+	}
+}
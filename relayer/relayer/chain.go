@@ -8,6 +8,7 @@ import (
 	"os"
 	"path"
 	"strconv"
+	"strings"
 	"time"
 
 	sdkCtx "github.com/cosmos/cosmos-sdk/client/context"
@@ -16,7 +17,6 @@ import (
 	codecstd "github.com/cosmos/cosmos-sdk/codec/std"
 	keys "github.com/cosmos/cosmos-sdk/crypto/keyring"
 	sdk "github.com/cosmos/cosmos-sdk/types"
-	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/go-bip39"
 	"github.com/tendermint/tendermint/libs/log"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
@@ -26,16 +26,20 @@ import (
 
 // Chain represents the necessary data for connecting to and indentifying a chain and its counterparites
 type Chain struct {
-	Key            string  `yaml:"key" json:"key"`
-	ChainID        string  `yaml:"chain-id" json:"chain-id"`
-	RPCAddr        string  `yaml:"rpc-addr" json:"rpc-addr"`
-	AccountPrefix  string  `yaml:"account-prefix" json:"account-prefix"`
-	Gas            uint64  `yaml:"gas,omitempty" json:"gas,omitempty"`
-	GasAdjustment  float64 `yaml:"gas-adjustment,omitempty" json:"gas-adjustment,omitempty"`
-	GasPrices      string  `yaml:"gas-prices,omitempty" json:"gas-prices,omitempty"`
-	DefaultDenom   string  `yaml:"default-denom,omitempty" json:"default-denom,omitempty"`
-	Memo           string  `yaml:"memo,omitempty" json:"memo,omitempty"`
-	TrustingPeriod string  `yaml:"trusting-period" json:"trusting-period"`
+	Key            string   `yaml:"key" json:"key"`
+	ChainID        string   `yaml:"chain-id" json:"chain-id"`
+	RPCAddr        string   `yaml:"rpc-addr" json:"rpc-addr"`
+	AccountPrefix  string   `yaml:"account-prefix" json:"account-prefix"`
+	Gas            uint64   `yaml:"gas,omitempty" json:"gas,omitempty"`
+	GasAdjustment  float64  `yaml:"gas-adjustment,omitempty" json:"gas-adjustment,omitempty"`
+	GasPrices      string   `yaml:"gas-prices,omitempty" json:"gas-prices,omitempty"`
+	DefaultDenom   string   `yaml:"default-denom,omitempty" json:"default-denom,omitempty"`
+	Memo           string   `yaml:"memo,omitempty" json:"memo,omitempty"`
+	TrustingPeriod string   `yaml:"trusting-period" json:"trusting-period"`
+	SimulateGas    bool     `yaml:"simulate-gas,omitempty" json:"simulate-gas,omitempty"`
+	MaxFee         string   `yaml:"max-fee,omitempty" json:"max-fee,omitempty"`
+	MaxFeePerHour  string   `yaml:"max-fee-per-hour,omitempty" json:"max-fee-per-hour,omitempty"`
+	ExtraKeys      []string `yaml:"extra-keys,omitempty" json:"extra-keys,omitempty"`
 
 	// TODO: make these private
 	HomePath string            `yaml:"-" json:"-"`
@@ -45,13 +49,20 @@ type Chain struct {
 	Cdc      *codecstd.Codec   `yaml:"-" json:"-"`
 	Amino    *aminocodec.Codec `yaml:"-" json:"-"`
 
-	address sdk.AccAddress
-	logger  log.Logger
-	timeout time.Duration
-	debug   bool
+	address  sdk.AccAddress
+	logger   log.Logger
+	pathName string
+	timeout  time.Duration
+	debug    bool
 
 	// stores facuet addresses that have been used reciently
 	faucetAddrs map[string]time.Time
+
+	// fees paid within the last hour, shared by the copies of the chain
+	fees *feeTracker
+
+	// signers of the keys of the chain, shared by the copies of the chain
+	signers *signers
 }
 
 // Init initializes the pieces of a chain that aren't set when it parses a config
@@ -77,6 +88,13 @@ func (src *Chain) Init(homePath string, cdc *codecstd.Codec, amino *aminocodec.C
 		return fmt.Errorf("failed to parse trusting period (%s) for chain %s", src.TrustingPeriod, src.ChainID)
 	}
 
+	if _, err = sdk.ParseCoins(src.MaxFee); err != nil {
+		return fmt.Errorf("failed to parse max fee (%s) for chain %s: %w", src.MaxFee, src.ChainID, err)
+	}
+	if _, err = sdk.ParseCoins(src.MaxFeePerHour); err != nil {
+		return fmt.Errorf("failed to parse max fee per hour (%s) for chain %s: %w", src.MaxFeePerHour, src.ChainID, err)
+	}
+
 	src.Keybase = keybase
 	src.Client = client
 	src.Cdc = cdc
@@ -86,6 +104,8 @@ func (src *Chain) Init(homePath string, cdc *codecstd.Codec, amino *aminocodec.C
 	src.timeout = timeout
 	src.debug = debug
 	src.faucetAddrs = make(map[string]time.Time)
+	src.fees = &feeTracker{}
+	src.signers = newSigners()
 	return nil
 }
 
@@ -140,31 +160,19 @@ func (src *Chain) SendMsg(datagram sdk.Msg) (sdk.TxResponse, error) {
 	return src.SendMsgs([]sdk.Msg{datagram})
 }
 
-// SendMsgs wraps the msgs in a stdtx, signs and sends it
+// SendMsgs wraps the msgs in a stdtx, signs and sends it. Transactions of the same key are sent
+// one at a time, and relay msgs are spread over the extra-keys of the chain.
 func (src *Chain) SendMsgs(datagrams []sdk.Msg) (res sdk.TxResponse, err error) {
-	var out []byte
-	if out, err = src.BuildAndSignTx(datagrams); err != nil {
+	s, msgs, err := src.nextSigner(datagrams)
+	if err != nil {
 		return res, err
 	}
-	return src.BroadcastTxCommit(out)
+	return s.send(src, msgs)
 }
 
 // BuildAndSignTx takes messages and builds, signs and marshals a sdk.Tx to prepare it for broadcast
 func (src *Chain) BuildAndSignTx(datagram []sdk.Msg) ([]byte, error) {
-	// Fetch account and sequence numbers for the account
-	sdkConf := sdk.GetConfig()
-	sdkConf.SetBech32PrefixForAccount(src.AccountPrefix, src.AccountPrefix+"pub")
-
-	acc, err := auth.NewAccountRetriever(src.Cdc, src).GetAccount(src.MustGetAddress())
-	if err != nil {
-		return nil, err
-	}
-
-	return auth.NewTxBuilder(
-		auth.DefaultTxEncoder(src.Amino), acc.GetAccountNumber(),
-		acc.GetSequence(), src.Gas, src.GasAdjustment, false, src.ChainID,
-		src.Memo, sdk.NewCoins(), src.getGasPrices()).WithKeybase(src.Keybase).
-		BuildAndSign(src.Key, ckeys.DefaultKeyPass, datagram)
+	return src.BuildAndSignTxWithKey(datagram, src.Key)
 }
 
 // BroadcastTxCommit takes the marshaled transaction bytes and broadcasts them
@@ -178,16 +186,6 @@ func (src *Chain) BroadcastTxCommit(txBytes []byte) (sdk.TxResponse, error) {
 	return res, err
 }
 
-// Log takes a string and logs the data
-func (src *Chain) Log(s string) {
-	src.logger.Info(s)
-}
-
-// Error takes an error, wraps it in the chainID and logs the error
-func (src *Chain) Error(err error) {
-	src.logger.Error(fmt.Sprintf("%s: err(%s)", src.ChainID, err.Error()))
-}
-
 // Start the client service
 func (src *Chain) Start() error {
 	return src.Client.Start()
@@ -283,6 +281,29 @@ func (src *Chain) Update(key, value string) (out *Chain, err error) {
 			return
 		}
 		out.TrustingPeriod = value
+	case "simulate-gas":
+		var simulate bool
+		if simulate, err = strconv.ParseBool(value); err != nil {
+			return
+		}
+		out.SimulateGas = simulate
+	case "max-fee":
+		if _, err = sdk.ParseCoins(value); err != nil {
+			return
+		}
+		out.MaxFee = value
+	case "max-fee-per-hour":
+		if _, err = sdk.ParseCoins(value); err != nil {
+			return
+		}
+		out.MaxFeePerHour = value
+	case "extra-keys":
+		out.ExtraKeys = nil
+		for _, key := range strings.Split(value, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				out.ExtraKeys = append(out.ExtraKeys, key)
+			}
+		}
 	default:
 		return out, fmt.Errorf("key %s not found", key)
 	}
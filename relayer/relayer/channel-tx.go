@@ -1,7 +1,6 @@
 package relayer
 
 import (
-	"fmt"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -37,9 +36,8 @@ func (src *Chain) CreateChannel(dst *Chain, ordered bool, to time.Duration) erro
 			if src.debug {
 				logChannelStates(src, dst, chans)
 			}
-			src.Log(fmt.Sprintf("★ Channel created: [%s]chan{%s}port{%s} -> [%s]chan{%s}port{%s}",
-				src.ChainID, src.PathEnd.ChannelID, src.PathEnd.PortID,
-				dst.ChainID, dst.PathEnd.ChannelID, dst.PathEnd.PortID))
+			src.Log("channel created", "dst_chain_id", dst.ChainID,
+				"dst_port", dst.PathEnd.PortID, "dst_channel", dst.PathEnd.ChannelID)
 			break
 		}
 	}
@@ -173,9 +171,8 @@ func (src *Chain) CloseChannel(dst *Chain, to time.Duration) error {
 			if src.debug {
 				logChannelStates(src, dst, chans)
 			}
-			src.Log(fmt.Sprintf("★ Closed channel between [%s]chan{%s}port{%s} -> [%s]chan{%s}port{%s}",
-				src.ChainID, src.PathEnd.ChannelID, src.PathEnd.PortID,
-				dst.ChainID, dst.PathEnd.ChannelID, dst.PathEnd.PortID))
+			src.Log("channel closed", "dst_chain_id", dst.ChainID,
+				"dst_port", dst.PathEnd.PortID, "dst_channel", dst.PathEnd.ChannelID)
 			break
 		}
 	}
@@ -2,9 +2,11 @@ package relayer
 
 import (
 	"fmt"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	clientTypes "github.com/cosmos/cosmos-sdk/x/ibc/02-client/types"
+	tmclient "github.com/cosmos/cosmos-sdk/x/ibc/07-tendermint/types"
 )
 
 // CreateClients creates clients for src on dst and dst on src given the configured paths
@@ -45,10 +47,96 @@ func (src *Chain) CreateClients(dst *Chain) (err error) {
 	// Send msgs to both chains
 	if clients.Ready() {
 		if clients.Send(src, dst); clients.success {
-			src.Log(fmt.Sprintf("★ Clients created: [%s]client(%s) and [%s]client(%s)",
-				src.ChainID, src.PathEnd.ClientID, dst.ChainID, dst.PathEnd.ClientID))
+			src.Log("clients created", "client", src.PathEnd.ClientID,
+				"dst_chain_id", dst.ChainID, "dst_client", dst.PathEnd.ClientID)
 		}
 	}
 
 	return nil
 }
+
+// ClientRefreshInterval is how often RefreshClients checks the age of the clients of a path
+var ClientRefreshInterval = time.Minute
+
+// ClientAge returns how long ago the latest consensus state of the client of c was created and
+// the trusting period of the client, or a zero trusting period if c has no tendermint client
+func (c *Chain) ClientAge() (age, trustingPeriod time.Duration, err error) {
+	csr, err := c.QueryClientState()
+	if err != nil || csr == nil {
+		return 0, 0, err
+	}
+
+	cs, ok := csr.ClientState.(tmclient.ClientState)
+	if !ok {
+		return 0, 0, nil
+	}
+	return time.Since(cs.GetLatestTimestamp()), cs.TrustingPeriod, nil
+}
+
+// clientNeedsUpdate returns true once the client of c is older than the given fraction of its
+// trusting period
+func (c *Chain) clientNeedsUpdate(fraction float64) (bool, error) {
+	age, tp, err := c.ClientAge()
+	if err != nil || tp == 0 {
+		return false, err
+	}
+	return age >= time.Duration(fraction*float64(tp)), nil
+}
+
+// UpdateClients updates the lite clients of both chains and then the clients of src on dst and of
+// dst on src which are older than the given fraction of their trusting period, or both clients if
+// force is set, so that they don't expire while the path is idle
+func (src *Chain) UpdateClients(dst *Chain, fraction float64, force bool) (err error) {
+	hs, err := UpdatesWithHeaders(src, dst)
+	if err != nil {
+		return err
+	}
+
+	clients := &RelayMsgs{Src: []sdk.Msg{}, Dst: []sdk.Msg{}}
+	srcUpdate, dstUpdate := force, force
+	if !force {
+		if srcUpdate, err = src.clientNeedsUpdate(fraction); err != nil {
+			return err
+		}
+		if dstUpdate, err = dst.clientNeedsUpdate(fraction); err != nil {
+			return err
+		}
+	}
+
+	if srcUpdate {
+		clients.Src = append(clients.Src, src.PathEnd.UpdateClient(hs[dst.ChainID], src.MustGetAddress()))
+	}
+	if dstUpdate {
+		clients.Dst = append(clients.Dst, dst.PathEnd.UpdateClient(hs[src.ChainID], dst.MustGetAddress()))
+	}
+
+	if !clients.Ready() {
+		return nil
+	}
+	if err = clients.sendErr(src, dst); err != nil {
+		return err
+	}
+	if srcUpdate {
+		src.logClientUpdated(dst)
+	}
+	if dstUpdate {
+		dst.logClientUpdated(src)
+	}
+	return nil
+}
+
+// RefreshClients calls UpdateClients every ClientRefreshInterval until stop is closed
+func RefreshClients(src, dst *Chain, fraction float64, stop <-chan struct{}) {
+	ticker := time.NewTicker(ClientRefreshInterval)
+	defer ticker.Stop()
+	for {
+		if err := src.UpdateClients(dst, fraction, false); err != nil {
+			src.Error(fmt.Errorf("failed to refresh clients: %w", err))
+		}
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -1,7 +1,6 @@
 package relayer
 
 import (
-	"fmt"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -32,9 +31,8 @@ func (src *Chain) CreateConnection(dst *Chain, to time.Duration) error {
 				logConnectionStates(src, dst, conns)
 			}
 
-			src.Log(fmt.Sprintf("★ Connection created: [%s]client{%s}conn{%s} -> [%s]client{%s}conn{%s}",
-				src.ChainID, src.PathEnd.ClientID, src.PathEnd.ConnectionID,
-				dst.ChainID, dst.PathEnd.ClientID, dst.PathEnd.ConnectionID))
+			src.Log("connection created", "client", src.PathEnd.ClientID, "connection", src.PathEnd.ConnectionID,
+				"dst_chain_id", dst.ChainID, "dst_client", dst.PathEnd.ClientID, "dst_connection", dst.PathEnd.ConnectionID)
 			break
 		}
 	}
@@ -8,42 +8,26 @@ import (
 
 	ckeys "github.com/cosmos/cosmos-sdk/client/keys"
 	sdk "github.com/cosmos/cosmos-sdk/types"
-	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
 )
 
 // SendMsgWithKey allows the user to specify which relayer key will sign the message
 func (src *Chain) SendMsgWithKey(datagram sdk.Msg, keyName string) (res sdk.TxResponse, err error) {
-	var out []byte
-	if out, err = src.BuildAndSignTxWithKey([]sdk.Msg{datagram}, keyName); err != nil {
-		return res, err
-	}
-	return src.BroadcastTxCommit(out)
-
+	return src.signers.get(keyName).send(src, []sdk.Msg{datagram})
 }
 
 // BuildAndSignTxWithKey allows the user to specify which relayer key will sign the message
 func (src *Chain) BuildAndSignTxWithKey(datagram []sdk.Msg, keyName string) ([]byte, error) {
-	// Set sdk config to use custom Bech32 account prefix
-	sdkConf := sdk.GetConfig()
-	sdkConf.SetBech32PrefixForAccount(src.AccountPrefix, src.AccountPrefix+"pub")
-
-	// Fetch account and sequence numbers for the account
-	info, err := src.Keybase.Get(keyName)
+	accNum, seq, err := src.account(keyName)
 	if err != nil {
 		return nil, err
 	}
 
-	acc, err := auth.NewAccountRetriever(src.Cdc, src).GetAccount(info.GetAddress())
+	txBldr, err := src.txBuilder(keyName, accNum, seq, datagram)
 	if err != nil {
 		return nil, err
 	}
-
-	return auth.NewTxBuilder(
-		auth.DefaultTxEncoder(src.Amino), acc.GetAccountNumber(),
-		acc.GetSequence(), src.Gas, src.GasAdjustment, false, src.ChainID,
-		src.Memo, sdk.NewCoins(), src.getGasPrices()).WithKeybase(src.Keybase).
-		BuildAndSign(info.GetName(), ckeys.DefaultKeyPass, datagram)
+	return txBldr.BuildAndSign(keyName, ckeys.DefaultKeyPass, datagram)
 }
 
 // FaucetHandler listens for addresses
@@ -58,7 +42,7 @@ func (src *Chain) FaucetHandler(fromKey sdk.AccAddress, amount sdk.Coin) func(w
 		defer r.Body.Close()
 
 		if wait, err := src.checkAddress(fr.Address); err != nil {
-			src.Log(fmt.Sprintf("%s hit rate limit, needs to wait %s", fr.Address, wait.String()))
+			src.Log("faucet rate limit hit", "address", fr.Address, "wait", wait.String())
 			respondWithError(w, http.StatusTooManyRequests, err.Error())
 			return
 		}
@@ -69,7 +53,7 @@ func (src *Chain) FaucetHandler(fromKey sdk.AccAddress, amount sdk.Coin) func(w
 			return
 		}
 
-		src.Log(fmt.Sprintf("%s was sent %s successfully", fr.Address, amount.String()))
+		src.Log("faucet sent coins", "address", fr.Address, "amount", amount.String())
 		respondWithJSON(w, http.StatusCreated, success{Address: fr.Address, Amount: amount.String()})
 	}
 }
@@ -0,0 +1,129 @@
+package relayer
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	authclient "github.com/cosmos/cosmos-sdk/x/auth/client"
+)
+
+// feeWindow is how long the fees of a chain count toward its max-fee-per-hour
+const feeWindow = time.Hour
+
+// feeSpend is the fee of a transaction sent at a given time
+type feeSpend struct {
+	fee  sdk.Coins
+	sent time.Time
+}
+
+// feeTracker tracks the fees a chain paid within the last feeWindow. It is shared by the copies of
+// a chain so that the cap holds across all the paths relaying to the chain.
+type feeTracker struct {
+	sync.Mutex
+	spends []*feeSpend
+}
+
+// txBuilder returns a builder of transactions signed by the given key with the given account
+// number and sequence. The gas of the datagrams is estimated by simulating them on the node when
+// simulate-gas is set, and the resulting fee must fit the max-fee of the chain.
+func (src *Chain) txBuilder(keyName string, accNum, seq uint64, datagrams []sdk.Msg) (txBldr auth.TxBuilder, err error) {
+	txBldr = auth.NewTxBuilder(
+		auth.DefaultTxEncoder(src.Amino), accNum,
+		seq, src.Gas, src.GasAdjustment, false, src.ChainID,
+		src.Memo, sdk.NewCoins(), src.getGasPrices()).WithKeybase(src.Keybase)
+
+	if src.SimulateGas {
+		txBytes, err := txBldr.BuildTxForSim(datagrams)
+		if err != nil {
+			return txBldr, err
+		}
+		_, gas, err := authclient.CalculateGas(src.QueryWithData, src.Amino, txBytes, src.GasAdjustment)
+		if err != nil {
+			return txBldr, fmt.Errorf("failed to simulate %s on %s: %w", getMsgAction(datagrams), src.ChainID, err)
+		}
+		txBldr = txBldr.WithGas(gas)
+	}
+
+	if fee := src.txFee(txBldr.Gas()); exceedsCap(fee, src.getMaxFee()) {
+		return txBldr, fmt.Errorf("fee %s for gas %d exceeds max-fee %s on %s", fee, txBldr.Gas(), src.MaxFee, src.ChainID)
+	}
+	return txBldr, nil
+}
+
+// txFee returns the fee of a transaction with the given gas limit, ceil(gasPrice * gas), which
+// is what the tx builder sets and what the chain charges
+func (src *Chain) txFee(gas uint64) sdk.Coins {
+	fee := sdk.Coins{}
+	for _, gp := range src.getGasPrices() {
+		fee = fee.Add(sdk.NewCoin(gp.Denom, gp.Amount.MulInt64(int64(gas)).Ceil().RoundInt()))
+	}
+	return fee
+}
+
+func (src *Chain) getMaxFee() sdk.Coins {
+	max, _ := sdk.ParseCoins(src.MaxFee)
+	return max
+}
+
+func (src *Chain) getMaxFeePerHour() sdk.Coins {
+	max, _ := sdk.ParseCoins(src.MaxFeePerHour)
+	return max
+}
+
+// exceedsCap returns true if fee holds more of any denom of max than max does. Denoms which are
+// not part of max are not capped.
+func exceedsCap(fee, max sdk.Coins) bool {
+	for _, c := range max {
+		if fee.AmountOf(c.Denom).GT(c.Amount) {
+			return true
+		}
+	}
+	return false
+}
+
+// reserveFee records the fee of a transaction about to be sent, unless it would take the fees of
+// the last hour over max-fee-per-hour. The returned release func takes the fee back out for
+// transactions which did not make it into a block.
+func (src *Chain) reserveFee(fee sdk.Coins) (release func(), err error) {
+	t := src.fees
+	t.Lock()
+	defer t.Unlock()
+
+	now := time.Now()
+	spent := sdk.Coins{}
+	recent := t.spends[:0]
+	for _, s := range t.spends {
+		if now.Sub(s.sent) < feeWindow {
+			recent = append(recent, s)
+			spent = spent.Add(s.fee...)
+		}
+	}
+	t.spends = recent
+
+	if max := src.getMaxFeePerHour(); exceedsCap(spent.Add(fee...), max) {
+		return nil, fmt.Errorf("fee %s would take the fees of the last hour (%s) over max-fee-per-hour %s on %s",
+			fee, spent, src.MaxFeePerHour, src.ChainID)
+	}
+
+	spend := &feeSpend{fee: fee, sent: now}
+	t.spends = append(t.spends, spend)
+	return func() {
+		t.Lock()
+		defer t.Unlock()
+		for i, s := range t.spends {
+			if s == spend {
+				t.spends = append(t.spends[:i], t.spends[i+1:]...)
+				return
+			}
+		}
+	}, nil
+}
+
+// isOutOfGas returns true if the transaction failed because it ran out of gas
+func isOutOfGas(res sdk.TxResponse) bool {
+	return res.Codespace == sdkerrors.RootCodespace && res.Code == sdkerrors.ErrOutOfGas.ABCICode()
+}
@@ -0,0 +1,102 @@
+package relayer
+
+import (
+	"errors"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExceedsCap(t *testing.T) {
+	coins := func(s string) sdk.Coins {
+		c, err := sdk.ParseCoins(s)
+		require.NoError(t, err)
+		return c
+	}
+
+	for _, tc := range []struct {
+		name     string
+		fee, max string
+		exceeds  bool
+	}{
+		{"no cap", "100stake", "", false},
+		{"below cap", "99stake", "100stake", false},
+		{"at cap", "100stake", "100stake", false},
+		{"above cap", "101stake", "100stake", true},
+		{"uncapped denom", "1000token", "100stake", false},
+		{"one denom above cap", "50stake,11token", "100stake,10token", true},
+		{"no fee", "", "100stake", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.exceeds, exceedsCap(coins(tc.fee), coins(tc.max)))
+		})
+	}
+}
+
+func TestTxFee(t *testing.T) {
+	chain := &Chain{GasPrices: "0.025stake,0.0011token"}
+	// the fee of each denom is rounded up like the tx builder does
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 25), sdk.NewInt64Coin("token", 2)), chain.txFee(1000))
+
+	chain.GasPrices = ""
+	require.True(t, chain.txFee(1000).IsZero())
+}
+
+func TestReserveFee(t *testing.T) {
+	chain := &Chain{ChainID: "stub", MaxFeePerHour: "100stake", fees: &feeTracker{}}
+	fee := func(amount int64) sdk.Coins { return sdk.NewCoins(sdk.NewInt64Coin("stake", amount)) }
+
+	release, err := chain.reserveFee(fee(60))
+	require.NoError(t, err)
+	_, err = chain.reserveFee(fee(50))
+	require.Error(t, err, "the fees of the hour would be over the cap")
+	_, err = chain.reserveFee(fee(40))
+	require.NoError(t, err)
+
+	// the fee of a transaction which did not make it into a block is released
+	release()
+	_, err = chain.reserveFee(fee(60))
+	require.NoError(t, err)
+	_, err = chain.reserveFee(fee(1))
+	require.Error(t, err)
+
+	// fees paid more than an hour ago don't count anymore
+	for _, s := range chain.fees.spends {
+		s.sent = s.sent.Add(-feeWindow)
+	}
+	_, err = chain.reserveFee(fee(100))
+	require.NoError(t, err)
+	require.Len(t, chain.fees.spends, 1)
+
+	// chains without max-fee-per-hour are not capped
+	chain.MaxFeePerHour = ""
+	_, err = chain.reserveFee(fee(1000))
+	require.NoError(t, err)
+}
+
+func TestFeeReleasedWhenTxNotInBlock(t *testing.T) {
+	chain, client := newStubChain(t, "relayer")
+	chain.MaxFeePerHour = "5000stake"
+	addr := chain.mustKeyAddress(t, "relayer")
+	fee := chain.txFee(chain.Gas)
+
+	_, err := chain.SendMsgs([]sdk.Msg{newTestSend(addr)})
+	require.NoError(t, err)
+	require.Len(t, chain.fees.spends, 1)
+
+	// the broadcast fails before the transaction is committed
+	client.broadcastErr = errors.New("connection reset")
+	_, err = chain.SendMsgs([]sdk.Msg{newTestSend(addr)})
+	require.Error(t, err)
+	require.Len(t, chain.fees.spends, 1)
+
+	// the transactions are rejected by CheckTx for their sequence
+	client.check = func(acc *auth.BaseAccount) { acc.Sequence++ }
+	res, err := chain.SendMsgs([]sdk.Msg{newTestSend(addr)})
+	require.NoError(t, err)
+	require.Equal(t, int64(0), res.Height)
+	require.Len(t, chain.fees.spends, 1)
+	require.Equal(t, fee, chain.fees.spends[0].fee)
+}
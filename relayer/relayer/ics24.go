@@ -55,6 +55,17 @@ func (c *Chain) SetPath(p *PathEnd) error {
 	return nil
 }
 
+// ForPath returns a copy of the chain set to the end of the named path, which shares the clients
+// of the chain so that several paths relaying to the chain can run at once
+func (c *Chain) ForPath(name string, p *PathEnd) (*Chain, error) {
+	out := *c
+	out.pathName = name
+	if err := out.SetPath(p); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 // AddPath takes the elements of a path and validates then, setting that path to the chain
 func (c *Chain) AddPath(clientID, connectionID, channelID, port string) error {
 	return c.SetPath(&PathEnd{ChainID: c.ChainID, ClientID: clientID, ConnectionID: connectionID, ChannelID: channelID, PortID: port})
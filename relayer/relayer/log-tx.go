@@ -11,72 +11,81 @@ import (
 // LogFailedTx takes the transaction and the messages to create it and logs the appropriate data
 func (c *Chain) LogFailedTx(res sdk.TxResponse, err error, msgs []sdk.Msg) {
 	if c.debug {
-		c.Log(fmt.Sprintf("- [%s] -> sending transaction:", c.ChainID))
-		c.Print(msgs, false, false)
+		c.Debug("sending transaction", "msgs", c.jsonString(msgs))
 	}
 
 	if err != nil {
-		c.logger.Error(fmt.Errorf("- [%s] -> err(%w)", c.ChainID, err).Error())
+		c.Error(err, "msg", getMsgAction(msgs))
 	}
 
 	if res.Codespace != "" && res.Code != 0 {
 		msg, err := GetCodespace(res.Codespace, int(res.Code))
 		if err != nil {
-			c.logger.Info(err.Error())
+			c.Error(err)
 		}
-		c.logger.Info(fmt.Sprintf("✘ [%s]@{%d} - msg(%s) err(%s: %s)", c.ChainID, res.Height, getMsgAction(msgs), res.Codespace, msg))
+		c.Log("transaction failed", "height", res.Height, "msg", getMsgAction(msgs), "tx_hash", res.TxHash,
+			"error", fmt.Sprintf("%s: %s", res.Codespace, msg))
 	}
 
 	if c.debug && !res.Empty() {
-		c.Print(res, false, false)
+		c.Debug("transaction response", "response", c.jsonString(res))
 	}
 }
 
 // LogSuccessTx take the transaction and the messages to create it and logs the appropriate data
 func (c *Chain) LogSuccessTx(res sdk.TxResponse, msgs []sdk.Msg) {
-	c.logger.Info(fmt.Sprintf("✔ [%s]@{%d} - msg(%s) hash(%s)", c.ChainID, res.Height, getMsgAction(msgs), res.TxHash))
+	c.Log("transaction committed", "height", res.Height, "msg", getMsgAction(msgs), "tx_hash", res.TxHash)
 }
 
 func (c *Chain) logPacketsRelayed(dst *Chain, num int) {
-	dst.Log(fmt.Sprintf("★ Relayed %d packets: [%s]port{%s}->[%s]port{%s}", num, dst.ChainID, dst.PathEnd.PortID, c.ChainID, c.PathEnd.PortID))
+	dst.Log("relayed packets", "count", num, "dst_chain_id", c.ChainID, "dst_port", c.PathEnd.PortID)
 }
 
 func logChannelStates(src, dst *Chain, conn map[string]chanTypes.ChannelResponse) {
-	// TODO: replace channelID with portID?
-	src.Log(fmt.Sprintf("- [%s]@{%d}chan(%s)-{%s} : [%s]@{%d}chan(%s)-{%s}",
-		src.ChainID,
-		conn[src.ChainID].ProofHeight,
-		src.PathEnd.ChannelID,
-		conn[src.ChainID].Channel.Channel.GetState(),
-		dst.ChainID,
-		conn[dst.ChainID].ProofHeight,
-		dst.PathEnd.ChannelID,
-		conn[dst.ChainID].Channel.Channel.GetState(),
-	))
+	src.Log("channel states",
+		"height", conn[src.ChainID].ProofHeight,
+		"state", conn[src.ChainID].Channel.Channel.GetState().String(),
+		"dst_chain_id", dst.ChainID,
+		"dst_height", conn[dst.ChainID].ProofHeight,
+		"dst_channel", dst.PathEnd.ChannelID,
+		"dst_state", conn[dst.ChainID].Channel.Channel.GetState().String(),
+	)
 }
 
 func logConnectionStates(src, dst *Chain, conn map[string]connTypes.ConnectionResponse) {
-	src.Log(fmt.Sprintf("- [%s]@{%d}conn(%s)-{%s} : [%s]@{%d}conn(%s)-{%s}",
-		src.ChainID,
-		conn[src.ChainID].ProofHeight,
-		src.PathEnd.ConnectionID,
-		conn[src.ChainID].Connection.Connection.GetState(),
-		dst.ChainID,
-		conn[dst.ChainID].ProofHeight,
-		dst.PathEnd.ConnectionID,
-		conn[dst.ChainID].Connection.Connection.GetState(),
-	))
+	src.Log("connection states",
+		"height", conn[src.ChainID].ProofHeight,
+		"connection", src.PathEnd.ConnectionID,
+		"state", conn[src.ChainID].Connection.Connection.GetState().String(),
+		"dst_chain_id", dst.ChainID,
+		"dst_height", conn[dst.ChainID].ProofHeight,
+		"dst_connection", dst.PathEnd.ConnectionID,
+		"dst_state", conn[dst.ChainID].Connection.Connection.GetState().String(),
+	)
 }
 
 func (c *Chain) logCreateClient(dst *Chain, dstH uint64) {
-	c.Log(fmt.Sprintf("- [%s] -> creating client for [%s]header-height{%d} trust-period(%s)", c.ChainID, dst.ChainID, dstH, dst.GetTrustingPeriod()))
+	c.Log("creating client", "client", c.PathEnd.ClientID, "dst_chain_id", dst.ChainID,
+		"dst_height", dstH, "trusting_period", dst.GetTrustingPeriod().String())
+}
+
+func (c *Chain) logClientUpdated(dst *Chain) {
+	c.Log("client updated", "client", c.PathEnd.ClientID, "dst_chain_id", dst.ChainID)
 }
 
 func (c *Chain) logTx(events map[string][]string) {
-	c.Log(fmt.Sprintf("• [%s]@{%d} - actions(%s) hash(%s)",
-		c.ChainID,
-		getEventHeight(events),
-		actions(events["message.action"]),
-		events["tx.hash"][0]),
+	c.Log("transaction event",
+		"height", getEventHeight(events),
+		"actions", actions(events["message.action"]),
+		"tx_hash", events["tx.hash"][0],
 	)
 }
+
+// jsonString returns the amino JSON of v for logging
+func (c *Chain) jsonString(v interface{}) string {
+	out, err := c.Amino.MarshalJSON(v)
+	if err != nil {
+		return err.Error()
+	}
+	return string(out)
+}
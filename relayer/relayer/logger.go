@@ -0,0 +1,66 @@
+package relayer
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// Log formats of the relayer
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+// NewLogger returns a logger writing to stdout in the given format, text or json, which drops
+// the messages below the given level, debug, info or error
+func NewLogger(format, level string) (log.Logger, error) {
+	var logger log.Logger
+	switch format {
+	case LogFormatText:
+		logger = log.NewTMLogger(log.NewSyncWriter(os.Stdout))
+	case LogFormatJSON:
+		logger = log.NewTMJSONLogger(log.NewSyncWriter(os.Stdout))
+	default:
+		return nil, fmt.Errorf("log format must be %s or %s, got %s", LogFormatText, LogFormatJSON, format)
+	}
+
+	option, err := log.AllowLevel(level)
+	if err != nil {
+		return nil, err
+	}
+	return log.NewFilter(logger, option), nil
+}
+
+// SetLogger sets the logger the chain writes its messages to
+func (src *Chain) SetLogger(logger log.Logger) {
+	src.logger = logger
+}
+
+// Log logs an info message with the given key value pairs and the fields of the chain
+func (src *Chain) Log(msg string, keyvals ...interface{}) {
+	src.logger.Info(msg, src.logFields(keyvals)...)
+}
+
+// Debug logs a debug message with the given key value pairs and the fields of the chain
+func (src *Chain) Debug(msg string, keyvals ...interface{}) {
+	src.logger.Debug(msg, src.logFields(keyvals)...)
+}
+
+// Error logs the error with the given key value pairs and the fields of the chain
+func (src *Chain) Error(err error, keyvals ...interface{}) {
+	src.logger.Error("error", src.logFields(append(keyvals, "error", err.Error()))...)
+}
+
+// logFields prepends the chain_id and, once a path is set, the path, port and channel fields
+func (src *Chain) logFields(keyvals []interface{}) []interface{} {
+	fields := []interface{}{"chain_id", src.ChainID}
+	if src.pathName != "" {
+		fields = append(fields, "path", src.pathName)
+	}
+	if src.PathEnd != nil {
+		fields = append(fields, "port", src.PathEnd.PortID, "channel", src.PathEnd.ChannelID)
+	}
+	return append(fields, keyvals...)
+}
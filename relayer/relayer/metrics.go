@@ -0,0 +1,252 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	chanTypes "github.com/cosmos/cosmos-sdk/x/ibc/04-channel/types"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const metricsNamespace = "relayer"
+
+// MetricsInterval is how often the lite client, unrelayed packet and balance metrics are refreshed
+var MetricsInterval = 30 * time.Second
+
+var (
+	metricsRegistry = prometheus.NewRegistry()
+
+	// packet labels name the chain the packet messages are sent to (dst) and the chain their
+	// proofs come from (src)
+	packetLabels = []string{"src_chain", "dst_chain", "dst_channel", "type"}
+
+	packetsRelayed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "packets_relayed_total",
+		Help:      "Number of packet, acknowledgement and timeout messages committed on the dst chain",
+	}, packetLabels)
+	packetsFailed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "packets_failed_total",
+		Help:      "Number of packet, acknowledgement and timeout messages in failed transactions to the dst chain",
+	}, packetLabels)
+	txGasUsed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "tx_gas_used_total",
+		Help:      "Gas used by the transactions of the relayer",
+	}, []string{"chain"})
+	txFeesPaid = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "tx_fees_paid_total",
+		Help:      "Fees paid for the transactions of the relayer",
+	}, []string{"chain", "denom"})
+	chainHeight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "chain_height",
+		Help:      "Latest height of the chain",
+	}, []string{"chain"})
+	liteHeight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "lite_height",
+		Help:      "Latest height of the lite client of the chain",
+	}, []string{"chain"})
+	liteLag = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "lite_lag_blocks",
+		Help:      "Number of blocks the lite client of the chain is behind the chain",
+	}, []string{"chain"})
+	unrelayedPackets = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "unrelayed_packets",
+		Help:      "Number of packets sent on the src channel which the dst chain has not received",
+	}, []string{"src_chain", "src_channel", "dst_chain", "dst_channel"})
+	accountBalance = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "account_balance",
+		Help:      "Balance of the relayer account on the chain",
+	}, []string{"chain", "denom"})
+)
+
+func init() {
+	metricsRegistry.MustRegister(
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		prometheus.NewGoCollector(),
+		packetsRelayed, packetsFailed, txGasUsed, txFeesPaid,
+		chainHeight, liteHeight, liteLag, unrelayedPackets, accountBalance,
+	)
+}
+
+// paths tracks whether the strategy of each supervised path is running, for the readiness check
+var paths = struct {
+	sync.Mutex
+	running map[string]bool
+}{running: make(map[string]bool)}
+
+func setPathRunning(name string, running bool) {
+	paths.Lock()
+	defer paths.Unlock()
+	paths.running[name] = running
+}
+
+// pathsReady returns the paths which are not running, and false if there are none to run
+func pathsReady() ([]string, bool) {
+	paths.Lock()
+	defer paths.Unlock()
+	down := []string{}
+	for name, running := range paths.running {
+		if !running {
+			down = append(down, name)
+		}
+	}
+	sort.Strings(down)
+	return down, len(paths.running) > 0 && len(down) == 0
+}
+
+// MetricsHandler returns the handler serving the Prometheus metrics on /metrics, the liveness
+// check on /healthz and the readiness check on /readyz, which succeeds once every path started
+// by the relayer is running
+func MetricsHandler() http.Handler {
+	r := mux.NewRouter()
+	r.Handle("/metrics", promhttp.HandlerFor(metricsRegistry, promhttp.HandlerOpts{})).Methods("GET")
+	r.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, "ok")
+	}).Methods("GET")
+	r.HandleFunc("/readyz", func(w http.ResponseWriter, _ *http.Request) {
+		down, ready := pathsReady()
+		switch {
+		case !ready && len(down) == 0:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprintln(w, "no paths started")
+			return
+		case !ready:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprintf(w, "paths not running: %v\n", down)
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	}).Methods("GET")
+	return r
+}
+
+// ServeMetrics serves the MetricsHandler on addr until stop is closed
+func ServeMetrics(addr string, stop <-chan struct{}) error {
+	srv := &http.Server{
+		Handler:      MetricsHandler(),
+		Addr:         addr,
+		WriteTimeout: 15 * time.Second,
+		ReadTimeout:  15 * time.Second,
+	}
+
+	go func() {
+		<-stop
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(ctx)
+	}()
+
+	if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// CollectMetrics refreshes the lite client, unrelayed packet and balance metrics of the path
+// between src and dst every MetricsInterval until stop is closed
+func CollectMetrics(src, dst *Chain, stop <-chan struct{}) {
+	ticker := time.NewTicker(MetricsInterval)
+	defer ticker.Stop()
+	for {
+		collectMetrics(src, dst)
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func collectMetrics(src, dst *Chain) {
+	hs, err := QueryLatestHeights(src, dst)
+	if err != nil {
+		src.Error(err)
+		return
+	}
+
+	for _, c := range []*Chain{src, dst} {
+		chainHeight.WithLabelValues(c.ChainID).Set(float64(hs[c.ChainID]))
+		if lh, err := c.GetLatestLiteHeight(); err == nil {
+			liteHeight.WithLabelValues(c.ChainID).Set(float64(lh))
+			liteLag.WithLabelValues(c.ChainID).Set(float64(hs[c.ChainID] - lh))
+		} else {
+			c.Error(err)
+		}
+		if coins, err := c.QueryBalance(""); err == nil {
+			for _, coin := range coins {
+				accountBalance.WithLabelValues(c.ChainID, coin.Denom).Set(intToFloat(coin.Amount))
+			}
+		} else {
+			c.Error(err)
+		}
+	}
+
+	sp, err := UnrelayedSequences(src, dst, hs[src.ChainID], hs[dst.ChainID])
+	if err != nil {
+		src.Error(err)
+		return
+	}
+	unrelayedPackets.WithLabelValues(src.ChainID, src.PathEnd.ChannelID, dst.ChainID, dst.PathEnd.ChannelID).
+		Set(float64(len(sp.Src)))
+	unrelayedPackets.WithLabelValues(dst.ChainID, dst.PathEnd.ChannelID, src.ChainID, src.PathEnd.ChannelID).
+		Set(float64(len(sp.Dst)))
+}
+
+// recordPackets records the packet messages of a transaction of msgs sent to dst with proofs from src
+func recordPackets(dst, src *Chain, res sdk.TxResponse, err error, msgs []sdk.Msg) {
+	counter := packetsRelayed
+	if err != nil || res.Code != 0 {
+		counter = packetsFailed
+	}
+	for _, msg := range msgs {
+		if typ := packetMsgType(msg); typ != "" {
+			counter.WithLabelValues(src.ChainID, dst.ChainID, dst.PathEnd.ChannelID, typ).Inc()
+		}
+	}
+}
+
+// recordFees records the gas and fees of a transaction sent to c
+func recordFees(c *Chain, res sdk.TxResponse, err error) {
+	// transactions which failed before making it into a block do not pay any fees
+	if err != nil || res.Height == 0 {
+		return
+	}
+	txGasUsed.WithLabelValues(c.ChainID).Add(float64(res.GasUsed))
+	for _, fee := range c.txFee(uint64(res.GasWanted)) {
+		txFeesPaid.WithLabelValues(c.ChainID, fee.Denom).Add(intToFloat(fee.Amount))
+	}
+}
+
+func packetMsgType(msg sdk.Msg) string {
+	switch msg.(type) {
+	case chanTypes.MsgPacket:
+		return "recv"
+	case chanTypes.MsgAcknowledgement:
+		return "ack"
+	case chanTypes.MsgTimeout:
+		return "timeout"
+	default:
+		return ""
+	}
+}
+
+func intToFloat(i sdk.Int) float64 {
+	f, _ := new(big.Float).SetInt(i.BigInt()).Float64()
+	return f
+}
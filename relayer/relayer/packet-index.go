@@ -0,0 +1,445 @@
+package relayer
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	retry "github.com/avast/retry-go"
+	chanTypes "github.com/cosmos/cosmos-sdk/x/ibc/04-channel/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// packetBackfillBlocks is how many of the latest blocks are indexed when a packet is missing from
+// both the index and the tx indexer of the node
+const packetBackfillBlocks = 100
+
+// IndexedPacket is a packet sent on a chain, as recorded from its send_packet event
+type IndexedPacket struct {
+	// ChainID, PortID and ChannelID identify the end of the path the packet was sent from
+	ChainID   string `json:"chain-id" yaml:"chain-id"`
+	PortID    string `json:"port-id" yaml:"port-id"`
+	ChannelID string `json:"channel-id" yaml:"channel-id"`
+
+	DstPortID    string `json:"dst-port-id" yaml:"dst-port-id"`
+	DstChannelID string `json:"dst-channel-id" yaml:"dst-channel-id"`
+	Sequence     uint64 `json:"sequence" yaml:"sequence"`
+	Data         string `json:"data" yaml:"data"`
+	Timeout      uint64 `json:"timeout" yaml:"timeout"`
+	// Height is the height of the block the packet was sent in
+	Height int64 `json:"height" yaml:"height"`
+}
+
+func newIndexedPacket(chainID string, height int64, p chanTypes.Packet) IndexedPacket {
+	return IndexedPacket{
+		ChainID:      chainID,
+		PortID:       p.SourcePort,
+		ChannelID:    p.SourceChannel,
+		DstPortID:    p.DestinationPort,
+		DstChannelID: p.DestinationChannel,
+		Sequence:     p.Sequence,
+		Data:         string(p.Data),
+		Timeout:      p.TimeoutHeight,
+		Height:       height,
+	}
+}
+
+func packetKey(chainID, portID, channelID string, seq uint64) []byte {
+	return []byte(fmt.Sprintf("packet/%s/%s/%s/%020d", chainID, portID, channelID, seq))
+}
+
+func indexHeightKey(chainID string) []byte {
+	return []byte(fmt.Sprintf("height/%s", chainID))
+}
+
+// PacketIndex records the packets sent on the chains the relayer works with, so that the data of
+// unrelayed packets is available even when the tx indexer of a node is disabled. It is persisted
+// in the relayer home and, like the relay queue, only open during an operation unless it is held
+// open, as it is while the relayer runs.
+type PacketIndex struct {
+	sync.Mutex
+	home string
+
+	// db is the database while the index is held open by holds users
+	db    *dbm.GoLevelDB
+	holds int
+
+	// backfilled is the latest height up to which the blocks of each chain were backfilled when
+	// looking for missing packets, so that a packet missing again doesn't backfill them again
+	backfilled map[string]int64
+}
+
+// packetIndexes are the packet indexes of the relayer homes in use, shared by all the chains of a
+// home so that their operations don't contend for the database
+var packetIndexes = struct {
+	sync.Mutex
+	byHome map[string]*PacketIndex
+}{byHome: make(map[string]*PacketIndex)}
+
+// PacketIndex returns the packet index stored in the home of the chain
+func (src *Chain) PacketIndex() *PacketIndex {
+	packetIndexes.Lock()
+	defer packetIndexes.Unlock()
+	if _, ok := packetIndexes.byHome[src.HomePath]; !ok {
+		packetIndexes.byHome[src.HomePath] = &PacketIndex{home: src.HomePath}
+	}
+	return packetIndexes.byHome[src.HomePath]
+}
+
+func indexDir(home string) string {
+	return path.Join(home, "index")
+}
+
+func openIndexDB(home string) (db *dbm.GoLevelDB, err error) {
+	err = retry.Do(func() error {
+		db, err = dbm.NewGoLevelDB("packets", indexDir(home))
+		if err != nil {
+			return fmt.Errorf("can't open packet index database: %w", err)
+		}
+		return nil
+	})
+	return db, err
+}
+
+// HoldPacketIndex keeps the packet index of the chain open until release is called, so that it
+// is not opened again for every operation
+func (src *Chain) HoldPacketIndex() (release func(), err error) {
+	idx := src.PacketIndex()
+	idx.Lock()
+	defer idx.Unlock()
+
+	if idx.holds == 0 {
+		if idx.db, err = openIndexDB(idx.home); err != nil {
+			return nil, err
+		}
+	}
+	idx.holds++
+
+	return func() {
+		idx.Lock()
+		defer idx.Unlock()
+
+		if idx.holds--; idx.holds > 0 {
+			return
+		}
+		if err := idx.db.Close(); err != nil {
+			panic(err)
+		}
+		idx.db = nil
+	}, nil
+}
+
+// withDB runs f with the index database, which is opened for the duration of f unless it is held
+func (idx *PacketIndex) withDB(f func(db dbm.DB) error) error {
+	idx.Lock()
+	defer idx.Unlock()
+
+	if idx.db != nil {
+		return f(idx.db)
+	}
+
+	db, err := openIndexDB(idx.home)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return f(db)
+}
+
+// Put records the packets
+func (idx *PacketIndex) Put(packets ...IndexedPacket) error {
+	return idx.withDB(func(db dbm.DB) error {
+		batch := db.NewBatch()
+		defer batch.Close()
+		if err := putPackets(batch, packets); err != nil {
+			return err
+		}
+		return batch.WriteSync()
+	})
+}
+
+// putBlock records the packets sent on the chain in the block at the given height. The indexed
+// height of the chain moves up to the block if the block follows on from it.
+func (idx *PacketIndex) putBlock(chainID string, height int64, packets []IndexedPacket) error {
+	return idx.withDB(func(db dbm.DB) error {
+		batch := db.NewBatch()
+		defer batch.Close()
+		if err := putPackets(batch, packets); err != nil {
+			return err
+		}
+
+		last, err := indexHeight(db, chainID)
+		if err != nil {
+			return err
+		}
+		if height == last+1 {
+			batch.Set(indexHeightKey(chainID), []byte(strconv.FormatInt(height, 10)))
+		}
+		return batch.WriteSync()
+	})
+}
+
+// setHeight sets the indexed height of the chain, when it is higher than the current one
+func (idx *PacketIndex) setHeight(chainID string, height int64) error {
+	return idx.withDB(func(db dbm.DB) error {
+		last, err := indexHeight(db, chainID)
+		if err != nil || height <= last {
+			return err
+		}
+		return db.SetSync(indexHeightKey(chainID), []byte(strconv.FormatInt(height, 10)))
+	})
+}
+
+func putPackets(batch dbm.Batch, packets []IndexedPacket) error {
+	for _, p := range packets {
+		bz, err := json.Marshal(p)
+		if err != nil {
+			return err
+		}
+		batch.Set(packetKey(p.ChainID, p.PortID, p.ChannelID, p.Sequence), bz)
+	}
+	return nil
+}
+
+// Get returns the packet sent on the given end of a path with the given sequence, and false if it
+// is not indexed
+func (idx *PacketIndex) Get(end *PathEnd, seq uint64) (p IndexedPacket, found bool, err error) {
+	err = idx.withDB(func(db dbm.DB) error {
+		bz, err := db.Get(packetKey(end.ChainID, end.PortID, end.ChannelID, seq))
+		if err != nil || bz == nil {
+			return err
+		}
+		found = true
+		return json.Unmarshal(bz, &p)
+	})
+	return p, found, err
+}
+
+// Height returns the height up to which all the blocks of the chain are indexed, or 0 if the chain
+// was never indexed
+func (idx *PacketIndex) Height(chainID string) (height int64, err error) {
+	err = idx.withDB(func(db dbm.DB) error {
+		height, err = indexHeight(db, chainID)
+		return err
+	})
+	return height, err
+}
+
+func indexHeight(db dbm.DB, chainID string) (int64, error) {
+	bz, err := db.Get(indexHeightKey(chainID))
+	if err != nil || bz == nil {
+		return 0, err
+	}
+	return strconv.ParseInt(string(bz), 10, 64)
+}
+
+// indexTxEvents records the packets sent by the transaction of the events. The indexed height is
+// left to indexNewBlock, as the other transactions of the block may still be on their way.
+func (src *Chain) indexTxEvents(events map[string][]string) error {
+	if _, ok := events["send_packet.packet_sequence"]; !ok {
+		return nil
+	}
+
+	height := getEventHeight(events)
+	packets, err := packetsFromEvents(events, "send_packet", "packet_data")
+	if err != nil {
+		return err
+	}
+
+	indexed := make([]IndexedPacket, 0, len(packets))
+	for _, p := range packets {
+		indexed = append(indexed, newIndexedPacket(src.ChainID, height, p))
+	}
+	return src.PacketIndex().Put(indexed...)
+}
+
+// indexNewBlock moves the indexed height of the chain up to the block before the new block, as
+// the tx events of a block are all published before the next block. Blocks missed since the
+// indexed height are backfilled first.
+func (src *Chain) indexNewBlock(ev ctypes.ResultEvent) error {
+	data, ok := ev.Data.(tmtypes.EventDataNewBlock)
+	if !ok || data.Block == nil {
+		return nil
+	}
+	height := data.Block.Height - 1
+
+	last, err := src.PacketIndex().Height(src.ChainID)
+	switch {
+	case err != nil:
+		return err
+	case last == 0:
+		return src.PacketIndex().setHeight(src.ChainID, height)
+	case last >= height:
+		return nil
+	case last < height-1:
+		if _, err = src.BackfillPacketIndex(last+1, height-1); err != nil {
+			return err
+		}
+	}
+	return src.PacketIndex().putBlock(src.ChainID, height, nil)
+}
+
+// BackfillPacketIndex records the packets sent on the chain in the blocks from one height to
+// another, reading the results of the blocks rather than searching the tx indexer of the node. It
+// returns the number of packets found.
+func (src *Chain) BackfillPacketIndex(from, to int64) (indexed int, err error) {
+	for h := from; h <= to; h++ {
+		height := h
+		res, err := src.Client.BlockResults(&height)
+		if err != nil {
+			return indexed, fmt.Errorf("failed to query block results of %s at height %d: %w", src.ChainID, height, err)
+		}
+
+		packets := []IndexedPacket{}
+		for _, tx := range res.TxsResults {
+			if !tx.IsOK() {
+				continue
+			}
+			sent, err := packetsFromEvents(abciEventsMap(tx.Events), "send_packet", "packet_data")
+			if err != nil {
+				return indexed, err
+			}
+			for _, p := range sent {
+				packets = append(packets, newIndexedPacket(src.ChainID, height, p))
+			}
+		}
+
+		if err = src.PacketIndex().putBlock(src.ChainID, height, packets); err != nil {
+			return indexed, err
+		}
+		indexed += len(packets)
+	}
+	return indexed, nil
+}
+
+// backfillFrom returns the first block to backfill to look for a missing packet of the chain in
+// the latest blocks up to the given height, skipping the blocks backfilled for previous misses
+func (idx *PacketIndex) backfillFrom(chainID string, latest int64) int64 {
+	idx.Lock()
+	defer idx.Unlock()
+	from := latest - packetBackfillBlocks + 1
+	if last := idx.backfilled[chainID]; from <= last {
+		from = last + 1
+	}
+	if from < 1 {
+		from = 1
+	}
+	return from
+}
+
+// setBackfilled records that the blocks of the chain up to the given height were backfilled
+func (idx *PacketIndex) setBackfilled(chainID string, height int64) {
+	idx.Lock()
+	defer idx.Unlock()
+	if idx.backfilled == nil {
+		idx.backfilled = make(map[string]int64)
+	}
+	if height > idx.backfilled[chainID] {
+		idx.backfilled[chainID] = height
+	}
+}
+
+// CatchUpPacketIndex indexes the blocks of the chain since the latest indexed height, which were
+// missed while the relayer was not running. A chain which was never indexed starts from its
+// latest height.
+func (src *Chain) CatchUpPacketIndex() error {
+	latest, err := src.QueryLatestHeight()
+	if err != nil {
+		return err
+	}
+
+	last, err := src.PacketIndex().Height(src.ChainID)
+	switch {
+	case err != nil:
+		return err
+	case last == 0:
+		return src.PacketIndex().setHeight(src.ChainID, latest)
+	case last >= latest:
+		return nil
+	}
+
+	indexed, err := src.BackfillPacketIndex(last+1, latest)
+	if err != nil {
+		return err
+	}
+	src.Log("packet index caught up", "height", latest, "from_height", last+1, "count", indexed)
+	return nil
+}
+
+// IndexPackets records the packets sent on the chain from its events until stop is closed,
+// subscribing again with backoff whenever the subscriptions fail. It runs once per chain, however
+// many paths relay to the chain.
+func (src *Chain) IndexPackets(stop <-chan struct{}) {
+	var attempts uint64
+	for {
+		start := time.Now()
+		err := src.indexEvents(stop)
+		if err == nil {
+			return
+		}
+		src.Error(err)
+
+		// a loop which ran for a while starts over with the shortest backoff
+		if time.Since(start) > queueMaxBackoff {
+			attempts = 0
+		}
+		attempts++
+
+		select {
+		case <-stop:
+			return
+		case <-time.After(backoff(attempts)):
+		}
+	}
+}
+
+// indexEvents indexes the tx and block events of the chain. It returns nil once stop is closed
+// and an error if a subscription fails.
+func (src *Chain) indexEvents(stop <-chan struct{}) error {
+	txEvts, blockEvts, cancel, err := src.subscribeRelayEvents()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	for {
+		select {
+		case ev, ok := <-txEvts:
+			if !ok {
+				return errSubscriptionClosed(src)
+			}
+			if err := src.indexTxEvents(ev.Events); err != nil {
+				src.Error(err)
+			}
+		case ev, ok := <-blockEvts:
+			if !ok {
+				return errSubscriptionClosed(src)
+			}
+			if err := src.indexNewBlock(ev); err != nil {
+				src.Error(err)
+			}
+		case <-stop:
+			return nil
+		}
+	}
+}
+
+// abciEventsMap returns the events in the form of the events of an event subscription, a map of
+// type.key to the values of the attribute
+func abciEventsMap(events []abci.Event) map[string][]string {
+	out := make(map[string][]string)
+	for _, e := range events {
+		for _, attr := range e.Attributes {
+			key := e.Type + "." + string(attr.Key)
+			out[key] = append(out[key], string(attr.Value))
+		}
+	}
+	return out
+}
@@ -35,14 +35,7 @@ func RelayUnRelayedPacketsOrderedChan(src, dst *Chain) error {
 		return err
 	}
 
-	// create the appropriate update client messages
 	msgs := &RelayMsgs{Src: []sdk.Msg{}, Dst: []sdk.Msg{}}
-	if len(sp.Src) > 0 {
-		msgs.Dst = append(msgs.Dst, dst.PathEnd.UpdateClient(hs[src.ChainID], dst.MustGetAddress()))
-	}
-	if len(sp.Dst) > 0 {
-		msgs.Src = append(msgs.Src, src.PathEnd.UpdateClient(hs[dst.ChainID], src.MustGetAddress()))
-	}
 
 	// add messages for src -> dst
 	for _, seq := range sp.Src {
@@ -59,16 +52,23 @@ func RelayUnRelayedPacketsOrderedChan(src, dst *Chain) error {
 	}
 
 	if !msgs.Ready() {
-		src.Log(fmt.Sprintf("- No packets to relay between [%s]port{%s} and [%s]port{%s}", src.ChainID, src.PathEnd.PortID, dst.ChainID, dst.PathEnd.PortID))
+		src.Log("no packets to relay", "dst_chain_id", dst.ChainID, "dst_port", dst.PathEnd.PortID)
 		return nil
 	}
 
-	// TODO: increase the amount of gas as the number of messages increases
-	// notify the user of that
+	// prepend the appropriate update client messages
+	if len(msgs.Dst) > 0 {
+		msgs.Dst = append([]sdk.Msg{dst.PathEnd.UpdateClient(hs[src.ChainID], dst.MustGetAddress())}, msgs.Dst...)
+	}
+	if len(msgs.Src) > 0 {
+		msgs.Src = append([]sdk.Msg{src.PathEnd.UpdateClient(hs[dst.ChainID], src.MustGetAddress())}, msgs.Src...)
+	}
 
+	// the gas of each transaction is estimated by simulation when simulate-gas is set, and
+	// transactions which run out of gas are split by Send
 	if msgs.Send(src, dst); msgs.success {
-		src.Log(fmt.Sprintf("★ Clients updated: [%s]client(%s) and [%s]client(%s)",
-			src.ChainID, src.PathEnd.ClientID, dst.ChainID, dst.PathEnd.ClientID))
+		src.Log("clients updated", "client", src.PathEnd.ClientID,
+			"dst_chain_id", dst.ChainID, "dst_client", dst.PathEnd.ClientID)
 		if len(msgs.Dst) > 1 {
 			src.logPacketsRelayed(dst, len(msgs.Dst)-1)
 		}
@@ -94,8 +94,6 @@ func (src *Chain) SendTransferBothSides(dst *Chain, amount sdk.Coin, dstAddr sdk
 		return err
 	}
 
-	timeoutHeight := dstHeader.GetHeight() + uint64(defaultPacketTimeout)
-
 	// MsgTransfer will call SendPacket on src chain
 	txs := RelayMsgs{
 		Src: []sdk.Msg{src.PathEnd.MsgTransfer(dst.PathEnd, dstHeader.GetHeight(), sdk.NewCoins(amount), dstAddr, src.MustGetAddress())},
@@ -146,23 +144,19 @@ func (src *Chain) SendTransferBothSides(dst *Chain, amount sdk.Coin, dstAddr sdk
 		return err
 	}
 
-	// reconstructing packet data here instead of retrieving from an indexed node
-	xferPacket := src.PathEnd.XferPacket(
-		sdk.NewCoins(amount),
-		src.MustGetAddress(),
-		dstAddr,
-	)
+	// the packet data is read from the send_packet event rather than reconstructed
+	xferPacket, timeout, err := src.queryPacketDataAndTimeout(hs[src.ChainID].GetHeight(), seqSend-1)
+	if err != nil {
+		return err
+	}
 
-	// Debugging by simply passing in the packet information that we know was sent earlier in the SendPacket
-	// part of the command. In a real relayer, this would be a separate command that retrieved the packet
-	// information from an indexing node
 	txs = RelayMsgs{
 		Dst: []sdk.Msg{
 			dst.PathEnd.UpdateClient(hs[src.ChainID], dst.MustGetAddress()),
 			dst.PathEnd.MsgRecvPacket(
 				src.PathEnd,
 				seqRecv.NextSequenceRecv,
-				timeoutHeight,
+				timeout,
 				xferPacket,
 				chanTypes.NewPacketResponse(
 					src.PathEnd.PortID,
@@ -172,7 +166,7 @@ func (src *Chain) SendTransferBothSides(dst *Chain, amount sdk.Coin, dstAddr sdk
 						dst.PathEnd,
 						seqSend-1,
 						xferPacket,
-						timeoutHeight,
+						timeout,
 					),
 					srcCommitRes.Proof.Proof,
 					int64(srcCommitRes.ProofHeight),
@@ -214,28 +208,22 @@ func (src *Chain) SendTransferMsg(dst *Chain, amount sdk.Coin, dstAddr sdk.AccAd
 }
 
 func addPacketMsg(src, dst *Chain, srcH, dstH *tmclient.Header, seq uint64, msgs *RelayMsgs, source bool) error {
-	eve, err := ParseEvents(fmt.Sprintf(defaultPacketQuery, src.PathEnd.ChannelID, seq))
+	pd, to, err := src.queryPacketDataAndTimeout(srcH.GetHeight(), seq)
 	if err != nil {
 		return err
 	}
 
-	tx, err := src.QueryTxs(srcH.GetHeight(), 1, 1000, eve)
-	switch {
-	case err != nil:
-		return err
-	case tx.Count == 0:
-		return fmt.Errorf("no transactions returned with query")
-	case tx.Count > 1:
-		return fmt.Errorf("more than one transaction returned with query")
-	}
-
-	pd, to, qSeq, err := src.packetDataAndTimeoutFromQueryResponse(src, tx.Txs[0])
+	// packets which can no longer be received on dst are timed out on src instead
+	timeoutMsg, err := src.TimeoutMsg(dst, pd, to, int64(seq), dstH.Height-1)
 	if err != nil {
 		return err
-	}
-
-	if seq != qSeq {
-		return fmt.Errorf("Different sequence number from query (%d vs %d)", seq, qSeq)
+	} else if timeoutMsg != nil {
+		if source {
+			msgs.Src = append(msgs.Src, timeoutMsg)
+		} else {
+			msgs.Dst = append(msgs.Dst, timeoutMsg)
+		}
+		return nil
 	}
 
 	var (
@@ -267,6 +255,156 @@ func addPacketMsg(src, dst *Chain, srcH, dstH *tmclient.Header, seq uint64, msgs
 	return nil
 }
 
+// PacketFilter returns whether a packet should be relayed
+type PacketFilter func(chanTypes.Packet) bool
+
+// RelayPacketTimeouts times out the packets sent by src which were not received on dst before
+// their timeout height
+func RelayPacketTimeouts(src, dst *Chain) error {
+	return relayPacketTimeouts(src, dst, nil)
+}
+
+// relayPacketTimeouts times out the expired packets sent by src which pass the filter, or all of
+// them if the filter is nil
+func relayPacketTimeouts(src, dst *Chain, filter PacketFilter) error {
+	// look for expired packets before paying for the lite client updates
+	heights, err := QueryLatestHeights(src, dst)
+	if err != nil {
+		return err
+	}
+
+	sp, err := UnrelayedSequences(src, dst, heights[src.ChainID], heights[dst.ChainID])
+	if err != nil {
+		return err
+	}
+
+	type expiredPacket struct {
+		seq     uint64
+		data    []byte
+		timeout uint64
+	}
+
+	expired := []expiredPacket{}
+	for _, seq := range sp.Src {
+		pd, to, err := src.queryPacketDataAndTimeout(uint64(heights[src.ChainID]), seq)
+		if err != nil {
+			return err
+		}
+		if filter != nil && !filter(src.PathEnd.NewPacket(dst.PathEnd, seq, pd, to)) {
+			continue
+		}
+		if uint64(heights[dst.ChainID]) >= to {
+			expired = append(expired, expiredPacket{seq, pd, to})
+		}
+	}
+
+	if len(expired) == 0 {
+		return nil
+	}
+
+	hs, err := UpdatesWithHeaders(src, dst)
+	if err != nil {
+		return err
+	}
+
+	msgs := &RelayMsgs{Src: []sdk.Msg{}, Dst: []sdk.Msg{}}
+	for _, p := range expired {
+		msg, err := src.TimeoutMsg(dst, p.data, p.timeout, int64(p.seq), hs[dst.ChainID].Height-1)
+		if err != nil {
+			return err
+		} else if msg != nil {
+			msgs.Src = append(msgs.Src, msg)
+		}
+	}
+
+	if !msgs.Ready() {
+		return nil
+	}
+
+	msgs.Src = append([]sdk.Msg{src.PathEnd.UpdateClient(hs[dst.ChainID], src.MustGetAddress())}, msgs.Src...)
+	if msgs.Send(src, dst); msgs.success {
+		src.Log("timed out packets", "count", len(msgs.Src)-1, "dst_chain_id", dst.ChainID, "dst_port", dst.PathEnd.PortID)
+	}
+	return nil
+}
+
+// queryPacketDataAndTimeout returns the data and the timeout of the packet sent by src with the
+// given sequence as of the given height, or the latest height if 0. The packet is looked up in the
+// packet index first and in the tx indexer of the node next. Packets found in neither are looked
+// for in the latest blocks of the chain, which works even when the node does not index txs. The
+// blocks looked at for a missing packet are not looked at again for the next ones.
+func (src *Chain) queryPacketDataAndTimeout(height, seq uint64) (packetData []byte, timeout uint64, err error) {
+	if packetData, timeout, found, err := src.indexedPacketDataAndTimeout(height, seq); err != nil || found {
+		return packetData, timeout, err
+	}
+
+	packetData, timeout, err = src.searchPacketDataAndTimeout(height, seq)
+	if err == nil {
+		return packetData, timeout, nil
+	}
+
+	latest := int64(height)
+	if latest == 0 {
+		if latest, err = src.QueryLatestHeight(); err != nil {
+			return nil, 0, err
+		}
+	}
+	idx := src.PacketIndex()
+	from := idx.backfillFrom(src.ChainID, latest)
+	if from > latest {
+		return nil, 0, fmt.Errorf("packet %d not found on %s: %w", seq, src.ChainID, err)
+	}
+	if _, bErr := src.BackfillPacketIndex(from, latest); bErr != nil {
+		src.Error(bErr)
+		return nil, 0, err
+	}
+	idx.setBackfilled(src.ChainID, latest)
+
+	if packetData, timeout, found, iErr := src.indexedPacketDataAndTimeout(height, seq); iErr != nil || found {
+		return packetData, timeout, iErr
+	}
+	return nil, 0, fmt.Errorf("packet %d not found on %s: %w", seq, src.ChainID, err)
+}
+
+// indexedPacketDataAndTimeout returns the data and the timeout of the packet sent by src with the
+// given sequence from the packet index, if it was sent by the given height
+func (src *Chain) indexedPacketDataAndTimeout(height, seq uint64) (packetData []byte, timeout uint64, found bool, err error) {
+	p, found, err := src.PacketIndex().Get(src.PathEnd, seq)
+	if err != nil || !found || (height != 0 && uint64(p.Height) > height) {
+		return nil, 0, false, err
+	}
+	return []byte(p.Data), p.Timeout, true, nil
+}
+
+// searchPacketDataAndTimeout returns the data and the timeout of the packet sent by src with the
+// given sequence, found by searching the tx indexer for the send_packet event of its transaction
+func (src *Chain) searchPacketDataAndTimeout(height, seq uint64) (packetData []byte, timeout uint64, err error) {
+	eve, err := ParseEvents(fmt.Sprintf(defaultPacketQuery, src.PathEnd.ChannelID, seq))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tx, err := src.QueryTxs(height, 1, 1000, eve)
+	switch {
+	case err != nil:
+		return nil, 0, err
+	case tx.Count == 0:
+		return nil, 0, fmt.Errorf("no transactions returned with query")
+	case tx.Count > 1:
+		return nil, 0, fmt.Errorf("more than one transaction returned with query")
+	}
+
+	pd, to, qSeq, err := src.packetDataAndTimeoutFromQueryResponse(src, tx.Txs[0])
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if seq != qSeq {
+		return nil, 0, fmt.Errorf("Different sequence number from query (%d vs %d)", seq, qSeq)
+	}
+	return pd, to, nil
+}
+
 func (src *Chain) packetDataAndTimeoutFromQueryResponse(dst *Chain, res sdk.TxResponse) (packetData []byte, timeout uint64, seq uint64, err error) {
 	// Set sdk config to use custom Bech32 account prefix
 	sdkConf := sdk.GetConfig()
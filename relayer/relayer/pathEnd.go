@@ -1,6 +1,7 @@
 package relayer
 
 import (
+	"fmt"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -272,3 +273,86 @@ func (src *Chain) PacketMsg(dst *Chain, xferPacket []byte, timeout uint64, seq i
 		src.MustGetAddress(),
 	), nil
 }
+
+// AckMsg returns a new MsgAcknowledgement for returning the acknowledgement written by dst
+// for a packet sent by src
+func (src *Chain) AckMsg(dst *Chain, packetData, ack []byte, timeout uint64, seq int64, dstAckRes CommitmentResponse) sdk.Msg {
+	return src.PathEnd.MsgAck(
+		src.PathEnd.NewPacket(
+			dst.PathEnd,
+			uint64(seq),
+			packetData,
+			timeout,
+		),
+		ack,
+		chanTypes.NewPacketResponse(
+			dst.PathEnd.PortID,
+			dst.PathEnd.ChannelID,
+			uint64(seq),
+			dst.PathEnd.NewPacket(
+				src.PathEnd,
+				uint64(seq),
+				packetData,
+				timeout,
+			),
+			dstAckRes.Proof.Proof,
+			int64(dstAckRes.ProofHeight),
+		),
+		src.MustGetAddress(),
+	)
+}
+
+// TimeoutMsg returns a new MsgTimeout for a packet sent by src which dst did not receive before
+// its timeout height. It returns nil if the packet has not timed out or has been received at
+// the given dst height.
+func (src *Chain) TimeoutMsg(dst *Chain, packetData []byte, timeout uint64, seq int64, dstHeight int64) (sdk.Msg, error) {
+	if uint64(dstHeight+1) < timeout {
+		return nil, nil
+	}
+
+	srcChan, err := src.QueryChannel(0)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		nextSeqRecv uint64
+		proof       CommitmentResponse
+	)
+
+	switch srcChan.Channel.Channel.Ordering {
+	case chanState.ORDERED:
+		// prove that the next sequence to receive on dst has not moved past the packet
+		recvRes, err := dst.QueryNextSeqRecv(dstHeight)
+		if err != nil {
+			return nil, err
+		} else if recvRes.NextSequenceRecv > uint64(seq) {
+			return nil, nil
+		}
+		nextSeqRecv = recvRes.NextSequenceRecv
+		proof = CommitmentResponse{Proof: recvRes.Proof, ProofHeight: recvRes.ProofHeight}
+	default:
+		// prove that dst has written no acknowledgement for the packet
+		if proof, err = dst.QueryPacketAck(dstHeight, seq); err != nil {
+			return nil, err
+		} else if proof.Data != nil {
+			return nil, nil
+		}
+	}
+
+	if proof.Proof.Proof == nil {
+		return nil, fmt.Errorf("- [%s]@{%d} - Timeout Proof is nil seq(%d)", dst.ChainID, dstHeight, seq)
+	}
+
+	packet := src.PathEnd.NewPacket(dst.PathEnd, uint64(seq), packetData, timeout)
+	return src.PathEnd.MsgTimeout(
+		packet,
+		nextSeqRecv,
+		chanTypes.PacketResponse{
+			Packet:      packet,
+			Proof:       proof.Proof,
+			ProofHeight: proof.ProofHeight,
+		},
+		src.MustGetAddress(),
+	), nil
+}
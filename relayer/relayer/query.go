@@ -776,7 +776,8 @@ type CommitmentResponse struct {
 	ProofHeight uint64                      `json:"proof_height,omitempty" yaml:"proof_height,omitempty"`
 }
 
-// QueryPacketAck returns the packet commitment proof at a given height
+// QueryPacketAck returns the packet acknowledgement proof at a given height
+// NOTE: when there is no acknowledgement, Data is nil and Proof proves its absence
 func (c *Chain) QueryPacketAck(height, seq int64) (comRes CommitmentResponse, err error) {
 	if !c.PathSet() {
 		return comRes, c.ErrPathNotSet()
@@ -792,8 +793,6 @@ func (c *Chain) QueryPacketAck(height, seq int64) (comRes CommitmentResponse, er
 	res, err := c.QueryABCI(req)
 	if err != nil {
 		return comRes, qPacketAckErr(err)
-	} else if res.Value == nil {
-		return comRes, nil
 	}
 
 	return CommitmentResponse{
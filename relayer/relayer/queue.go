@@ -0,0 +1,217 @@
+package relayer
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sync"
+	"time"
+
+	retry "github.com/avast/retry-go"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// Types of relay jobs
+const (
+	RelayJobRecv = "recv"
+	RelayJobAck  = "ack"
+)
+
+var (
+	// queueAttemptDelay leaves time for the first attempt of a new job before the worker retries it
+	queueAttemptDelay = time.Minute
+	queueMinBackoff   = 10 * time.Second
+	queueMaxBackoff   = 10 * time.Minute
+)
+
+// RelayJob is a packet, or the acknowledgement of a packet, which has to be relayed to a chain
+type RelayJob struct {
+	// ChainID, PortID and ChannelID identify the end of the path the job relays to
+	ChainID   string `json:"chain-id" yaml:"chain-id"`
+	PortID    string `json:"port-id" yaml:"port-id"`
+	ChannelID string `json:"channel-id" yaml:"channel-id"`
+
+	Type     string `json:"type" yaml:"type"`
+	Sequence uint64 `json:"sequence" yaml:"sequence"`
+	// Data and Timeout are the ones of the packet to receive
+	Data    string `json:"data,omitempty" yaml:"data,omitempty"`
+	Timeout uint64 `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	// Ack is the acknowledgement to return
+	Ack string `json:"ack,omitempty" yaml:"ack,omitempty"`
+
+	Attempts    uint64    `json:"attempts" yaml:"attempts"`
+	LastError   string    `json:"last-error,omitempty" yaml:"last-error,omitempty"`
+	NextAttempt time.Time `json:"next-attempt" yaml:"next-attempt"`
+	CreatedAt   time.Time `json:"created-at" yaml:"created-at"`
+}
+
+// NewRelayJob returns a job relaying a packet to the given end of a path
+func NewRelayJob(end *PathEnd, jobType string, seq uint64) RelayJob {
+	return RelayJob{
+		ChainID:   end.ChainID,
+		PortID:    end.PortID,
+		ChannelID: end.ChannelID,
+		Type:      jobType,
+		Sequence:  seq,
+	}
+}
+
+func (j RelayJob) key() []byte {
+	return []byte(fmt.Sprintf("%s%s/%020d", queueEndPrefix(j.ChainID, j.PortID, j.ChannelID), j.Type, j.Sequence))
+}
+
+func queueEndPrefix(chainID, portID, channelID string) string {
+	return fmt.Sprintf("%s/%s/%s/", chainID, portID, channelID)
+}
+
+// backoff returns how long to wait before retrying a job which failed the given number of times
+func backoff(attempts uint64) time.Duration {
+	delay := queueMinBackoff
+	for i := uint64(1); i < attempts && delay < queueMaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > queueMaxBackoff {
+		return queueMaxBackoff
+	}
+	return delay
+}
+
+// RelayQueue is the queue of relay jobs, persisted in the relayer home so that jobs which fail
+// are retried, including after a restart. The database is only open during an operation so
+// that it can be inspected while the relayer runs.
+type RelayQueue struct {
+	sync.Mutex
+	home string
+}
+
+// NewRelayQueue returns the relay queue stored in the given relayer home
+func NewRelayQueue(home string) *RelayQueue {
+	return &RelayQueue{home: home}
+}
+
+// relayQueues are the relay queues of the relayer homes in use, shared by all the paths of a home
+// so that their operations don't contend for the database
+var relayQueues = struct {
+	sync.Mutex
+	byHome map[string]*RelayQueue
+}{byHome: make(map[string]*RelayQueue)}
+
+// RelayQueue returns the relay queue stored in the home of the chain
+func (src *Chain) RelayQueue() *RelayQueue {
+	relayQueues.Lock()
+	defer relayQueues.Unlock()
+	if _, ok := relayQueues.byHome[src.HomePath]; !ok {
+		relayQueues.byHome[src.HomePath] = NewRelayQueue(src.HomePath)
+	}
+	return relayQueues.byHome[src.HomePath]
+}
+
+func queueDir(home string) string {
+	return path.Join(home, "queue")
+}
+
+// withDB opens the queue database for the duration of f
+func (q *RelayQueue) withDB(f func(db dbm.DB) error) (err error) {
+	q.Lock()
+	defer q.Unlock()
+
+	var db *dbm.GoLevelDB
+	if err = retry.Do(func() error {
+		db, err = dbm.NewGoLevelDB("queue", queueDir(q.home))
+		if err != nil {
+			return fmt.Errorf("can't open relay queue database: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return f(db)
+}
+
+// Push adds a job to the queue unless it is already queued
+func (q *RelayQueue) Push(job RelayJob) error {
+	return q.PushDelayed(job, 0)
+}
+
+// PushDelayed adds a job to the queue unless it is already queued, for a first attempt which is
+// only made once the given delay has passed. The worker leaves time for that attempt before
+// retrying the job.
+func (q *RelayQueue) PushDelayed(job RelayJob, delay time.Duration) error {
+	return q.withDB(func(db dbm.DB) error {
+		if has, err := db.Has(job.key()); err != nil || has {
+			return err
+		}
+		job.CreatedAt = time.Now()
+		job.NextAttempt = job.CreatedAt.Add(delay + queueAttemptDelay)
+		bz, err := json.Marshal(job)
+		if err != nil {
+			return err
+		}
+		return db.SetSync(job.key(), bz)
+	})
+}
+
+// Remove removes a job from the queue
+func (q *RelayQueue) Remove(job RelayJob) error {
+	return q.withDB(func(db dbm.DB) error {
+		return db.DeleteSync(job.key())
+	})
+}
+
+// Failed records the failure of a job and schedules its next attempt
+func (q *RelayQueue) Failed(job RelayJob, jobErr error) error {
+	return q.withDB(func(db dbm.DB) error {
+		// the job may have been relayed by another attempt in the meantime
+		if has, err := db.Has(job.key()); err != nil || !has {
+			return err
+		}
+		job.Attempts++
+		job.LastError = jobErr.Error()
+		job.NextAttempt = time.Now().Add(backoff(job.Attempts))
+		bz, err := json.Marshal(job)
+		if err != nil {
+			return err
+		}
+		return db.SetSync(job.key(), bz)
+	})
+}
+
+// Jobs returns the jobs queued for the given end of a path
+func (q *RelayQueue) Jobs(end *PathEnd) (jobs []RelayJob, err error) {
+	prefix := []byte(queueEndPrefix(end.ChainID, end.PortID, end.ChannelID))
+	err = q.withDB(func(db dbm.DB) error {
+		// the prefix ends with '/' and '0' is the next byte
+		iter, err := db.Iterator(prefix, append(prefix[:len(prefix)-1:len(prefix)-1], '0'))
+		if err != nil {
+			return err
+		}
+		defer iter.Close()
+
+		for ; iter.Valid(); iter.Next() {
+			var job RelayJob
+			if err = json.Unmarshal(iter.Value(), &job); err != nil {
+				return err
+			}
+			jobs = append(jobs, job)
+		}
+		return nil
+	})
+	return jobs, err
+}
+
+// DueJobs returns the jobs queued for the given end of a path which are due for an attempt
+func (q *RelayQueue) DueJobs(end *PathEnd, now time.Time) ([]RelayJob, error) {
+	jobs, err := q.Jobs(end)
+	if err != nil {
+		return nil, err
+	}
+	due := []RelayJob{}
+	for _, job := range jobs {
+		if !job.NextAttempt.After(now) {
+			due = append(due, job)
+		}
+	}
+	return due, nil
+}
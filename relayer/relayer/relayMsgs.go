@@ -42,26 +42,15 @@ func (r *RelayMsgs) Send(src, dst *Chain) {
 	// TODO: Parallelize? Maybe?
 	if len(r.Src) > 0 {
 		// Submit the transactions to src chain
-		res, err := src.SendMsgs(r.Src)
-		if err != nil || res.Code != 0 {
-			src.LogFailedTx(res, err, r.Src)
+		if !sendSplit(src, dst, r.Src) {
 			failed = true
-		} else {
-			// NOTE: Add more data to this such as identifiers
-			src.LogSuccessTx(res, r.Src)
 		}
 	}
 
 	if len(r.Dst) > 0 {
 		// Submit the transactions to dst chain
-		res, err := dst.SendMsgs(r.Dst)
-		if err != nil || res.Code != 0 {
-			dst.LogFailedTx(res, err, r.Dst)
+		if !sendSplit(dst, src, r.Dst) {
 			failed = true
-		} else {
-			// NOTE: Add more data to this such as identifiers
-			dst.LogSuccessTx(res, r.Dst)
-
 		}
 	}
 
@@ -72,6 +61,37 @@ func (r *RelayMsgs) Send(src, dst *Chain) {
 	r.success = true
 }
 
+// sendSplit sends the msgs to the to chain in one transaction. If the transaction runs out of gas,
+// the msgs are split in halves which are sent in order, so the client update leading a batch
+// lands before the packets proven against it.
+func sendSplit(to, from *Chain, msgs []sdk.Msg) bool {
+	res, err := to.SendMsgs(msgs)
+	recordFees(to, res, err)
+	if err == nil && isOutOfGas(res) && len(msgs) > 1 {
+		to.Log("transaction out of gas, splitting it", "height", res.Height, "tx_hash", res.TxHash,
+			"msgs", len(msgs), "gas_wanted", res.GasWanted, "gas_used", res.GasUsed)
+		half := len(msgs) / 2
+		return sendSplit(to, from, msgs[:half]) && sendSplit(to, from, msgs[half:])
+	}
+
+	recordPackets(to, from, res, err, msgs)
+	if err != nil || res.Code != 0 {
+		to.LogFailedTx(res, err, msgs)
+		return false
+	}
+	// NOTE: Add more data to this such as identifiers
+	to.LogSuccessTx(res, msgs)
+	return true
+}
+
+// sendErr sends the messages and returns an error if any transaction failed
+func (r *RelayMsgs) sendErr(src, dst *Chain) error {
+	if r.Send(src, dst); !r.success {
+		return fmt.Errorf("failed to relay %s", getMsgAction(append(append([]sdk.Msg{}, r.Src...), r.Dst...)))
+	}
+	return nil
+}
+
 func getMsgAction(msgs []sdk.Msg) string {
 	var out string
 	for i, msg := range msgs {
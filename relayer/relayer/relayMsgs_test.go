@@ -0,0 +1,61 @@
+package relayer
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// outOfGasOver makes the transactions with more than max msgs run out of gas
+func outOfGasOver(max int) func(tx auth.StdTx) abci.ResponseDeliverTx {
+	return func(tx auth.StdTx) abci.ResponseDeliverTx {
+		if len(tx.Msgs) > max {
+			return abci.ResponseDeliverTx{Codespace: sdkerrors.RootCodespace, Code: sdkerrors.ErrOutOfGas.ABCICode()}
+		}
+		return abci.ResponseDeliverTx{}
+	}
+}
+
+func TestSendSplitOutOfGas(t *testing.T) {
+	to, client := newStubChain(t, "relayer")
+	from, _ := newStubChain(t, "relayer")
+	addr := to.mustKeyAddress(t, "relayer")
+	client.deliver = outOfGasOver(2)
+
+	msgs := []sdk.Msg{}
+	for i := int64(1); i <= 5; i++ {
+		msgs = append(msgs, bank.NewMsgSend(addr, addr, sdk.NewCoins(sdk.NewInt64Coin("stake", i))))
+	}
+	require.True(t, sendSplit(to, from, msgs))
+
+	// the halves are sent in order, and split again while they run out of gas
+	sizes := []int{}
+	delivered := []sdk.Msg{}
+	for _, tx := range client.txs {
+		sizes = append(sizes, len(tx.Msgs))
+		if len(tx.Msgs) <= 2 {
+			delivered = append(delivered, tx.Msgs...)
+		}
+	}
+	require.Equal(t, []int{5, 2, 3, 1, 2}, sizes)
+	require.Equal(t, msgs, delivered)
+}
+
+func TestSendSplitSingleMsgOutOfGas(t *testing.T) {
+	to, client := newStubChain(t, "relayer")
+	from, _ := newStubChain(t, "relayer")
+	addr := to.mustKeyAddress(t, "relayer")
+	client.deliver = outOfGasOver(0)
+
+	require.False(t, sendSplit(to, from, []sdk.Msg{newTestSend(addr)}))
+	require.Equal(t, 1, client.broadcasts())
+
+	// a batch fails as soon as one of its halves can't be sent
+	require.False(t, sendSplit(to, from, []sdk.Msg{newTestSend(addr), newTestSend(addr)}))
+	require.Equal(t, 3, client.broadcasts())
+}
@@ -0,0 +1,192 @@
+package relayer
+
+import (
+	"fmt"
+	"sync"
+
+	ckeys "github.com/cosmos/cosmos-sdk/client/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	chanTypes "github.com/cosmos/cosmos-sdk/x/ibc/04-channel/types"
+	tmclient "github.com/cosmos/cosmos-sdk/x/ibc/07-tendermint/types"
+)
+
+// signerAttempts is how many times a transaction is signed and broadcast again after its account
+// sequence turned out to be out of date
+const signerAttempts = 3
+
+// signer signs and broadcasts the transactions of one key of a chain one at a time. It tracks the
+// sequence of the account of the key locally instead of querying it for every transaction, and
+// syncs it with the chain again once a transaction is rejected for its sequence.
+type signer struct {
+	sync.Mutex
+	key    string
+	accNum uint64
+	seq    uint64
+	synced bool
+}
+
+// signers holds the signer of every key a chain signs with, shared by the copies of the chain so
+// that all the paths relaying to the chain use the same sequences
+type signers struct {
+	sync.Mutex
+	byKey map[string]*signer
+	next  int
+}
+
+func newSigners() *signers {
+	return &signers{byKey: make(map[string]*signer)}
+}
+
+// get returns the signer of the key
+func (s *signers) get(key string) *signer {
+	s.Lock()
+	defer s.Unlock()
+	if _, ok := s.byKey[key]; !ok {
+		s.byKey[key] = &signer{key: key}
+	}
+	return s.byKey[key]
+}
+
+// relayKeys returns the keys the chain relays packets with, its key followed by its extra-keys
+func (src *Chain) relayKeys() []string {
+	return append([]string{src.Key}, src.ExtraKeys...)
+}
+
+// nextSigner returns the signer to send the msgs with along with the msgs to send. Packet, ack,
+// timeout and client update msgs are spread over the relay keys in turn, with their signer set to
+// the chosen key. Any other msgs are sent with the key of the chain as they are.
+func (src *Chain) nextSigner(msgs []sdk.Msg) (*signer, []sdk.Msg, error) {
+	keys := src.relayKeys()
+	if len(keys) == 1 || !relayMsgs(msgs) {
+		return src.signers.get(src.Key), msgs, nil
+	}
+
+	src.signers.Lock()
+	key := keys[src.signers.next%len(keys)]
+	src.signers.next++
+	src.signers.Unlock()
+
+	info, err := src.Keybase.Get(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return src.signers.get(key), withSigner(msgs, info.GetAddress()), nil
+}
+
+// relayMsgs returns true if all the msgs may be signed by any of the relay keys
+func relayMsgs(msgs []sdk.Msg) bool {
+	for _, msg := range msgs {
+		switch msg.(type) {
+		case tmclient.MsgUpdateClient, chanTypes.MsgPacket, chanTypes.MsgAcknowledgement, chanTypes.MsgTimeout:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// withSigner returns a copy of the relay msgs with their signer set to addr
+func withSigner(msgs []sdk.Msg, addr sdk.AccAddress) []sdk.Msg {
+	out := make([]sdk.Msg, len(msgs))
+	for i, msg := range msgs {
+		switch m := msg.(type) {
+		case tmclient.MsgUpdateClient:
+			m.Signer = addr
+			out[i] = m
+		case chanTypes.MsgPacket:
+			m.Signer = addr
+			out[i] = m
+		case chanTypes.MsgAcknowledgement:
+			m.Signer = addr
+			out[i] = m
+		case chanTypes.MsgTimeout:
+			m.Signer = addr
+			out[i] = m
+		default:
+			out[i] = msg
+		}
+	}
+	return out
+}
+
+// send signs the msgs with the key of the signer and broadcasts them, once the transactions
+// signed before have been broadcast
+func (s *signer) send(c *Chain, msgs []sdk.Msg) (res sdk.TxResponse, err error) {
+	s.Lock()
+	defer s.Unlock()
+
+	for attempt := 1; ; attempt++ {
+		if !s.synced {
+			if s.accNum, s.seq, err = c.account(s.key); err != nil {
+				return res, err
+			}
+			s.synced = true
+		}
+
+		txBldr, err := c.txBuilder(s.key, s.accNum, s.seq, msgs)
+		if err != nil {
+			return res, err
+		}
+
+		out, err := txBldr.BuildAndSign(s.key, ckeys.DefaultKeyPass, msgs)
+		if err != nil {
+			return res, err
+		}
+
+		release, err := c.reserveFee(c.txFee(txBldr.Gas()))
+		if err != nil {
+			return res, err
+		}
+
+		// transactions which don't make it into a block don't pay their fee
+		if res, err = c.BroadcastTxCommit(out); err != nil || res.Height == 0 {
+			release()
+		}
+
+		switch {
+		case err != nil:
+			// the transaction may or may not have made it into the mempool
+			s.synced = false
+			return res, err
+		case res.Code == 0 || res.Height != 0:
+			// the sequence is used up once the transaction passes CheckTx, even if its msgs fail
+			s.seq++
+			return res, nil
+		case wrongSequence(res) && attempt < signerAttempts:
+			c.Log("account sequence out of date, resyncing", "key", s.key, "sequence", s.seq, "attempt", attempt)
+			s.synced = false
+		default:
+			if wrongSequence(res) {
+				s.synced = false
+			}
+			return res, nil
+		}
+	}
+}
+
+// wrongSequence returns true if the transaction was rejected by CheckTx for its account sequence,
+// which fails its signature verification
+func wrongSequence(res sdk.TxResponse) bool {
+	return res.Codespace == sdkerrors.RootCodespace &&
+		(res.Code == sdkerrors.ErrUnauthorized.ABCICode() || res.Code == sdkerrors.ErrInvalidSequence.ABCICode())
+}
+
+// account returns the account number and sequence of the account of the key
+func (src *Chain) account(keyName string) (accNum, seq uint64, err error) {
+	// Set sdk config to use custom Bech32 account prefix
+	sdkConf := sdk.GetConfig()
+	sdkConf.SetBech32PrefixForAccount(src.AccountPrefix, src.AccountPrefix+"pub")
+
+	info, err := src.Keybase.Get(keyName)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	acc, err := auth.NewAccountRetriever(src.Cdc, src).GetAccount(info.GetAddress())
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to query account of key %s on %s: %w", keyName, src.ChainID, err)
+	}
+	return acc.GetAccountNumber(), acc.GetSequence(), nil
+}
@@ -0,0 +1,269 @@
+package relayer
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	ckeys "github.com/cosmos/cosmos-sdk/client/keys"
+	aminocodec "github.com/cosmos/cosmos-sdk/codec"
+	codecstd "github.com/cosmos/cosmos-sdk/codec/std"
+	keys "github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/simapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	authexported "github.com/cosmos/cosmos-sdk/x/auth/exported"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	chanTypes "github.com/cosmos/cosmos-sdk/x/ibc/04-channel/types"
+	tmclient "github.com/cosmos/cosmos-sdk/x/ibc/07-tendermint/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/libs/log"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// stubClient is a node which holds the accounts of the keys of a chain. It checks the signature
+// of the transactions broadcast to it against the sequence of their account, like CheckTx does,
+// and commits the ones which pass in a new block.
+type stubClient struct {
+	rpcclient.Client
+	sync.Mutex
+
+	chainID  string
+	cdc      *codecstd.Codec
+	amino    *aminocodec.Codec
+	accounts map[string]*auth.BaseAccount
+
+	// broadcastErr is returned by the next broadcast instead of checking the transaction
+	broadcastErr error
+	// check is called with the account of each transaction before its signature is checked
+	check func(acc *auth.BaseAccount)
+	// deliver returns the result of the transactions which pass CheckTx, success when nil
+	deliver func(tx auth.StdTx) abci.ResponseDeliverTx
+	// txs are the transactions broadcast, including the rejected ones
+	txs []auth.StdTx
+	// height is the height of the latest block
+	height int64
+}
+
+// newStubChain returns a chain relaying with the given keys whose node is a stubClient
+func newStubChain(t *testing.T, keyNames ...string) (*Chain, *stubClient) {
+	amino := codecstd.MakeCodec(simapp.ModuleBasics)
+	cdc := codecstd.NewAppCodec(amino)
+	client := &stubClient{chainID: "stub", cdc: cdc, amino: amino, accounts: make(map[string]*auth.BaseAccount)}
+
+	keybase := keys.NewInMemory()
+	for i, name := range keyNames {
+		info, _, err := keybase.CreateMnemonic(name, keys.English, ckeys.DefaultKeyPass, keys.Secp256k1)
+		require.NoError(t, err)
+		client.accounts[info.GetAddress().String()] = auth.NewBaseAccount(info.GetAddress(), nil, uint64(i), 0)
+	}
+
+	return &Chain{
+		Key:           keyNames[0],
+		ExtraKeys:     keyNames[1:],
+		ChainID:       client.chainID,
+		AccountPrefix: sdk.Bech32MainPrefix,
+		Gas:           200000,
+		GasPrices:     "0.01stake",
+		Keybase:       keybase,
+		Client:        client,
+		Cdc:           cdc,
+		Amino:         amino,
+		logger:        log.NewNopLogger(),
+		fees:          &feeTracker{},
+		signers:       newSigners(),
+	}, client
+}
+
+func (c *stubClient) account(addr sdk.AccAddress) *auth.BaseAccount {
+	c.Lock()
+	defer c.Unlock()
+	return c.accounts[addr.String()]
+}
+
+// ABCIQueryWithOptions answers the account queries of the signers
+func (c *stubClient) ABCIQueryWithOptions(path string, data tmbytes.HexBytes, opts rpcclient.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
+	var params auth.QueryAccountParams
+	if err := c.cdc.UnmarshalJSON(data, &params); err != nil {
+		return nil, err
+	}
+	acc := c.account(params.Address)
+	if acc == nil {
+		return &ctypes.ResultABCIQuery{Response: abci.ResponseQuery{Code: 1, Log: "unknown account"}}, nil
+	}
+	bz, err := c.cdc.MarshalJSON(authexported.Account(acc))
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultABCIQuery{Response: abci.ResponseQuery{Value: bz, Height: c.height}}, nil
+}
+
+// BroadcastTxCommit checks the signature of the transaction with the sequence of its account and
+// commits it when it passes
+func (c *stubClient) BroadcastTxCommit(bz tmtypes.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	if err := c.broadcastErr; err != nil {
+		c.broadcastErr = nil
+		return nil, err
+	}
+
+	decoded, err := auth.DefaultTxDecoder(c.amino)(bz)
+	if err != nil {
+		return nil, err
+	}
+	tx := decoded.(auth.StdTx)
+	c.txs = append(c.txs, tx)
+
+	acc := c.accounts[tx.GetSigners()[0].String()]
+	if acc == nil {
+		return nil, errors.New("unknown account")
+	}
+	if c.check != nil {
+		c.check(acc)
+	}
+	signBytes := auth.StdSignBytes(c.chainID, acc.AccountNumber, acc.Sequence, tx.Fee, tx.Msgs, tx.Memo)
+	if !tx.GetPubKeys()[0].VerifyBytes(signBytes, tx.Signatures[0].Signature) {
+		return &ctypes.ResultBroadcastTxCommit{CheckTx: abci.ResponseCheckTx{
+			Codespace: sdkerrors.RootCodespace, Code: sdkerrors.ErrUnauthorized.ABCICode(),
+		}}, nil
+	}
+
+	acc.Sequence++
+	c.height++
+	res := &ctypes.ResultBroadcastTxCommit{Hash: bz.Hash(), Height: c.height}
+	if c.deliver != nil {
+		res.DeliverTx = c.deliver(tx)
+	}
+	return res, nil
+}
+
+// broadcasts returns the number of transactions broadcast to the node
+func (c *stubClient) broadcasts() int {
+	c.Lock()
+	defer c.Unlock()
+	return len(c.txs)
+}
+
+func (src *Chain) mustKeyAddress(t *testing.T, key string) sdk.AccAddress {
+	info, err := src.Keybase.Get(key)
+	require.NoError(t, err)
+	return info.GetAddress()
+}
+
+func newTestSend(from sdk.AccAddress) sdk.Msg {
+	return bank.NewMsgSend(from, from, sdk.NewCoins(sdk.NewInt64Coin("stake", 1)))
+}
+
+func TestSignerTracksSequence(t *testing.T) {
+	chain, client := newStubChain(t, "relayer")
+	addr := chain.mustKeyAddress(t, "relayer")
+	s := chain.signers.get("relayer")
+
+	for i := 0; i < 3; i++ {
+		res, err := chain.SendMsgs([]sdk.Msg{newTestSend(addr)})
+		require.NoError(t, err)
+		require.Equal(t, uint32(0), res.Code)
+	}
+	// the sequence is only queried before the first transaction
+	require.Equal(t, uint64(3), s.seq)
+	require.Equal(t, uint64(3), client.account(addr).Sequence)
+	require.Equal(t, 3, client.broadcasts())
+}
+
+func TestSignerResyncsSequence(t *testing.T) {
+	chain, client := newStubChain(t, "relayer")
+	addr := chain.mustKeyAddress(t, "relayer")
+	s := chain.signers.get("relayer")
+
+	_, err := chain.SendMsgs([]sdk.Msg{newTestSend(addr)})
+	require.NoError(t, err)
+
+	// another process sends transactions with the key
+	client.account(addr).Sequence = 5
+
+	res, err := chain.SendMsgs([]sdk.Msg{newTestSend(addr)})
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), res.Code)
+	require.Equal(t, uint64(6), s.seq)
+	// the first attempt was rejected for its sequence and signed again after syncing
+	require.Equal(t, 3, client.broadcasts())
+
+	// a transaction which may have reached the mempool makes the signer sync again
+	client.broadcastErr = errors.New("connection reset")
+	_, err = chain.SendMsgs([]sdk.Msg{newTestSend(addr)})
+	require.Error(t, err)
+	require.False(t, s.synced)
+	client.account(addr).Sequence = 7
+
+	_, err = chain.SendMsgs([]sdk.Msg{newTestSend(addr)})
+	require.NoError(t, err)
+	require.Equal(t, uint64(8), s.seq)
+	require.Equal(t, 4, client.broadcasts())
+}
+
+func TestSignerGivesUpOnWrongSequence(t *testing.T) {
+	chain, client := newStubChain(t, "relayer")
+	addr := chain.mustKeyAddress(t, "relayer")
+	// another process always sends a transaction with the key first
+	client.check = func(acc *auth.BaseAccount) { acc.Sequence++ }
+
+	res, err := chain.SendMsgs([]sdk.Msg{newTestSend(addr)})
+	require.NoError(t, err)
+	require.True(t, wrongSequence(res))
+	require.Equal(t, signerAttempts, client.broadcasts())
+	require.False(t, chain.signers.get("relayer").synced)
+}
+
+func TestNextSignerRotatesRelayKeys(t *testing.T) {
+	chain, _ := newStubChain(t, "relayer", "extra-1", "extra-2")
+	update := tmclient.MsgUpdateClient{ClientID: "client"}
+
+	for _, key := range []string{"relayer", "extra-1", "extra-2", "relayer"} {
+		s, msgs, err := chain.nextSigner([]sdk.Msg{update})
+		require.NoError(t, err)
+		require.Equal(t, key, s.key)
+		require.Equal(t, chain.mustKeyAddress(t, key), msgs[0].(tmclient.MsgUpdateClient).Signer)
+	}
+	require.Same(t, chain.signers.get("extra-1"), chain.signers.get("extra-1"))
+
+	// msgs which must be signed by the key of the chain don't take a turn
+	send := newTestSend(chain.mustKeyAddress(t, "relayer"))
+	s, msgs, err := chain.nextSigner([]sdk.Msg{update, send})
+	require.NoError(t, err)
+	require.Equal(t, "relayer", s.key)
+	require.Equal(t, []sdk.Msg{update, send}, msgs)
+
+	s, _, err = chain.nextSigner([]sdk.Msg{update})
+	require.NoError(t, err)
+	require.Equal(t, "extra-1", s.key)
+}
+
+func TestWithSigner(t *testing.T) {
+	chain, _ := newStubChain(t, "relayer", "extra-1")
+	relayer, extra := chain.mustKeyAddress(t, "relayer"), chain.mustKeyAddress(t, "extra-1")
+
+	msgs := []sdk.Msg{
+		tmclient.MsgUpdateClient{ClientID: "client", Signer: relayer},
+		chanTypes.MsgPacket{Signer: relayer},
+		chanTypes.MsgAcknowledgement{Signer: relayer},
+		chanTypes.MsgTimeout{Signer: relayer},
+		newTestSend(relayer),
+	}
+	out := withSigner(msgs, extra)
+
+	require.Equal(t, extra, out[0].(tmclient.MsgUpdateClient).Signer)
+	require.Equal(t, "client", out[0].(tmclient.MsgUpdateClient).ClientID)
+	require.Equal(t, extra, out[1].(chanTypes.MsgPacket).Signer)
+	require.Equal(t, extra, out[2].(chanTypes.MsgAcknowledgement).Signer)
+	require.Equal(t, extra, out[3].(chanTypes.MsgTimeout).Signer)
+	require.Equal(t, msgs[4], out[4])
+	// the msgs given are left as they are
+	require.Equal(t, relayer, msgs[0].(tmclient.MsgUpdateClient).Signer)
+}
@@ -4,16 +4,26 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	retry "github.com/avast/retry-go"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	chanTypes "github.com/cosmos/cosmos-sdk/x/ibc/04-channel/types"
 	tmclient "github.com/cosmos/cosmos-sdk/x/ibc/07-tendermint/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 )
 
 var (
 	txEvents = "tm.event = 'Tx'"
 	blEvents = "tm.event = 'NewBlock'"
+
+	// acknowledgements are read from write_acknowledgement events when the chain emits them,
+	// and otherwise from recv_packet events which carry the acknowledgement as their data
+	ackEvents = []struct{ event, ackKey string }{
+		{"write_acknowledgement", "packet_ack"},
+		{"recv_packet", "packet_data"},
+	}
 )
 
 // MustGetStrategy returns the strategy and panics on error
@@ -30,6 +40,8 @@ func (r *Path) GetStrategy() (Strategy, error) {
 	switch r.Strategy.Type {
 	case NaiveStrategy{}.GetType():
 		return NaiveStrategy{}.Init(r.Strategy)
+	case BatchStrategy{}.GetType():
+		return BatchStrategy{}.Init(r.Strategy)
 	default:
 		return nil, fmt.Errorf("invalid strategy: %s", r.Strategy.Type)
 	}
@@ -56,10 +68,9 @@ type Strategy interface {
 	// Used in constructing StrategyCfg
 	GetConstraints() map[string]string
 
-	// Run starts the relayer
-	// it returns a recieve only channel that is used to wait for
-	// the strategy to be ready to relay, and a done channel
-	// that shuts down the relayer when it is time to exit
+	// Run starts the relayer in the background
+	// it returns a function that shuts down the relayer when
+	// it is time to exit and waits for it to stop
 	Run(*Chain, *Chain) (func(), error)
 }
 
@@ -104,8 +115,6 @@ func (nrs NaiveStrategy) GetConstraints() map[string]string {
 
 // Run implements Strategy and defines what actions are taken when the relayer runs
 func (nrs NaiveStrategy) Run(src, dst *Chain) (func(), error) {
-	doneChan := make(chan struct{})
-
 	// first, we want to ensure that there are no packets remaining to be relayed
 	if err := RelayUnRelayedPacketsOrderedChan(src, dst); err != nil {
 		// TODO: some errors may leak here when there are no packets to be relayed
@@ -113,88 +122,198 @@ func (nrs NaiveStrategy) Run(src, dst *Chain) (func(), error) {
 		return nil, err
 	}
 
-	go nrsLoop(src, dst, doneChan)
+	queue := src.RelayQueue()
+
+	// timeout relaying runs at most once at a time in each direction, and so does the worker
+	// retrying the queued jobs
+	var srcTimeouts, dstTimeouts, retrying int32
+
+	return startRelayLoop(src, dst, relayHandlers{
+		srcTx: func(events map[string][]string) {
+			go dst.handlePacket(src, queue, events)
+			go dst.handleAck(src, queue, events)
+		},
+		dstTx: func(events map[string][]string) {
+			go src.handlePacket(dst, queue, events)
+			go src.handleAck(dst, queue, events)
+		},
+		srcBlock: func(events map[string][]string) {
+			go dst.handlePacket(src, queue, events)
+			go relayTimeouts(dst, src, &dstTimeouts, nil)
+			go relayQueued(src, dst, queue, &retrying)
+		},
+		dstBlock: func(events map[string][]string) {
+			go src.handlePacket(dst, queue, events)
+			go relayTimeouts(src, dst, &srcTimeouts, nil)
+			go relayQueued(src, dst, queue, &retrying)
+		},
+	}), nil
+}
 
-	return func() { doneChan <- struct{}{} }, nil
+// relayHandlers are the functions a relay loop calls with the events of each chain
+type relayHandlers struct {
+	srcTx, dstTx, srcBlock, dstBlock func(events map[string][]string)
 }
 
-func nrsLoop(src, dst *Chain, doneChan chan struct{}) {
-	// Subscribe to source chain
-	if err := src.Start(); err != nil {
-		src.Error(err)
-		return
-	}
+// startRelayLoop runs the relay loop of the path in the background and returns the function
+// which stops it and waits for it to exit
+func startRelayLoop(src, dst *Chain, h relayHandlers) func() {
+	doneChan := make(chan struct{})
+	stopped := make(chan struct{})
 
-	srcTxEvents, srcTxCancel, err := src.Subscribe(txEvents)
-	if err != nil {
-		src.Error(err)
-		return
+	go func() {
+		defer close(stopped)
+		relayLoop(src, dst, doneChan, h)
+	}()
+
+	return func() {
+		close(doneChan)
+		<-stopped
 	}
-	defer srcTxCancel()
-	src.Log(fmt.Sprintf("- listening to tx events from %s...", src.ChainID))
+}
 
-	srcBlockEvents, srcBlockCancel, err := src.Subscribe(blEvents)
-	if err != nil {
+// relayLoop passes the events of both chains to the handlers until done is closed, subscribing
+// again with backoff whenever the subscriptions fail
+func relayLoop(src, dst *Chain, doneChan <-chan struct{}, h relayHandlers) {
+	var attempts uint64
+	for {
+		start := time.Now()
+		err := relayEvents(src, dst, doneChan, h)
+		if err == nil {
+			src.Log("relayer shutting down", "dst_chain_id", dst.ChainID, "dst_port", dst.PathEnd.PortID)
+			return
+		}
 		src.Error(err)
-		return
-	}
-	defer srcBlockCancel()
-	src.Log(fmt.Sprintf("- listening to block events from %s...", src.ChainID))
 
-	// Subscribe to destination chain
-	if err := dst.Start(); err != nil {
-		dst.Error(err)
-		return
+		// a loop which ran for a while starts over with the shortest backoff
+		if time.Since(start) > queueMaxBackoff {
+			attempts = 0
+		}
+		attempts++
+
+		select {
+		case <-doneChan:
+			return
+		case <-time.After(backoff(attempts)):
+		}
 	}
+}
 
-	dstTxEvents, dstTxCancel, err := dst.Subscribe(txEvents)
+// relayEvents subscribes to the events of both chains and passes them to the handlers. It returns
+// nil once done is closed and an error if a subscription fails.
+func relayEvents(src, dst *Chain, doneChan <-chan struct{}, h relayHandlers) error {
+	srcTxEvents, srcBlockEvents, srcCancel, err := src.subscribeRelayEvents()
 	if err != nil {
-		dst.Error(err)
-		return
+		return err
 	}
-	defer dstTxCancel()
-	dst.Log(fmt.Sprintf("- listening to tx events from %s...", dst.ChainID))
+	defer srcCancel()
 
-	dstBlockEvents, dstBlockCancel, err := dst.Subscribe(blEvents)
+	dstTxEvents, dstBlockEvents, dstCancel, err := dst.subscribeRelayEvents()
 	if err != nil {
-		src.Error(err)
-		return
+		return err
 	}
-	defer dstBlockCancel()
-	dst.Log(fmt.Sprintf("- listening to block events from %s...", dst.ChainID))
+	defer dstCancel()
 
 	// Listen to channels and take appropriate action
 	for {
 		select {
-		case srcMsg := <-srcTxEvents:
+		case srcMsg, ok := <-srcTxEvents:
+			if !ok {
+				return errSubscriptionClosed(src)
+			}
 			src.logTx(srcMsg.Events)
-			go dst.handlePacket(src, srcMsg.Events)
-		case dstMsg := <-dstTxEvents:
+			h.srcTx(srcMsg.Events)
+		case dstMsg, ok := <-dstTxEvents:
+			if !ok {
+				return errSubscriptionClosed(dst)
+			}
 			dst.logTx(dstMsg.Events)
-			go src.handlePacket(dst, dstMsg.Events)
-		case srcMsg := <-srcBlockEvents:
-			go dst.handlePacket(src, srcMsg.Events)
-		case dstMsg := <-dstBlockEvents:
-			go src.handlePacket(dst, dstMsg.Events)
+			h.dstTx(dstMsg.Events)
+		case srcMsg, ok := <-srcBlockEvents:
+			if !ok {
+				return errSubscriptionClosed(src)
+			}
+			h.srcBlock(srcMsg.Events)
+		case dstMsg, ok := <-dstBlockEvents:
+			if !ok {
+				return errSubscriptionClosed(dst)
+			}
+			h.dstBlock(dstMsg.Events)
 		case <-doneChan:
-			src.Log(fmt.Sprintf("- [%s]:{%s} <-> [%s]:{%s} relayer shutting down",
-				src.ChainID, src.PathEnd.PortID, dst.ChainID, dst.PathEnd.PortID))
-			close(doneChan)
+			return nil
+		}
+	}
+}
+
+func errSubscriptionClosed(c *Chain) error {
+	return fmt.Errorf("- [%s] - event subscription closed", c.ChainID)
+}
+
+// subscribeRelayEvents subscribes to the tx and block events of the chain
+func (src *Chain) subscribeRelayEvents() (txEvts, blockEvts <-chan ctypes.ResultEvent, cancel func(), err error) {
+	txEvts, txCancel, err := src.SubscribeShared(txEvents)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	src.Log("listening to tx events")
+
+	blockEvts, blockCancel, err := src.SubscribeShared(blEvents)
+	if err != nil {
+		txCancel()
+		return nil, nil, nil, err
+	}
+	src.Log("listening to block events")
+
+	return txEvts, blockEvts, func() {
+		txCancel()
+		blockCancel()
+	}, nil
+}
+
+// SupervisePath runs the strategy of a path until stop is closed. The strategy is started again
+// with backoff as long as it fails to start, independently of the other paths of the process.
+// The path counts as ready on /readyz while its strategy is running.
+func SupervisePath(name string, strategy Strategy, src, dst *Chain, stop <-chan struct{}) {
+	setPathRunning(name, false)
+	for attempts := uint64(1); ; attempts++ {
+		done, err := strategy.Run(src, dst)
+		if err == nil {
+			setPathRunning(name, true)
+			<-stop
+			setPathRunning(name, false)
+			done()
 			return
 		}
+		src.Error(fmt.Errorf("failed to start path %s: %w", name, err))
+
+		select {
+		case <-stop:
+			return
+		case <-time.After(backoff(attempts)):
+		}
 	}
 }
 
-func (src *Chain) handlePacket(dst *Chain, events map[string][]string) {
-	byt, seq, timeout, err := src.packetDataAndTimeoutFromEvent(dst, events)
-	if byt != nil && seq != 0 && err == nil {
-		src.sendPacketFromEvent(dst, byt, seq, timeout)
-	} else if err != nil {
+// handlePacket relays to src the packets dst sent on the channel of the path
+func (src *Chain) handlePacket(dst *Chain, queue *RelayQueue, events map[string][]string) {
+	packets, err := packetsFromEvents(events, "send_packet", "packet_data")
+	if err != nil {
 		src.Error(err)
+		return
+	}
+
+	for _, packet := range packets {
+		// skip packets of other channels
+		if packet.SourcePort != dst.PathEnd.PortID || packet.SourceChannel != dst.PathEnd.ChannelID {
+			continue
+		}
+		job := NewRelayJob(src.PathEnd, RelayJobRecv, packet.Sequence)
+		job.Data, job.Timeout = string(packet.Data), packet.TimeoutHeight
+		src.pushAndRelay(dst, queue, job)
 	}
 }
 
-func (src *Chain) sendPacketFromEvent(dst *Chain, xferPacket []byte, seq int64, timeout uint64) {
+func (src *Chain) sendPacketFromEvent(dst *Chain, xferPacket []byte, seq int64, timeout uint64) error {
 	var (
 		err          error
 		dstH         *tmclient.Header
@@ -214,8 +333,7 @@ func (src *Chain) sendPacketFromEvent(dst *Chain, xferPacket []byte, seq int64,
 		}
 		return nil
 	}); err != nil {
-		dst.Error(err)
-		return
+		return err
 	}
 
 	txs := &RelayMsgs{
@@ -244,36 +362,219 @@ func (src *Chain) sendPacketFromEvent(dst *Chain, xferPacket []byte, seq int64,
 		},
 		Dst: []sdk.Msg{},
 	}
-	txs.Send(src, dst)
+	return txs.sendErr(src, dst)
 }
 
-func (src *Chain) packetDataAndTimeoutFromEvent(dst *Chain, events map[string][]string) (packetData []byte, seq int64, timeout uint64, err error) {
-	// Set sdk config to use custom Bech32 account prefix
-	sdkConf := sdk.GetConfig()
-	sdkConf.SetBech32PrefixForAccount(dst.AccountPrefix, dst.AccountPrefix+"pub")
+// handleAck relays to src the acknowledgements written by dst for the packets src sent
+func (src *Chain) handleAck(dst *Chain, queue *RelayQueue, events map[string][]string) {
+	acks, err := acksFromEvents(events)
+	if err != nil {
+		src.Error(err)
+		return
+	}
 
-	// then, get packet data and parse
-	if pdval, ok := events["send_packet.packet_data"]; ok {
-		packetData = []byte(pdval[0])
+	for _, ack := range acks {
+		// skip packets of other channels and packets without acknowledgement
+		if ack.DestinationPort != dst.PathEnd.PortID || ack.DestinationChannel != dst.PathEnd.ChannelID || len(ack.Data) == 0 {
+			continue
+		}
+		job := NewRelayJob(src.PathEnd, RelayJobAck, ack.Sequence)
+		job.Ack = string(ack.Data)
+		src.pushAndRelay(dst, queue, job)
 	}
+}
+
+func (src *Chain) sendAckFromEvent(dst *Chain, ack []byte, seq int64) error {
+	var (
+		err       error
+		dstH      *tmclient.Header
+		dstAckRes CommitmentResponse
+	)
 
-	// next, get and parse the sequence
-	if sval, ok := events["send_packet.packet_sequence"]; ok {
-		seq, err = strconv.ParseInt(sval[0], 10, 64)
+	// the acknowledgement has to be returned with the packet which src sent
+	packetData, timeout, err := src.queryPacketDataAndTimeout(0, uint64(seq))
+	if err != nil {
+		return err
+	}
+
+	if err = retry.Do(func() error {
+		dstH, err = dst.UpdateLiteWithHeader()
 		if err != nil {
-			return nil, 0, 0, err
+			return err
+		}
+		dstAckRes, err = dst.QueryPacketAck(dstH.Height-1, seq)
+		if err != nil {
+			return err
+		} else if dstAckRes.Data == nil || dstAckRes.Proof.Proof == nil {
+			return fmt.Errorf("- [%s]@{%d} - Packet Acknowledgement Proof is nil seq(%d)", dst.ChainID, dstH.Height-1, seq)
 		}
+		return nil
+	}); err != nil {
+		return err
 	}
 
-	// finally, get and parse the timeout
-	if sval, ok := events["send_packet.packet_timeout"]; ok {
-		timeout, err = strconv.ParseUint(sval[0], 10, 64)
+	txs := &RelayMsgs{
+		Src: []sdk.Msg{
+			src.PathEnd.UpdateClient(dstH, src.MustGetAddress()),
+			src.AckMsg(dst, packetData, ack, timeout, seq, dstAckRes),
+		},
+		Dst: []sdk.Msg{},
+	}
+	return txs.sendErr(src, dst)
+}
+
+// pushAndRelay persists the job in the queue before relaying it, so that it is retried if it fails
+func (src *Chain) pushAndRelay(dst *Chain, queue *RelayQueue, job RelayJob) {
+	if err := queue.Push(job); err != nil {
+		src.Error(err, "sequence", job.Sequence)
+	}
+	src.relayJob(dst, queue, job)
+}
+
+// relayJob relays the job to src, removing it from the queue on success and recording the failure
+// otherwise
+func (src *Chain) relayJob(dst *Chain, queue *RelayQueue, job RelayJob) {
+	var err error
+	switch job.Type {
+	case RelayJobRecv:
+		err = src.sendPacketFromEvent(dst, []byte(job.Data), int64(job.Sequence), job.Timeout)
+	case RelayJobAck:
+		err = src.sendAckFromEvent(dst, []byte(job.Ack), int64(job.Sequence))
+	default:
+		err = fmt.Errorf("invalid relay job type %s", job.Type)
+	}
+
+	if err != nil {
+		src.Error(err, "type", job.Type, "sequence", job.Sequence)
+		err = queue.Failed(job, err)
+	} else {
+		err = queue.Remove(job)
+	}
+	if err != nil {
+		src.Error(err, "sequence", job.Sequence)
+	}
+}
+
+// jobDone returns whether the job no longer needs to be relayed to src, either because it was
+// relayed or because the packet timed out
+func (src *Chain) jobDone(dst *Chain, job RelayJob) (bool, error) {
+	switch job.Type {
+	case RelayJobRecv:
+		height, err := src.QueryLatestHeight()
 		if err != nil {
-			return nil, 0, 0, err
+			return false, err
+		} else if uint64(height) >= job.Timeout {
+			return true, nil
 		}
+		recvRes, err := src.QueryNextSeqRecv(height)
+		if err != nil {
+			return false, err
+		} else if recvRes.NextSequenceRecv > job.Sequence {
+			return true, nil
+		}
+		// unordered channels write an acknowledgement for every packet they receive
+		ackRes, err := src.QueryPacketAck(height, int64(job.Sequence))
+		return ackRes.Data != nil, err
+	case RelayJobAck:
+		// the commitment of a packet is deleted once its acknowledgement is processed
+		commitRes, err := src.QueryPacketCommitment(0, int64(job.Sequence))
+		return commitRes.Data == nil, err
+	default:
+		return false, fmt.Errorf("invalid relay job type %s", job.Type)
 	}
+}
 
-	return
+// relayQueued retries the due jobs of the queue for both ends of the path unless a previous run
+// has not finished yet
+func relayQueued(src, dst *Chain, queue *RelayQueue, running *int32) {
+	if !atomic.CompareAndSwapInt32(running, 0, 1) {
+		return
+	}
+	defer atomic.StoreInt32(running, 0)
+
+	for _, c := range []struct{ to, from *Chain }{{src, dst}, {dst, src}} {
+		jobs, err := queue.DueJobs(c.to.PathEnd, time.Now())
+		if err != nil {
+			c.to.Error(err)
+			return
+		}
+
+		for _, job := range jobs {
+			done, err := c.to.jobDone(c.from, job)
+			if err != nil {
+				c.to.Error(err, "sequence", job.Sequence)
+				continue
+			} else if done {
+				if err = queue.Remove(job); err != nil {
+					c.to.Error(err, "sequence", job.Sequence)
+				}
+				continue
+			}
+			c.to.Log("retrying queued job", "type", job.Type, "sequence", job.Sequence, "attempt", job.Attempts+1)
+			c.to.relayJob(c.from, queue, job)
+		}
+	}
+}
+
+// relayTimeouts times out the packets sent by src which pass the filter unless a previous run for
+// the same direction has not finished yet
+func relayTimeouts(src, dst *Chain, running *int32, filter PacketFilter) {
+	if !atomic.CompareAndSwapInt32(running, 0, 1) {
+		return
+	}
+	defer atomic.StoreInt32(running, 0)
+
+	if err := relayPacketTimeouts(src, dst, filter); err != nil {
+		src.Error(err)
+	}
+}
+
+// acksFromEvents returns the packets acknowledged in the events, with their acknowledgement in
+// place of their data
+func acksFromEvents(events map[string][]string) ([]chanTypes.Packet, error) {
+	for _, e := range ackEvents {
+		if _, ok := events[e.event+".packet_sequence"]; ok {
+			return packetsFromEvents(events, e.event, e.ackKey)
+		}
+	}
+	return nil, nil
+}
+
+// packetsFromEvents returns the packets described by the events of the given type, with the value
+// of the dataKey attribute as their data
+func packetsFromEvents(events map[string][]string, event, dataKey string) ([]chanTypes.Packet, error) {
+	seqs := events[event+".packet_sequence"]
+	attr := func(key string) ([]string, error) {
+		vals := events[event+"."+key]
+		if len(vals) != len(seqs) {
+			return nil, fmt.Errorf("malformed %s event: %d %s for %d sequences", event, len(vals), key, len(seqs))
+		}
+		return vals, nil
+	}
+
+	keys := []string{dataKey, "packet_timeout", "packet_src_port", "packet_src_channel", "packet_dst_port", "packet_dst_channel"}
+	vals := make([][]string, len(keys))
+	for i, key := range keys {
+		v, err := attr(key)
+		if err != nil {
+			return nil, err
+		}
+		vals[i] = v
+	}
+
+	packets := make([]chanTypes.Packet, 0, len(seqs))
+	for i, sval := range seqs {
+		seq, err := strconv.ParseUint(sval, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		timeout, err := strconv.ParseUint(vals[1][i], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		packets = append(packets, chanTypes.NewPacket([]byte(vals[0][i]), seq, vals[2][i], vals[3][i], vals[4][i], vals[5][i], timeout))
+	}
+	return packets, nil
 }
 
 func getEventHeight(events map[string][]string) int64 {
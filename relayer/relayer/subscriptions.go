@@ -0,0 +1,127 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+// eventHubs are the event subscriptions open in the process, which the paths relaying to the same
+// chain share
+var eventHubs = struct {
+	sync.Mutex
+	hubs map[string]*eventHub
+}{hubs: make(map[string]*eventHub)}
+
+// eventHub fans out the events of a subscription to its listeners
+type eventHub struct {
+	sync.Mutex
+	listeners map[*eventListener]struct{}
+	cancel    func()
+}
+
+type eventListener struct {
+	events chan ctypes.ResultEvent
+	done   chan struct{}
+}
+
+// SubscribeShared returns a channel of events given a query. The chain's client is started if
+// needed, and the subscription is shared with the other subscribers to the same query on the
+// chain until they have all called cancel. The channel is closed if the subscription ends.
+func (src *Chain) SubscribeShared(query string) (<-chan ctypes.ResultEvent, func(), error) {
+	eventHubs.Lock()
+	defer eventHubs.Unlock()
+
+	key := fmt.Sprintf("%s/%s", src.ChainID, query)
+	hub, ok := eventHubs.hubs[key]
+	if !ok {
+		var err error
+		if hub, err = src.newEventHub(key, query); err != nil {
+			return nil, nil, err
+		}
+		eventHubs.hubs[key] = hub
+	}
+
+	l := &eventListener{events: make(chan ctypes.ResultEvent, 100), done: make(chan struct{})}
+	hub.Lock()
+	hub.listeners[l] = struct{}{}
+	hub.Unlock()
+
+	return l.events, func() {
+		eventHubs.Lock()
+		defer eventHubs.Unlock()
+
+		close(l.done)
+		hub.Lock()
+		delete(hub.listeners, l)
+		last := len(hub.listeners) == 0
+		hub.Unlock()
+
+		if last && eventHubs.hubs[key] == hub {
+			delete(eventHubs.hubs, key)
+			hub.cancel()
+		}
+	}, nil
+}
+
+func (src *Chain) newEventHub(key, query string) (*eventHub, error) {
+	if !src.Client.IsRunning() {
+		if err := src.Start(); err != nil {
+			return nil, err
+		}
+	}
+
+	suffix, err := GenerateRandomString(8)
+	if err != nil {
+		return nil, err
+	}
+	subscriber := fmt.Sprintf("%s-subscriber-%s", src.ChainID, suffix)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	events, err := src.Client.Subscribe(ctx, subscriber, query)
+	if err != nil {
+		return nil, err
+	}
+
+	hub := &eventHub{listeners: make(map[*eventListener]struct{})}
+	hub.cancel = func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := src.Client.Unsubscribe(ctx, subscriber, query); err != nil {
+			src.Error(err)
+		}
+	}
+
+	go func() {
+		for ev := range events {
+			hub.Lock()
+			for l := range hub.listeners {
+				select {
+				case l.events <- ev:
+				case <-l.done:
+				}
+			}
+			hub.Unlock()
+		}
+
+		// the subscription ended, so later subscribers need a new one
+		eventHubs.Lock()
+		if eventHubs.hubs[key] == hub {
+			delete(eventHubs.hubs, key)
+		}
+		eventHubs.Unlock()
+
+		hub.Lock()
+		for l := range hub.listeners {
+			close(l.events)
+		}
+		hub.listeners = nil
+		hub.Unlock()
+	}()
+
+	return hub, nil
+}
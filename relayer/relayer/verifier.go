@@ -179,27 +179,92 @@ func (c *Chain) TrustNodeInitClient(db *dbm.GoLevelDB) (*lite.Client, error) {
 	return lc, nil
 }
 
+// liteDBs are the lite client databases open in the process, which the paths relaying to the
+// same chain share
+var liteDBs = struct {
+	sync.Mutex
+	dbs map[string]*liteDB
+}{dbs: make(map[string]*liteDB)}
+
+// liteDB is a lite client database along with the number of its users. Its lock is held while
+// the database is in use.
+type liteDB struct {
+	sync.Mutex
+	db   *dbm.GoLevelDB
+	refs int
+}
+
 // NewLiteDB returns a new instance of the liteclient database connection
 // CONTRACT: must close the database connection when done with it (defer df())
+// NOTE: the use of the database of a chain is serialized within the process
 func (c *Chain) NewLiteDB() (db *dbm.GoLevelDB, df func(), err error) {
-	if err := retry.Do(func() error {
-		db, err = dbm.NewGoLevelDB(c.ChainID, liteDir(c.HomePath))
-		if err != nil {
-			return fmt.Errorf("can't open lite client database: %w", err)
-		}
-		return nil
-	}); err != nil {
+	l, err := c.acquireLiteDB()
+	if err != nil {
 		return nil, nil, err
 	}
+	l.Lock()
 
 	df = func() {
-		err := db.Close()
-		if err != nil {
-			panic(err)
+		l.Unlock()
+		c.releaseLiteDB(l)
+	}
+
+	return l.db, df, nil
+}
+
+// HoldLiteDB keeps the lite client database of the chain open until release is called, so that
+// it is not opened again for every operation
+func (c *Chain) HoldLiteDB() (release func(), err error) {
+	l, err := c.acquireLiteDB()
+	if err != nil {
+		return nil, err
+	}
+	return func() { c.releaseLiteDB(l) }, nil
+}
+
+func (c *Chain) liteDBKey() string {
+	return filepath.Join(liteDir(c.HomePath), c.ChainID)
+}
+
+// acquireLiteDB opens the lite client database of the chain unless it is already open
+func (c *Chain) acquireLiteDB() (*liteDB, error) {
+	liteDBs.Lock()
+	defer liteDBs.Unlock()
+
+	l, ok := liteDBs.dbs[c.liteDBKey()]
+	if !ok {
+		var (
+			db  *dbm.GoLevelDB
+			err error
+		)
+		if err := retry.Do(func() error {
+			db, err = dbm.NewGoLevelDB(c.ChainID, liteDir(c.HomePath))
+			if err != nil {
+				return fmt.Errorf("can't open lite client database: %w", err)
+			}
+			return nil
+		}); err != nil {
+			return nil, err
 		}
+		l = &liteDB{db: db}
+		liteDBs.dbs[c.liteDBKey()] = l
 	}
+	l.refs++
+	return l, nil
+}
+
+// releaseLiteDB closes the lite client database of the chain once it has no more users
+func (c *Chain) releaseLiteDB(l *liteDB) {
+	liteDBs.Lock()
+	defer liteDBs.Unlock()
 
-	return
+	if l.refs--; l.refs > 0 {
+		return
+	}
+	delete(liteDBs.dbs, c.liteDBKey())
+	if err := l.db.Close(); err != nil {
+		panic(err)
+	}
 }
 
 // DeleteLiteDB removes the lite client database on disk, forcing re-initialization
@@ -291,10 +356,8 @@ func (c *Chain) ForceInitLite() error {
 	if err != nil {
 		return err
 	}
+	defer df()
+
 	_, err = c.TrustNodeInitClient(db)
-	if err != nil {
-		return err
-	}
-	df()
-	return nil
+	return err
 }
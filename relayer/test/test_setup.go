@@ -113,14 +113,14 @@ func spinUpTestContainer(t *testing.T, rchan chan<- *dockertest.Resource, pool *
 	resource, err = pool.RunWithOptions(dockerOpts)
 	require.NoError(t, err)
 
-	c.Log(fmt.Sprintf("- [%s] SPUN UP IN CONTAINER %s from %s", c.ChainID, resource.Container.Name, resource.Container.Config.Image))
+	c.Log("spun up container", "container", resource.Container.Name, "image", resource.Container.Config.Image)
 
 	// retry polling the container until status doesn't error
 	if err = pool.Retry(c.StatusErr); err != nil {
 		require.NoError(t, fmt.Errorf("Could not connect to container at %s: %s", c.RPCAddr, err))
 	}
 
-	c.Log(fmt.Sprintf("- [%s] CONTAINER AVAILABLE AT PORT %s", c.ChainID, c.RPCAddr))
+	c.Log("container available", "rpc_addr", c.RPCAddr)
 
 	// initalize the lite client
 	require.NoError(t, c.ForceInitLite())
@@ -144,7 +144,7 @@ func cleanUpTest(t *testing.T, testsDone <-chan struct{}, contDone chan<- struct
 			require.NoError(t, fmt.Errorf("Could not purge container %s: %w", r.Container.Name, err))
 		}
 		c := getLoggingChain(chains, r)
-		chains[i].Log(fmt.Sprintf("- [%s] SPUN DOWN CONTAINER %s from %s", c.ChainID, r.Container.Name, r.Container.Config.Image))
+		chains[i].Log("spun down container", "container", r.Container.Name, "image", r.Container.Config.Image)
 	}
 
 	// Notify the other side that we have deleted the docker containers
@@ -1 +1 @@
-bccli rest-server --chain-id sunchain --trust-node
+bccli rest-server --chain-id sunchain --trust-node --keyring-backend test
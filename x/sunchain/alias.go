@@ -9,6 +9,15 @@ const (
 	ModuleName = types.ModuleName
 	RouterKey  = types.RouterKey
 	StoreKey   = types.StoreKey
+
+	EventTypeProduct      = types.EventTypeProduct
+	EventTypeSell         = types.EventTypeSell
+	EventTypeReservation  = types.EventTypeReservation
+	EventTypeOrder        = types.EventTypeOrder
+	AttributeKeyProductID = types.AttributeKeyProductID
+
+	ProposalTypeCategoryChange = types.ProposalTypeCategoryChange
+	ProductMetadataUpgrade     = types.ProductMetadataUpgrade
 )
 
 var (
@@ -25,18 +34,34 @@ var (
 	NewMsgDeleteSell = types.NewMsgDeleteSell
 	NewMsgDecideSell = types.NewMsgDecideSell
 
+	NewMsgCreateFiatSell        = types.NewMsgCreateFiatSell
+	NewMsgCreateFiatReservation = types.NewMsgCreateFiatReservation
+	NewMsgRequestQuote          = types.NewMsgRequestQuote
+
 	NewMsgCreateReservation = types.NewMsgCreateReservation
 	NewMsgUpdateReservation = types.NewMsgUpdateReservation
 	NewMsgDeleteReservation = types.NewMsgDeleteReservation
 	NewMsgPayReservation    = types.NewMsgPayReservation
+
+	NewMsgSetSourceChannel = types.NewMsgSetSourceChannel
+	NewSourceChannel       = types.NewSourceChannel
+
+	NewCategory               = types.NewCategory
+	DefaultCategories         = types.DefaultCategories
+	NewCategoryChangeProposal = types.NewCategoryChangeProposal
 )
 
 type (
 	Keeper              = keeper.Keeper
 	MsgBuyGold          = types.MsgBuyGold
 	MsgSetSourceChannel = types.MsgSetSourceChannel
+	SourceChannel       = types.SourceChannel
 
 	Product          = types.Product
+	ProductMetadata  = types.ProductMetadata
+	ProductImage     = types.ProductImage
+	ProductAttribute = types.ProductAttribute
+	ProductV0        = types.ProductV0
 	MsgCreateProduct = types.MsgCreateProduct
 	MsgUpdateProduct = types.MsgUpdateProduct
 
@@ -51,4 +76,12 @@ type (
 	MsgUpdateReservation = types.MsgUpdateReservation
 	MsgDeleteReservation = types.MsgDeleteReservation
 	MsgPayReservation    = types.MsgPayReservation
+
+	MsgCreateFiatSell        = types.MsgCreateFiatSell
+	MsgCreateFiatReservation = types.MsgCreateFiatReservation
+	MsgRequestQuote          = types.MsgRequestQuote
+	Settlement               = types.Settlement
+
+	Category               = types.Category
+	CategoryChangeProposal = types.CategoryChangeProposal
 )
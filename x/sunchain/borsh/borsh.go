@@ -0,0 +1,261 @@
+// Package borsh implements the Borsh binary serialization format used by BandChain oracle
+// scripts for calldata and results (https://borsh.io).
+//
+// Values are mapped to Borsh types by their Go kind:
+//
+//	uint8 ... uint64, int8 ... int64   fixed-size little-endian integers
+//	bool                               a single 0 or 1 byte
+//	string, []byte                     u32 length followed by the bytes
+//	[]T                                Vec<T>, u32 length followed by the elements
+//	[N]T                               fixed-size array, the N elements without length
+//	*T                                 Option<T>, 0 for nil or 1 followed by the value
+//	struct                             the exported fields in declaration order
+//
+// Empty vectors are decoded as nil slices. A struct field tagged with `borsh:"-"` is skipped.
+// Platform dependent integers, floats, maps and interfaces are not supported. Marshal
+// dereferences a top-level pointer so both Marshal(v) and Marshal(&v) produce the encoding of v.
+package borsh
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// Marshal returns the Borsh encoding of v.
+func Marshal(v interface{}) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	encoder := NewEncoder()
+	if err := encoder.encodeValue(rv); err != nil {
+		return nil, err
+	}
+	return encoder.GetEncodedData(), nil
+}
+
+// Unmarshal decodes the Borsh encoded data into the value pointed to by v. It fails if
+// any byte is left after the value is decoded.
+func Unmarshal(data []byte, v interface{}) error {
+	decoder := NewDecoder(data)
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if !decoder.Finished() {
+		return ErrBytesLeft
+	}
+	return nil
+}
+
+// Encode appends the Borsh encoding of v.
+func (encoder *Encoder) Encode(v interface{}) error {
+	return encoder.encodeValue(reflect.ValueOf(v))
+}
+
+// Decode decodes the next value into the value pointed to by v.
+func (decoder *Decoder) Decode(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.New("borsh: decode requires a non-nil pointer")
+	}
+	return decoder.decodeValue(rv.Elem())
+}
+
+func (encoder *Encoder) encodeValue(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Uint8:
+		encoder.EncodeU8(uint8(v.Uint()))
+	case reflect.Uint16:
+		encoder.EncodeU16(uint16(v.Uint()))
+	case reflect.Uint32:
+		encoder.EncodeU32(uint32(v.Uint()))
+	case reflect.Uint64:
+		encoder.EncodeU64(v.Uint())
+	case reflect.Int8:
+		encoder.EncodeI8(int8(v.Int()))
+	case reflect.Int16:
+		encoder.EncodeI16(int16(v.Int()))
+	case reflect.Int32:
+		encoder.EncodeI32(int32(v.Int()))
+	case reflect.Int64:
+		encoder.EncodeI64(v.Int())
+	case reflect.Bool:
+		encoder.EncodeBool(v.Bool())
+	case reflect.String:
+		if uint64(v.Len()) > math.MaxUint32 {
+			return fmt.Errorf("borsh: string of length %d is too long", v.Len())
+		}
+		encoder.EncodeString(v.String())
+	case reflect.Slice:
+		if uint64(v.Len()) > math.MaxUint32 {
+			return fmt.Errorf("borsh: vector of length %d is too long", v.Len())
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			encoder.EncodeBytes(v.Bytes())
+			return nil
+		}
+		encoder.EncodeU32(uint32(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			if err := encoder.encodeValue(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := encoder.encodeValue(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Ptr:
+		if v.IsNil() {
+			encoder.EncodeOption(false)
+			return nil
+		}
+		encoder.EncodeOption(true)
+		return encoder.encodeValue(v.Elem())
+	case reflect.Struct:
+		for _, i := range structFields(v.Type()) {
+			if err := encoder.encodeValue(v.Field(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Invalid:
+		return errors.New("borsh: cannot encode nil")
+	default:
+		return fmt.Errorf("borsh: unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+func (decoder *Decoder) decodeValue(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Uint8:
+		val, err := decoder.DecodeU8()
+		if err != nil {
+			return err
+		}
+		v.SetUint(uint64(val))
+	case reflect.Uint16:
+		val, err := decoder.DecodeU16()
+		if err != nil {
+			return err
+		}
+		v.SetUint(uint64(val))
+	case reflect.Uint32:
+		val, err := decoder.DecodeU32()
+		if err != nil {
+			return err
+		}
+		v.SetUint(uint64(val))
+	case reflect.Uint64:
+		val, err := decoder.DecodeU64()
+		if err != nil {
+			return err
+		}
+		v.SetUint(val)
+	case reflect.Int8:
+		val, err := decoder.DecodeI8()
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(val))
+	case reflect.Int16:
+		val, err := decoder.DecodeI16()
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(val))
+	case reflect.Int32:
+		val, err := decoder.DecodeI32()
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(val))
+	case reflect.Int64:
+		val, err := decoder.DecodeI64()
+		if err != nil {
+			return err
+		}
+		v.SetInt(val)
+	case reflect.Bool:
+		val, err := decoder.DecodeBool()
+		if err != nil {
+			return err
+		}
+		v.SetBool(val)
+	case reflect.String:
+		val, err := decoder.DecodeString()
+		if err != nil {
+			return err
+		}
+		v.SetString(val)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			val, err := decoder.DecodeBytes()
+			if err != nil {
+				return err
+			}
+			v.SetBytes(val)
+			return nil
+		}
+		length, err := decoder.DecodeLength()
+		if err != nil {
+			return err
+		}
+		if length == 0 {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		slice := reflect.MakeSlice(v.Type(), int(length), int(length))
+		for i := 0; i < int(length); i++ {
+			if err := decoder.decodeValue(slice.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := decoder.decodeValue(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Ptr:
+		some, err := decoder.DecodeOption()
+		if err != nil {
+			return err
+		}
+		if !some {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		val := reflect.New(v.Type().Elem())
+		if err := decoder.decodeValue(val.Elem()); err != nil {
+			return err
+		}
+		v.Set(val)
+	case reflect.Struct:
+		for _, i := range structFields(v.Type()) {
+			if err := decoder.decodeValue(v.Field(i)); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("borsh: unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// structFields returns the indexes of the fields that are part of the encoding of the struct.
+func structFields(t reflect.Type) []int {
+	fields := make([]int, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" || field.Tag.Get("borsh") == "-" {
+			continue
+		}
+		fields = append(fields, i)
+	}
+	return fields
+}
@@ -0,0 +1,188 @@
+package borsh
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testInner struct {
+	Flag  bool
+	Small int8
+	Name  string
+}
+
+type testValue struct {
+	U8      uint8
+	U16     uint16
+	U32     uint32
+	U64     uint64
+	I8      int8
+	I16     int16
+	I32     int32
+	I64     int64
+	Bytes   []byte
+	Inner   testInner
+	Opt     *testInner
+	OptU64  *uint64
+	Vec     []testInner
+	Fixed   [3]int16
+	Nested  [][]string
+	Skipped string `borsh:"-"`
+	private uint64
+}
+
+func TestEncodePrimitives(t *testing.T) {
+	bz, err := Marshal(struct {
+		A uint32
+		B string
+		C int16
+		D bool
+		E *uint8
+	}{A: 1, B: "hi", C: -2, D: true})
+	require.NoError(t, err)
+	require.Equal(t, []byte{
+		0x01, 0x00, 0x00, 0x00,
+		0x02, 0x00, 0x00, 0x00, 'h', 'i',
+		0xfe, 0xff,
+		0x01,
+		0x00,
+	}, bz)
+}
+
+func TestMarshalDereferencesTopLevelPointer(t *testing.T) {
+	v := testInner{Flag: true, Small: 3, Name: "gold"}
+	bz1, err := Marshal(v)
+	require.NoError(t, err)
+	bz2, err := Marshal(&v)
+	require.NoError(t, err)
+	require.Equal(t, bz1, bz2)
+}
+
+func TestRoundTrip(t *testing.T) {
+	px := uint64(1850)
+	v := testValue{
+		U8: 0xff, U16: 0xbeef, U32: 0xdeadbeef, U64: 1 << 63,
+		I8: -128, I16: -1, I32: -123456, I64: -1 << 63,
+		Bytes:  []byte{1, 2, 3},
+		Inner:  testInner{Flag: true, Small: -7, Name: "XAU"},
+		Opt:    &testInner{Name: "USD"},
+		OptU64: &px,
+		Vec:    []testInner{{Name: "a"}, {Flag: true}},
+		Fixed:  [3]int16{-1, 0, 1},
+		Nested: [][]string{{"x"}, nil, {"y", "z"}},
+	}
+	bz, err := Marshal(v)
+	require.NoError(t, err)
+
+	var out testValue
+	require.NoError(t, Unmarshal(bz, &out))
+	require.Equal(t, v, out)
+}
+
+func TestSkippedFields(t *testing.T) {
+	v := testValue{Skipped: "not encoded", private: 42}
+	bz, err := Marshal(v)
+	require.NoError(t, err)
+
+	var out testValue
+	require.NoError(t, Unmarshal(bz, &out))
+	require.Equal(t, "", out.Skipped)
+	require.Equal(t, uint64(0), out.private)
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	var u64 uint64
+	require.Equal(t, ErrOutOfRange, Unmarshal([]byte{1, 2, 3}, &u64))
+	require.Equal(t, ErrBytesLeft, Unmarshal([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9}, &u64))
+
+	var b bool
+	require.Equal(t, ErrInvalidBool, Unmarshal([]byte{2}, &b))
+
+	var opt *uint8
+	require.Equal(t, ErrInvalidOption, Unmarshal([]byte{2, 0}, &opt))
+
+	// A length prefix larger than the data must not allocate
+	var s []uint32
+	require.Equal(t, ErrOutOfRange, Unmarshal([]byte{0xff, 0xff, 0xff, 0xff}, &s))
+
+	require.Error(t, Unmarshal([]byte{0}, u64))
+
+	var i int
+	require.Error(t, Unmarshal([]byte{0}, &i))
+	_, err := Marshal(1.5)
+	require.Error(t, err)
+}
+
+func randomInner(r *rand.Rand) testInner {
+	name := make([]byte, r.Intn(8))
+	r.Read(name)
+	return testInner{Flag: r.Intn(2) == 1, Small: int8(r.Int()), Name: string(name)}
+}
+
+func randomValue(r *rand.Rand) testValue {
+	v := testValue{
+		U8: uint8(r.Int()), U16: uint16(r.Int()), U32: r.Uint32(), U64: r.Uint64(),
+		I8: int8(r.Int()), I16: int16(r.Int()), I32: int32(r.Uint32()), I64: int64(r.Uint64()),
+		Inner: randomInner(r),
+		Fixed: [3]int16{int16(r.Int()), int16(r.Int()), int16(r.Int())},
+	}
+	if n := r.Intn(4); n > 0 {
+		v.Bytes = make([]byte, n)
+		r.Read(v.Bytes)
+	}
+	if r.Intn(2) == 1 {
+		inner := randomInner(r)
+		v.Opt = &inner
+	}
+	if r.Intn(2) == 1 {
+		u := r.Uint64()
+		v.OptU64 = &u
+	}
+	for i := r.Intn(3); i > 0; i-- {
+		v.Vec = append(v.Vec, randomInner(r))
+	}
+	for i := r.Intn(3); i > 0; i-- {
+		var strs []string
+		for j := r.Intn(3); j > 0; j-- {
+			strs = append(strs, randomInner(r).Name)
+		}
+		v.Nested = append(v.Nested, strs)
+	}
+	return v
+}
+
+func TestFuzzRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		v := randomValue(r)
+		bz, err := Marshal(v)
+		require.NoError(t, err)
+
+		var out testValue
+		require.NoError(t, Unmarshal(bz, &out))
+		require.Equal(t, v, out)
+
+		// Any truncation of a valid encoding must fail cleanly
+		cut := r.Intn(len(bz))
+		require.Error(t, Unmarshal(bz[:cut], &out))
+	}
+}
+
+func TestFuzzRandomBytes(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 20000; i++ {
+		data := make([]byte, r.Intn(128))
+		r.Read(data)
+
+		var out testValue
+		if err := Unmarshal(data, &out); err != nil {
+			continue
+		}
+		// Borsh is canonical, so everything that decodes must encode back to the input
+		bz, err := Marshal(out)
+		require.NoError(t, err)
+		require.Equal(t, data, bz)
+	}
+}
@@ -0,0 +1,179 @@
+package borsh
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+var (
+	// ErrOutOfRange is returned when the data ends before the value being decoded
+	ErrOutOfRange = errors.New("borsh: out of range")
+	// ErrBytesLeft is returned when there are bytes left after decoding the whole value
+	ErrBytesLeft = errors.New("borsh: bytes left after decoding")
+	// ErrInvalidBool is returned when a bool is neither 0 nor 1
+	ErrInvalidBool = errors.New("borsh: invalid bool")
+	// ErrInvalidOption is returned when an option tag is neither 0 nor 1
+	ErrInvalidOption = errors.New("borsh: invalid option")
+)
+
+// Decoder reads Borsh encoded values from a byte slice.
+type Decoder struct {
+	data   []byte
+	offset uint64
+}
+
+// NewDecoder creates a new Decoder reading from the given data.
+func NewDecoder(data []byte) *Decoder {
+	return &Decoder{
+		data:   data,
+		offset: 0,
+	}
+}
+
+// Finished returns true if all bytes have been decoded.
+func (decoder *Decoder) Finished() bool {
+	return decoder.offset == uint64(len(decoder.data))
+}
+
+// Remaining returns the number of bytes that have not been decoded yet.
+func (decoder *Decoder) Remaining() uint64 {
+	return uint64(len(decoder.data)) - decoder.offset
+}
+
+func (decoder *Decoder) read(length uint64) ([]byte, error) {
+	if decoder.Remaining() < length {
+		return nil, ErrOutOfRange
+	}
+	val := decoder.data[decoder.offset : decoder.offset+length]
+	decoder.offset += length
+	return val, nil
+}
+
+// DecodeU8 decodes an unsigned 8-bit integer.
+func (decoder *Decoder) DecodeU8() (uint8, error) {
+	bz, err := decoder.read(1)
+	if err != nil {
+		return 0, err
+	}
+	return bz[0], nil
+}
+
+// DecodeU16 decodes a little-endian unsigned 16-bit integer.
+func (decoder *Decoder) DecodeU16() (uint16, error) {
+	bz, err := decoder.read(2)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint16(bz), nil
+}
+
+// DecodeU32 decodes a little-endian unsigned 32-bit integer.
+func (decoder *Decoder) DecodeU32() (uint32, error) {
+	bz, err := decoder.read(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(bz), nil
+}
+
+// DecodeU64 decodes a little-endian unsigned 64-bit integer.
+func (decoder *Decoder) DecodeU64() (uint64, error) {
+	bz, err := decoder.read(8)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(bz), nil
+}
+
+// DecodeI8 decodes a signed 8-bit integer.
+func (decoder *Decoder) DecodeI8() (int8, error) {
+	val, err := decoder.DecodeU8()
+	return int8(val), err
+}
+
+// DecodeI16 decodes a little-endian two's complement signed 16-bit integer.
+func (decoder *Decoder) DecodeI16() (int16, error) {
+	val, err := decoder.DecodeU16()
+	return int16(val), err
+}
+
+// DecodeI32 decodes a little-endian two's complement signed 32-bit integer.
+func (decoder *Decoder) DecodeI32() (int32, error) {
+	val, err := decoder.DecodeU32()
+	return int32(val), err
+}
+
+// DecodeI64 decodes a little-endian two's complement signed 64-bit integer.
+func (decoder *Decoder) DecodeI64() (int64, error) {
+	val, err := decoder.DecodeU64()
+	return int64(val), err
+}
+
+// DecodeBool decodes a bool stored as a single 0 or 1 byte.
+func (decoder *Decoder) DecodeBool() (bool, error) {
+	val, err := decoder.DecodeU8()
+	if err != nil {
+		return false, err
+	}
+	switch val {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	default:
+		return false, ErrInvalidBool
+	}
+}
+
+// DecodeLength decodes the u32 length prefix of a vector, string or map. The length is
+// checked against the remaining bytes so a corrupted prefix cannot trigger a huge allocation.
+func (decoder *Decoder) DecodeLength() (uint32, error) {
+	length, err := decoder.DecodeU32()
+	if err != nil {
+		return 0, err
+	}
+	if uint64(length) > decoder.Remaining() {
+		return 0, ErrOutOfRange
+	}
+	return length, nil
+}
+
+// DecodeBytes decodes a u32 length-prefixed byte vector. An empty vector is returned as nil.
+func (decoder *Decoder) DecodeBytes() ([]byte, error) {
+	length, err := decoder.DecodeLength()
+	if err != nil {
+		return nil, err
+	}
+	bz, err := decoder.read(uint64(length))
+	if err != nil || length == 0 {
+		return nil, err
+	}
+	val := make([]byte, length)
+	copy(val, bz)
+	return val, nil
+}
+
+// DecodeString decodes a u32 length-prefixed UTF-8 string.
+func (decoder *Decoder) DecodeString() (string, error) {
+	bz, err := decoder.DecodeBytes()
+	if err != nil {
+		return "", err
+	}
+	return string(bz), nil
+}
+
+// DecodeOption decodes the tag of an option and returns true if a value follows.
+func (decoder *Decoder) DecodeOption() (bool, error) {
+	val, err := decoder.DecodeU8()
+	if err != nil {
+		return false, err
+	}
+	switch val {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	default:
+		return false, ErrInvalidOption
+	}
+}
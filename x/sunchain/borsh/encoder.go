@@ -0,0 +1,93 @@
+package borsh
+
+import (
+	"encoding/binary"
+)
+
+// Encoder writes Borsh encoded values to a byte slice.
+type Encoder struct {
+	data []byte
+}
+
+// NewEncoder creates a new empty Encoder.
+func NewEncoder() *Encoder {
+	return &Encoder{
+		data: []byte{},
+	}
+}
+
+// GetEncodedData returns all bytes written so far.
+func (encoder *Encoder) GetEncodedData() []byte {
+	return encoder.data
+}
+
+// EncodeU8 encodes an unsigned 8-bit integer.
+func (encoder *Encoder) EncodeU8(val uint8) {
+	encoder.data = append(encoder.data, val)
+}
+
+// EncodeU16 encodes an unsigned 16-bit integer in little-endian.
+func (encoder *Encoder) EncodeU16(val uint16) {
+	bz := make([]byte, 2)
+	binary.LittleEndian.PutUint16(bz, val)
+	encoder.data = append(encoder.data, bz...)
+}
+
+// EncodeU32 encodes an unsigned 32-bit integer in little-endian.
+func (encoder *Encoder) EncodeU32(val uint32) {
+	bz := make([]byte, 4)
+	binary.LittleEndian.PutUint32(bz, val)
+	encoder.data = append(encoder.data, bz...)
+}
+
+// EncodeU64 encodes an unsigned 64-bit integer in little-endian.
+func (encoder *Encoder) EncodeU64(val uint64) {
+	bz := make([]byte, 8)
+	binary.LittleEndian.PutUint64(bz, val)
+	encoder.data = append(encoder.data, bz...)
+}
+
+// EncodeI8 encodes a signed 8-bit integer.
+func (encoder *Encoder) EncodeI8(val int8) {
+	encoder.EncodeU8(uint8(val))
+}
+
+// EncodeI16 encodes a signed 16-bit integer in little-endian two's complement.
+func (encoder *Encoder) EncodeI16(val int16) {
+	encoder.EncodeU16(uint16(val))
+}
+
+// EncodeI32 encodes a signed 32-bit integer in little-endian two's complement.
+func (encoder *Encoder) EncodeI32(val int32) {
+	encoder.EncodeU32(uint32(val))
+}
+
+// EncodeI64 encodes a signed 64-bit integer in little-endian two's complement.
+func (encoder *Encoder) EncodeI64(val int64) {
+	encoder.EncodeU64(uint64(val))
+}
+
+// EncodeBool encodes a bool as a single 0 or 1 byte.
+func (encoder *Encoder) EncodeBool(val bool) {
+	if val {
+		encoder.EncodeU8(1)
+	} else {
+		encoder.EncodeU8(0)
+	}
+}
+
+// EncodeBytes encodes a byte vector with its u32 length prefix.
+func (encoder *Encoder) EncodeBytes(val []byte) {
+	encoder.EncodeU32(uint32(len(val)))
+	encoder.data = append(encoder.data, val...)
+}
+
+// EncodeString encodes a string with its u32 length prefix.
+func (encoder *Encoder) EncodeString(val string) {
+	encoder.EncodeBytes([]byte(val))
+}
+
+// EncodeOption encodes the tag of an option, 1 if a value follows and 0 otherwise.
+func (encoder *Encoder) EncodeOption(some bool) {
+	encoder.EncodeBool(some)
+}
@@ -0,0 +1,46 @@
+//go:build gofuzz
+// +build gofuzz
+
+package borsh
+
+import (
+	"bytes"
+)
+
+type fuzzInner struct {
+	Flag  bool
+	Small int8
+	Name  string
+}
+
+type fuzzValue struct {
+	U8     uint8
+	U16    uint16
+	U32    uint32
+	U64    uint64
+	I16    int16
+	I32    int32
+	I64    int64
+	Bytes  []byte
+	Inner  fuzzInner
+	Opt    *fuzzInner
+	Vec    []fuzzInner
+	Fixed  [3]int16
+	Nested [][]string
+}
+
+// Fuzz is the entry point for go-fuzz. Every input that decodes must encode back to itself.
+func Fuzz(data []byte) int {
+	var v fuzzValue
+	if err := Unmarshal(data, &v); err != nil {
+		return 0
+	}
+	bz, err := Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	if !bytes.Equal(bz, data) {
+		panic("borsh: decoded value does not encode back to its input")
+	}
+	return 1
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	authclient "github.com/cosmos/cosmos-sdk/x/auth/client"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/spf13/cobra"
+
+	"github.com/trinhtan/cosmos-hackathon/x/sunchain/types"
+)
+
+// CategoryChangeProposalJSON defines a CategoryChangeProposal with a deposit
+type CategoryChangeProposalJSON struct {
+	Title       string           `json:"title"`
+	Description string           `json:"description"`
+	Set         []types.Category `json:"set"`
+	Remove      []string         `json:"remove"`
+	Deposit     string           `json:"deposit"`
+}
+
+// ParseCategoryChangeProposalJSON reads and parses a CategoryChangeProposalJSON from a file.
+func ParseCategoryChangeProposalJSON(cdc *codec.Codec, proposalFile string) (CategoryChangeProposalJSON, error) {
+	proposal := CategoryChangeProposalJSON{}
+
+	contents, err := ioutil.ReadFile(proposalFile)
+	if err != nil {
+		return proposal, err
+	}
+
+	if err := cdc.UnmarshalJSON(contents, &proposal); err != nil {
+		return proposal, err
+	}
+
+	return proposal, nil
+}
+
+// GetCmdSubmitCategoryChangeProposal implements the command to submit a category change proposal
+func GetCmdSubmitCategoryChangeProposal(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sunchain-category-change [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a proposal to change the product categories of sunchain",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal to add, update or remove product categories along with an initial deposit.
+Categories of "set" are added or replaced, then categories of "remove" are removed. A category can
+only be removed when it has no sub categories and no products.
+The proposal details must be supplied via a JSON file.
+
+Example:
+$ %s tx gov submit-proposal sunchain-category-change <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+  "title": "Add a watches category",
+  "description": "Watches get their own category with a listing fee",
+  "set": [
+    {
+      "name": "watches",
+      "parent": "jewelry",
+      "description": "Wrist and pocket watches",
+      "listingFee": [{"denom": "stake", "amount": "10"}],
+      "requiredAttributes": ["brand"]
+    }
+  ],
+  "remove": [],
+  "deposit": "1000stake"
+}
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(authclient.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			proposal, err := ParseCategoryChangeProposalJSON(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			content := types.NewCategoryChangeProposal(proposal.Title, proposal.Description, proposal.Set, proposal.Remove)
+
+			deposit, err := sdk.ParseCoins(proposal.Deposit)
+			if err != nil {
+				return err
+			}
+			msg := gov.NewMsgSubmitProposal(content, deposit, cliCtx.GetFromAddress())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return authclient.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	return cmd
+}
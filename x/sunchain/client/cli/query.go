@@ -2,11 +2,13 @@ package cli
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/version"
 	"github.com/spf13/cobra"
 
 	"github.com/trinhtan/cosmos-hackathon/x/sunchain/types"
@@ -23,12 +25,21 @@ func GetQueryCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 	}
 	sunchainCmd.AddCommand(flags.GetCommands(
 		GetCmdReadOrder(storeKey, cdc),
+		GetCmdLatestPrice(storeKey, cdc),
+		GetCmdPrices(storeKey, cdc),
+		GetCmdTWAP(storeKey, cdc),
+		GetCmdQuote(storeKey, cdc),
+		GetCmdSettlement(storeKey, cdc),
 		GetCmdProduct(storeKey, cdc),
 		GetCmdProducts(storeKey, cdc),
 		GetCmdSell(storeKey, cdc),
 		GetCmdSells(storeKey, cdc),
 		GetCmdReservation(storeKey, cdc),
 		GetCmdReservations(storeKey, cdc),
+		GetCmdListing(storeKey, cdc),
+		GetCmdCategory(storeKey, cdc),
+		GetCmdCategories(storeKey, cdc),
+		GetCmdProductsByCategory(storeKey, cdc),
 	)...)
 
 	return sunchainCmd
@@ -48,8 +59,7 @@ func GetCmdReadOrder(queryRoute string, cdc *codec.Codec) *cobra.Command {
 				nil,
 			)
 			if err != nil {
-				fmt.Printf("read request fail - %s \n", orderID)
-				return nil
+				return fmt.Errorf("could not get order %s: %w", orderID, err)
 			}
 
 			var order types.Order
@@ -61,6 +71,122 @@ func GetCmdReadOrder(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	}
 }
 
+// GetCmdLatestPrice queries the most recent gold price
+func GetCmdLatestPrice(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "price",
+		Short: "Query the latest gold price",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/price", queryRoute), nil)
+			if err != nil {
+				return err
+			}
+
+			var out types.Price
+			if err := cdc.UnmarshalJSON(res, &out); err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdPrices queries gold prices recorded in a time range
+func GetCmdPrices(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "prices [from-unix-time] [to-unix-time]",
+		Short: "Query gold prices recorded in a time range",
+		Long:  fmt.Sprintf("Query gold prices recorded in a time range. At most the latest %d prices of the range are returned.", types.MaxPricesPerQuery),
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/prices/%s/%s", queryRoute, args[0], args[1]), nil)
+			if err != nil {
+				return err
+			}
+
+			var out types.QueryResPrices
+			if err := cdc.UnmarshalJSON(res, &out); err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdTWAP queries time-weighted average gold price
+func GetCmdTWAP(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "twap [window-seconds]",
+		Short: "Query time-weighted average gold price over the last window seconds",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/twap/%s", queryRoute, args[0]), nil)
+			if err != nil {
+				return err
+			}
+
+			var out types.TWAP
+			if err := cdc.UnmarshalJSON(res, &out); err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdQuote queries the latest quote of a denom in a currency
+func GetCmdQuote(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "quote [currency] [denom]",
+		Short: "Query the latest quote of a denom in a reference currency",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/quote/%s/%s", queryRoute, args[0], args[1]), nil)
+			if err != nil {
+				return err
+			}
+
+			var out types.Quote
+			if err := cdc.UnmarshalJSON(res, &out); err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdSettlement queries the latest settlement of a paid sell
+func GetCmdSettlement(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "settlement [sellID]",
+		Short: "Query the latest settlement of a paid sell",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/settlement/%s", queryRoute, args[0]), nil)
+			if err != nil {
+				return err
+			}
+
+			var out types.Settlement
+			if err := cdc.UnmarshalJSON(res, &out); err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
 // GetCmdProduct queries information about a product
 func GetCmdProduct(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
@@ -73,8 +199,7 @@ func GetCmdProduct(queryRoute string, cdc *codec.Codec) *cobra.Command {
 
 			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/product/%s", queryRoute, productID), nil)
 			if err != nil {
-				fmt.Printf("could not get product - %s \n", productID)
-				return nil
+				return fmt.Errorf("could not get product %s: %w", productID, err)
 			}
 
 			var out types.Product
@@ -98,8 +223,7 @@ func GetCmdProducts(queryRoute string, cdc *codec.Codec) *cobra.Command {
 
 			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/products", queryRoute), nil)
 			if err != nil {
-				fmt.Printf("could not get query products\n")
-				return nil
+				return fmt.Errorf("could not get products: %w", err)
 			}
 
 			var out types.QueryResProducts
@@ -124,8 +248,7 @@ func GetCmdSell(queryRoute string, cdc *codec.Codec) *cobra.Command {
 
 			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/sell/%s", queryRoute, sellID), nil)
 			if err != nil {
-				fmt.Printf("could not get sell - %s \n", sellID)
-				return nil
+				return fmt.Errorf("could not get sell %s: %w", sellID, err)
 			}
 
 			var out types.Sell
@@ -149,8 +272,7 @@ func GetCmdSells(queryRoute string, cdc *codec.Codec) *cobra.Command {
 
 			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/sells", queryRoute), nil)
 			if err != nil {
-				fmt.Printf("could not get query sells\n")
-				return nil
+				return fmt.Errorf("could not get sells: %w", err)
 			}
 
 			var out types.QueryResSells
@@ -175,8 +297,7 @@ func GetCmdReservation(queryRoute string, cdc *codec.Codec) *cobra.Command {
 
 			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/reservation/%s", queryRoute, reservationID), nil)
 			if err != nil {
-				fmt.Printf("could not get resertvation - %s \n", reservationID)
-				return nil
+				return fmt.Errorf("could not get reservation %s: %w", reservationID, err)
 			}
 
 			var out types.Reservation
@@ -200,8 +321,7 @@ func GetCmdReservations(queryRoute string, cdc *codec.Codec) *cobra.Command {
 
 			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/reservations", queryRoute), nil)
 			if err != nil {
-				fmt.Printf("could not get query reservations\n")
-				return nil
+				return fmt.Errorf("could not get reservations: %w", err)
 			}
 
 			var out types.QueryResReservations
@@ -213,3 +333,148 @@ func GetCmdReservations(queryRoute string, cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
+
+// GetCmdCategory queries a category of the product registry
+func GetCmdCategory(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "category [name]",
+		Short: "Query a product category",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/category/%s", queryRoute, args[0]), nil)
+			if err != nil {
+				return err
+			}
+
+			var out types.Category
+			if err := cdc.UnmarshalJSON(res, &out); err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdCategories queries all the categories of the product registry
+func GetCmdCategories(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "categories",
+		Short: "Query all product categories",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/categories", queryRoute), nil)
+			if err != nil {
+				return err
+			}
+
+			var out types.QueryResCategories
+			if err := cdc.UnmarshalJSON(res, &out); err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdProductsByCategory queries the products of a category and of its sub categories
+func GetCmdProductsByCategory(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "products-by-category [name]",
+		Short: "Query the products of a category and of its sub categories",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/productsByCategory/%s", queryRoute, args[0]), nil)
+			if err != nil {
+				return err
+			}
+
+			var out types.QueryResProducts
+			if err := cdc.UnmarshalJSON(res, &out); err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// Listing is a product joined with its current sell and the reservations made on it
+type Listing struct {
+	Product      types.Product              `json:"product" yaml:"product"`
+	Sell         *types.Sell                `json:"sell,omitempty" yaml:"sell,omitempty"`
+	Reservations types.QueryResReservations `json:"reservations" yaml:"reservations"`
+}
+
+// GetCmdListing returns the commands showing marketplace listings
+func GetCmdListing(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	listingCmd := &cobra.Command{
+		Use:                        "listing",
+		Short:                      "Querying commands for marketplace listings",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+	listingCmd.AddCommand(flags.GetCommands(
+		GetCmdShowListing(queryRoute, cdc),
+	)...)
+	return listingCmd
+}
+
+// GetCmdShowListing queries a product together with its sell and the reservations on it
+func GetCmdShowListing(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "show [productID]",
+		Short: "Show a product with its sell and reservations",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Show a product with its current sell and the reservations made on the sell.
+The sell and reservations are left out when the product is not on sale.
+
+Example:
+$ %s query sunchain listing show 1b4f0e98-51c7-4e6b-9a3e-2a7c5c6d8f10 --output json
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			productID := args[0]
+
+			var listing Listing
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/product/%s", queryRoute, productID), nil)
+			if err != nil {
+				return fmt.Errorf("could not get product %s: %w", productID, err)
+			}
+			if err := cdc.UnmarshalJSON(res, &listing.Product); err != nil {
+				return err
+			}
+			if !listing.Product.Selling {
+				return cliCtx.PrintOutput(listing)
+			}
+
+			sellID := listing.Product.SellID
+			res, _, err = cliCtx.QueryWithData(fmt.Sprintf("custom/%s/sell/%s", queryRoute, sellID), nil)
+			if err != nil {
+				return fmt.Errorf("could not get sell %s of product %s: %w", sellID, productID, err)
+			}
+			listing.Sell = &types.Sell{}
+			if err := cdc.UnmarshalJSON(res, listing.Sell); err != nil {
+				return err
+			}
+
+			res, _, err = cliCtx.QueryWithData(fmt.Sprintf("custom/%s/reservationsBySellID/%s", queryRoute, sellID), nil)
+			if err != nil {
+				return fmt.Errorf("could not get reservations of sell %s: %w", sellID, err)
+			}
+			if err := cdc.UnmarshalJSON(res, &listing.Reservations); err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(listing)
+		},
+	}
+}
@@ -2,7 +2,10 @@ package cli
 
 import (
 	"bufio"
+	"crypto/rand"
 	"fmt"
+	"io/ioutil"
+	"strconv"
 	"strings"
 
 	"github.com/cosmos/cosmos-sdk/client"
@@ -14,10 +17,17 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	authclient "github.com/cosmos/cosmos-sdk/x/auth/client"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/trinhtan/cosmos-hackathon/x/sunchain/types"
 )
 
+const (
+	flagSellID    = "sell-id"
+	flagMinPrice  = "min-price"
+	flagMaxAmount = "max-amount"
+)
+
 // GetTxCmd returns the transaction commands for this module
 func GetTxCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 	sunchainCmd := &cobra.Command{
@@ -34,15 +44,19 @@ func GetTxCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 		GetCmdUpdateProduct(cdc),
 
 		GetCmdCreateSell(cdc),
+		GetCmdSellFlow(cdc),
 		GetCmdUpdateSell(cdc),
 		GetCmdDeteleSell(cdc),
 		GetCmdDecideSell(cdc),
+		GetCmdCreateFiatSell(cdc),
 
 		GetCmdCreateReservation(cdc),
 		GetCmdUpdateReservation(cdc),
 		GetCmdDeleteReservation(cdc),
 		GetCmdPayReservation(cdc),
+		GetCmdCreateFiatReservation(cdc),
 
+		GetCmdRequestQuote(cdc),
 		GetCmdSetChannel(cdc),
 	)...)
 
@@ -96,9 +110,9 @@ func GetCmdSetChannel(cdc *codec.Codec) *cobra.Command {
 		Short: "Register a verified channel",
 		Args:  cobra.ExactArgs(3),
 		Long: strings.TrimSpace(
-			fmt.Sprintf(`Register a verified channel.
+			fmt.Sprintf(`Register a verified channel. Only the channel authority set at genesis may register channels.
 Example:
-$ %s tx sunchain set-cahnnel bandchain sunchain dbdfgsdfsd
+$ %s tx sunchain set-channel bandchain sunchain dbdfgsdfsd
 `,
 				version.ClientName,
 			),
@@ -129,17 +143,47 @@ $ %s tx sunchain set-cahnnel bandchain sunchain dbdfgsdfsd
 
 // GetCmdCreateProduct is the CLI command for sending a SetProduct transaction
 func GetCmdCreateProduct(cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
-		Use:   "create-product [productID] [title] [description] [category] [images]",
-		Short: "set the value associated with a product that you own",
-		Args:  cobra.ExactArgs(5),
+	cmd := &cobra.Command{
+		Use:   "create-product [metadata-file]",
+		Short: "create a product described by a metadata JSON file, whose id is assigned by the chain",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Create a product described by a metadata JSON file, such as:
+
+{
+  "title": "Gold ring",
+  "description": "18k gold ring",
+  "category": "jewelry",
+  "images": [{"uri": "https://ipfs.io/ipfs/Qm...", "hash": "<hex sha256 of the image>"}],
+  "attributes": [{"key": "weight_grams", "type": "decimal", "value": "3.5"}]
+}
+
+Attribute types are %s, %s, %s and %s.
+
+The product is put on sale in the same transaction when --min-price is given.
+
+Example:
+$ %s tx sunchain create-product ring.json --from mykey
+`,
+				types.AttributeTypeString, types.AttributeTypeInteger, types.AttributeTypeDecimal, types.AttributeTypeBool,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			inBuf := bufio.NewReader(cmd.InOrStdin())
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(authclient.GetTxEncoder(cdc))
 
-			msg := types.NewMsgCreateProduct(args[0], args[1], args[2], args[3], args[4], cliCtx.GetFromAddress())
-			err := msg.ValidateBasic()
+			metadata, err := readProductMetadata(cdc, args[0])
+			if err != nil {
+				return err
+			}
+			minPrice, err := sdk.ParseCoins(viper.GetString(flagMinPrice))
+			if err != nil {
+				return err
+			}
+
+			msg, err := newMsgCreateProduct(metadata, minPrice, cliCtx.GetFromAddress())
 			if err != nil {
 				return err
 			}
@@ -148,21 +192,60 @@ func GetCmdCreateProduct(cdc *codec.Codec) *cobra.Command {
 			return authclient.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
+	cmd.Flags().String(flagMinPrice, "", "put the product on sale at this minimum price")
+	cmd.Flags().String(flagSellID, "", "id of the sell, a random one if not given")
+	return cmd
+}
+
+// newMsgCreateProduct returns the message creating a product, which puts it on sale when minPrice
+// is not empty, with the id of the sell flag or a random one
+func newMsgCreateProduct(metadata types.ProductMetadata, minPrice sdk.Coins, signer sdk.AccAddress) (types.MsgCreateProduct, error) {
+	msg := types.NewMsgCreateProduct(metadata, signer)
+	if !minPrice.Empty() {
+		msg.MinPrice = minPrice
+		msg.SellID = viper.GetString(flagSellID)
+		if msg.SellID == "" {
+			sellID, err := newSellID()
+			if err != nil {
+				return msg, err
+			}
+			msg.SellID = sellID
+		}
+	}
+	return msg, msg.ValidateBasic()
+}
+
+// readProductMetadata reads product metadata from a JSON file
+func readProductMetadata(cdc *codec.Codec, path string) (types.ProductMetadata, error) {
+	var metadata types.ProductMetadata
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return metadata, err
+	}
+	if err := cdc.UnmarshalJSON(bz, &metadata); err != nil {
+		return metadata, fmt.Errorf("invalid metadata file %s: %w", path, err)
+	}
+	return metadata, nil
 }
 
 // GetCmdUpdateProduct is the CLI command for sending a SetProduct transaction
 func GetCmdUpdateProduct(cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
-		Use:   "update-product [productID] [title] [description] [category] [images]",
-		Short: "set the value associated with a product that you own",
-		Args:  cobra.ExactArgs(5),
+		Use:   "update-product [productID] [metadata-file]",
+		Short: "replace the metadata of a product that you own with a metadata JSON file",
+		Args:  cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			inBuf := bufio.NewReader(cmd.InOrStdin())
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(authclient.GetTxEncoder(cdc))
 
-			msg := types.NewMsgUpdateProduct(args[0], args[1], args[2], args[3], args[4], cliCtx.GetFromAddress())
-			err := msg.ValidateBasic()
+			metadata, err := readProductMetadata(cdc, args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgUpdateProduct(args[0], metadata, cliCtx.GetFromAddress())
+			err = msg.ValidateBasic()
 			if err != nil {
 				return err
 			}
@@ -201,6 +284,62 @@ func GetCmdCreateSell(cdc *codec.Codec) *cobra.Command {
 	}
 }
 
+// GetCmdSellFlow is the CLI command creating a product and putting it on sale in one transaction
+func GetCmdSellFlow(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sell-flow [metadata-file] [minPrice]",
+		Short: "create a product from a metadata JSON file and put it on sale in one transaction",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Create a product, list it in the category of its metadata and put it on sale at a
+minimum price, in one transaction. The metadata file is the one of create-product.
+
+The product id is assigned by the chain, and is found along with the sell id in the events of
+the transaction.
+
+Example:
+$ %s tx sunchain sell-flow ring.json 100stake --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(authclient.GetTxEncoder(cdc))
+
+			metadata, err := readProductMetadata(cdc, args[0])
+			if err != nil {
+				return err
+			}
+			minPrice, err := sdk.ParseCoins(args[1])
+			if err != nil {
+				return err
+			}
+			if minPrice.Empty() {
+				return fmt.Errorf("the minimum price must not be empty")
+			}
+
+			msg, err := newMsgCreateProduct(metadata, minPrice, cliCtx.GetFromAddress())
+			if err != nil {
+				return err
+			}
+			return authclient.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().String(flagSellID, "", "id of the sell, a random one if not given")
+	return cmd
+}
+
+// newSellID returns a random sell id, in the format the REST server uses
+func newSellID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
 // GetCmdUpdateSellcdc is the CLI command for sending a UpdateSell transaction
 func GetCmdUpdateSell(cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
@@ -356,17 +495,24 @@ func GetCmdDecideSell(cdc *codec.Codec) *cobra.Command {
 
 // GetCmdPayReservation cdc is the CLI command for sending a PayReservation transaction
 func GetCmdPayReservation(cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "pay-reservation [reservationID]",
-		Short: "set the value associated with a product that you own",
-		Args:  cobra.ExactArgs(1),
+		Short: "pay an accepted reservation",
+		Long: `Pay an accepted reservation. The payment is rejected if it is above --max-amount, which is
+required for sells priced in a fiat currency as the amount paid depends on the quote at the time of the payment.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			inBuf := bufio.NewReader(cmd.InOrStdin())
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(authclient.GetTxEncoder(cdc))
 
-			msg := types.NewMsgPayReservation(args[0], cliCtx.GetFromAddress())
-			err := msg.ValidateBasic()
+			maxAmount, err := sdk.ParseCoins(viper.GetString(flagMaxAmount))
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgPayReservation(args[0], maxAmount, cliCtx.GetFromAddress())
+			err = msg.ValidateBasic()
 			if err != nil {
 				return err
 			}
@@ -375,4 +521,100 @@ func GetCmdPayReservation(cdc *codec.Codec) *cobra.Command {
 			return authclient.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
+	cmd.Flags().String(flagMaxAmount, "", "the most to pay for the reservation")
+	return cmd
+}
+
+// GetCmdCreateFiatSell is the CLI command for sending a CreateFiatSell transaction
+func GetCmdCreateFiatSell(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "create-fiat-sell [sellID] [productID] [fiatMinPrice] [currency] [denom]",
+		Short: "put a product you own on sale at a price in a reference currency, paid in denom",
+		Args:  cobra.ExactArgs(5),
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Put a product on sale at a price in a reference currency.
+The buyer pays in denom, converted with a fresh oracle quote at payment time.
+Example:
+$ %s tx sunchain create-fiat-sell sell-1 product-1 2500 USD transfer/ibczeroxfer/uatom
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(authclient.GetTxEncoder(cdc))
+
+			fiatMinPrice, err := strconv.ParseUint(args[2], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgCreateFiatSell(args[0], args[1], cliCtx.GetFromAddress(), args[3], fiatMinPrice, args[4])
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return authclient.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdCreateFiatReservation is the CLI command for sending a CreateFiatReservation transaction
+func GetCmdCreateFiatReservation(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "create-fiat-reservation [reservationID] [sellID] [fiatPrice]",
+		Short: "offer a price in the reference currency of a sell",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(authclient.GetTxEncoder(cdc))
+
+			fiatPrice, err := strconv.ParseUint(args[2], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgCreateFiatReservation(args[0], args[1], cliCtx.GetFromAddress(), fiatPrice)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return authclient.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdRequestQuote implements the request quote command handler.
+func GetCmdRequestQuote(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "request-quote [currency] [denom]",
+		Short: "Request the price of a denom in a reference currency from BandChain",
+		Args:  cobra.ExactArgs(2),
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Request the price of a denom in a reference currency from BandChain.
+Fiat priced reservations can be paid while the quote is fresh.
+Example:
+$ %s tx sunchain request-quote USD transfer/ibczeroxfer/uatom
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(authclient.GetTxEncoder(cdc))
+
+			msg := types.NewMsgRequestQuote(args[0], args[1], cliCtx.GetFromAddress())
+			err := msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return authclient.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
 }
@@ -0,0 +1,11 @@
+package client
+
+import (
+	govclient "github.com/cosmos/cosmos-sdk/x/gov/client"
+
+	"github.com/trinhtan/cosmos-hackathon/x/sunchain/client/cli"
+	"github.com/trinhtan/cosmos-hackathon/x/sunchain/client/rest"
+)
+
+// ProposalHandler is the category change proposal handler of the gov client
+var ProposalHandler = govclient.NewProposalHandler(cli.GetCmdSubmitCategoryChangeProposal, rest.ProposalRESTHandler)
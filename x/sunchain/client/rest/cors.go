@@ -0,0 +1,100 @@
+package rest
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/spf13/viper"
+)
+
+// REST server flags that configure CORS on the sunchain routes. They can also be set in the config file.
+const (
+	FlagCORSAllowedOrigins = "cors-allowed-origins"
+	FlagCORSAllowedMethods = "cors-allowed-methods"
+	FlagCORSAllowedHeaders = "cors-allowed-headers"
+)
+
+// Default CORS settings, which let a browser frontend on any origin use every route.
+var (
+	DefaultCORSAllowedOrigins = []string{"*"}
+	DefaultCORSAllowedMethods = []string{
+		http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions,
+	}
+	DefaultCORSAllowedHeaders = []string{"Content-Type"}
+)
+
+// corsMaxAge is how long in seconds browsers may cache the result of a preflight request
+const corsMaxAge = "3600"
+
+// CORSConfig holds the origins, methods and headers browsers are allowed to use on the REST routes
+type CORSConfig struct {
+	AllowedOrigins []string
+	AllowedMethods []string
+	AllowedHeaders []string
+}
+
+// NewCORSConfigFromViper reads the CORS settings from the REST server flags, using the defaults for unset ones.
+func NewCORSConfigFromViper() CORSConfig {
+	config := CORSConfig{
+		AllowedOrigins: viper.GetStringSlice(FlagCORSAllowedOrigins),
+		AllowedMethods: viper.GetStringSlice(FlagCORSAllowedMethods),
+		AllowedHeaders: viper.GetStringSlice(FlagCORSAllowedHeaders),
+	}
+	if len(config.AllowedOrigins) == 0 {
+		config.AllowedOrigins = DefaultCORSAllowedOrigins
+	}
+	if len(config.AllowedMethods) == 0 {
+		config.AllowedMethods = DefaultCORSAllowedMethods
+	}
+	if len(config.AllowedHeaders) == 0 {
+		config.AllowedHeaders = DefaultCORSAllowedHeaders
+	}
+	return config
+}
+
+// allowedOrigin returns the value of the Access-Control-Allow-Origin header for the given request origin,
+// or an empty string if the origin is not allowed.
+func (config CORSConfig) allowedOrigin(origin string) string {
+	for _, allowed := range config.AllowedOrigins {
+		if allowed == "*" {
+			return "*"
+		}
+		if origin != "" && strings.EqualFold(allowed, origin) {
+			return origin
+		}
+	}
+	return ""
+}
+
+// corsMiddleware sets the CORS headers on every response and answers preflight requests.
+func corsMiddleware(config CORSConfig) mux.MiddlewareFunc {
+	methods := strings.Join(config.AllowedMethods, ", ")
+	headers := strings.Join(config.AllowedHeaders, ", ")
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := config.allowedOrigin(r.Header.Get("Origin"))
+			if origin != "" {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+				if origin != "*" {
+					w.Header().Add("Vary", "Origin")
+				}
+			}
+			if r.Method != http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if origin != "" {
+				w.Header().Set("Access-Control-Allow-Methods", methods)
+				w.Header().Set("Access-Control-Allow-Headers", headers)
+				w.Header().Set("Access-Control-Max-Age", corsMaxAge)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
+
+// preflightHandler matches OPTIONS requests on every route so that corsMiddleware runs for them.
+func preflightHandler(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+}
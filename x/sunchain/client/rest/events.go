@@ -0,0 +1,346 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types/rest"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/trinhtan/cosmos-hackathon/x/sunchain/types"
+)
+
+const (
+	// eventsQuery selects the txs of the node event bus that went through the sunchain module
+	eventsQuery = "tm.event='Tx' AND message.module='" + types.ModuleName + "'"
+	// eventsSubscriber is the subscriber name the REST server uses on the node event bus
+	eventsSubscriber = "sunchain-rest"
+	// eventsBufferSize is the number of events kept for a slow browser before new ones are dropped
+	eventsBufferSize = 64
+	// eventsHistorySize is the number of latest events kept to be replayed to browsers reconnecting
+	// with a Last-Event-ID
+	eventsHistorySize = 256
+	// eventsKeepAlive is how often a comment is sent on an idle stream so proxies keep it open. It is
+	// kept below the default write timeout of the REST server, in case a proxy applies the same one.
+	eventsKeepAlive = 5 * time.Second
+	// eventsResubscribeMin and eventsResubscribeMax bound the backoff between attempts to subscribe
+	// to the node event bus again once the subscription is lost
+	eventsResubscribeMin = time.Second
+	eventsResubscribeMax = 30 * time.Second
+)
+
+// marketEvent is a product, sell, reservation or order update pushed to browsers
+type marketEvent struct {
+	ID         string            `json:"id"`
+	Type       string            `json:"type"`
+	Height     int64             `json:"height"`
+	TxHash     string            `json:"tx_hash"`
+	Attributes map[string]string `json:"attributes"`
+	// index is the position of the event among the events of its block
+	index int
+}
+
+// eventID returns the id of the event at the given position among the events of a block. Ids are
+// the same whichever REST server the events are read from.
+func eventID(height int64, index int) string {
+	return fmt.Sprintf("%d-%d", height, index)
+}
+
+// parseEventID returns the height and position of an event id returned by eventID
+func parseEventID(id string) (height int64, index int, ok bool) {
+	parts := strings.SplitN(id, "-", 2)
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+	height, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	index, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	return height, index, true
+}
+
+// after returns true if the event comes after the event at the given height and position
+func (event marketEvent) after(height int64, index int) bool {
+	return event.Height > height || (event.Height == height && event.index > index)
+}
+
+// eventFilter selects the events a browser wants. Empty fields match every event.
+type eventFilter struct {
+	ProductID string
+	Owner     string
+	SellID    string
+}
+
+func (filter eventFilter) matches(event marketEvent) bool {
+	return matchAttribute(event, types.AttributeKeyProductID, filter.ProductID) &&
+		matchAttribute(event, types.AttributeKeyOwner, filter.Owner) &&
+		matchAttribute(event, types.AttributeKeySellID, filter.SellID)
+}
+
+func matchAttribute(event marketEvent, key string, value string) bool {
+	return value == "" || event.Attributes[key] == value
+}
+
+// isMarketEventType returns true for the event types of the sunchain module
+func isMarketEventType(eventType string) bool {
+	switch eventType {
+	case types.EventTypeProduct, types.EventTypeSell, types.EventTypeReservation, types.EventTypeOrder:
+		return true
+	}
+	return false
+}
+
+// eventHub holds a single subscription to the node event bus and fans its sunchain events out
+// to the connected browsers. The subscription is made when the first browser connects, and made
+// again when it is lost while browsers are connected.
+type eventHub struct {
+	nodeURI     string
+	mtx         sync.Mutex
+	client      *rpcclient.HTTP
+	subscribers map[chan marketEvent]eventFilter
+	// history holds the latest events, oldest first
+	history []marketEvent
+}
+
+func newEventHub(nodeURI string) *eventHub {
+	return &eventHub{
+		nodeURI:     nodeURI,
+		subscribers: make(map[chan marketEvent]eventFilter),
+	}
+}
+
+// subscribe registers a browser and returns the channel its matching events are sent to. The
+// matching events of the history which come after lastEventID are returned to be sent first.
+func (hub *eventHub) subscribe(filter eventFilter, lastEventID string) (chan marketEvent, []marketEvent, error) {
+	hub.mtx.Lock()
+	defer hub.mtx.Unlock()
+	if err := hub.start(); err != nil {
+		return nil, nil, err
+	}
+	events := make(chan marketEvent, eventsBufferSize)
+	hub.subscribers[events] = filter
+
+	replay := []marketEvent{}
+	if height, index, ok := parseEventID(lastEventID); ok {
+		for _, event := range hub.history {
+			if event.after(height, index) && filter.matches(event) {
+				replay = append(replay, event)
+			}
+		}
+	}
+	return events, replay, nil
+}
+
+// unsubscribe removes a browser registered with subscribe.
+func (hub *eventHub) unsubscribe(events chan marketEvent) {
+	hub.mtx.Lock()
+	defer hub.mtx.Unlock()
+	delete(hub.subscribers, events)
+}
+
+// start subscribes to the node event bus if it is not subscribed yet. It must be called with mtx held.
+func (hub *eventHub) start() error {
+	if hub.client != nil {
+		return nil
+	}
+	if hub.nodeURI == "" {
+		return fmt.Errorf("no node configured on the REST server")
+	}
+	client, err := rpcclient.NewHTTP(hub.nodeURI, "/websocket")
+	if err != nil {
+		return err
+	}
+	if err := client.Start(); err != nil {
+		return err
+	}
+	out, err := client.Subscribe(context.Background(), eventsSubscriber, eventsQuery, eventsBufferSize)
+	if err != nil {
+		client.Stop() // nolint: errcheck
+		return err
+	}
+	hub.client = client
+	go hub.run(client, out)
+	return nil
+}
+
+// resubscribe drops the subscription of the client, whose channel was closed, and subscribes to
+// the node event bus again with backoff. It gives up once no browser is connected, the next one
+// to connect subscribes again.
+func (hub *eventHub) resubscribe(client *rpcclient.HTTP) {
+	hub.mtx.Lock()
+	if hub.client == client {
+		hub.client = nil
+	}
+	hub.mtx.Unlock()
+	client.Stop() // nolint: errcheck
+
+	backoff := eventsResubscribeMin
+	for {
+		hub.mtx.Lock()
+		if len(hub.subscribers) == 0 {
+			hub.mtx.Unlock()
+			return
+		}
+		err := hub.start()
+		hub.mtx.Unlock()
+		if err == nil {
+			return
+		}
+
+		time.Sleep(backoff)
+		if backoff *= 2; backoff > eventsResubscribeMax {
+			backoff = eventsResubscribeMax
+		}
+	}
+}
+
+// run decodes the sunchain events of every tx from the node and publishes them, until the
+// subscription of the client is lost.
+func (hub *eventHub) run(client *rpcclient.HTTP, out <-chan coretypes.ResultEvent) {
+	defer hub.resubscribe(client)
+	for result := range out {
+		data, ok := result.Data.(tmtypes.EventDataTx)
+		if !ok {
+			continue
+		}
+		txHash := fmt.Sprintf("%X", data.Tx.Hash())
+		for _, abciEvent := range data.Result.Events {
+			if !isMarketEventType(abciEvent.Type) {
+				continue
+			}
+			event := marketEvent{
+				Type:       abciEvent.Type,
+				Height:     data.Height,
+				TxHash:     txHash,
+				Attributes: make(map[string]string, len(abciEvent.Attributes)),
+			}
+			for _, attribute := range abciEvent.Attributes {
+				event.Attributes[string(attribute.Key)] = string(attribute.Value)
+			}
+			hub.publish(event)
+		}
+	}
+}
+
+// publish numbers the event, records it in the history and sends it to every browser whose
+// filter matches it, dropping it for browsers that are too slow to keep up.
+func (hub *eventHub) publish(event marketEvent) {
+	hub.mtx.Lock()
+	defer hub.mtx.Unlock()
+
+	if n := len(hub.history); n > 0 && hub.history[n-1].Height == event.Height {
+		event.index = hub.history[n-1].index + 1
+	}
+	event.ID = eventID(event.Height, event.index)
+	hub.history = append(hub.history, event)
+	if len(hub.history) > eventsHistorySize {
+		hub.history = hub.history[len(hub.history)-eventsHistorySize:]
+	}
+
+	for events, filter := range hub.subscribers {
+		if !filter.matches(event) {
+			continue
+		}
+		select {
+		case events <- event:
+		default:
+		}
+	}
+}
+
+// eventsStreamHandler streams the sunchain events as Server-Sent Events, filtered by the
+// product_id, owner and sell_id query parameters. Each event is named after its type and carries
+// its id, so that a browser reconnecting with a Last-Event-ID gets the events it missed which are
+// still in the history. The connection is taken over from the REST server, so that its write
+// timeout doesn't cut the stream, which stays open until the browser leaves.
+func eventsStreamHandler(hub *eventHub) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		hijacker, ok := w.(http.Hijacker)
+		if !ok {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, "streaming is not supported")
+			return
+		}
+
+		query := r.URL.Query()
+		events, replay, err := hub.subscribe(eventFilter{
+			ProductID: query.Get(types.AttributeKeyProductID),
+			Owner:     query.Get(types.AttributeKeyOwner),
+			SellID:    query.Get(types.AttributeKeySellID),
+		}, r.Header.Get("Last-Event-ID"))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusServiceUnavailable, err.Error())
+			return
+		}
+		defer hub.unsubscribe(events)
+
+		conn, buf, err := hijacker.Hijack()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		defer conn.Close()
+		if err := conn.SetDeadline(time.Time{}); err != nil {
+			return
+		}
+
+		// the browser sends nothing more, the read only ends once it leaves
+		left := make(chan struct{})
+		go func() {
+			io.Copy(ioutil.Discard, buf) // nolint: errcheck
+			close(left)
+		}()
+
+		header := w.Header()
+		header.Set("Content-Type", "text/event-stream")
+		header.Set("Cache-Control", "no-cache")
+		header.Set("Connection", "close")
+		fmt.Fprint(buf, "HTTP/1.1 200 OK\r\n")
+		header.Write(buf) // nolint: errcheck
+		fmt.Fprint(buf, "\r\n")
+		for _, event := range replay {
+			writeEvent(buf, event)
+		}
+		if buf.Flush() != nil {
+			return
+		}
+
+		keepAlive := time.NewTicker(eventsKeepAlive)
+		defer keepAlive.Stop()
+		for {
+			select {
+			case <-left:
+				return
+			case <-keepAlive.C:
+				fmt.Fprint(buf, ": keep-alive\n\n")
+			case event := <-events:
+				writeEvent(buf, event)
+			}
+			if buf.Flush() != nil {
+				return
+			}
+		}
+	}
+}
+
+// writeEvent writes the event in the Server-Sent Events format
+func writeEvent(w io.Writer, event marketEvent) {
+	bz, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, bz)
+}
@@ -0,0 +1,37 @@
+package rest
+
+//go:generate statik -src=. -include=openapi.yaml -ns=sunchain -p=spec -f -m
+
+import (
+	"net/http"
+
+	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/rakyll/statik/fs"
+
+	"github.com/trinhtan/cosmos-hackathon/x/sunchain/client/rest/spec"
+)
+
+// openAPIHandler serves the OpenAPI document of the sunchain REST routes.
+func openAPIHandler() http.HandlerFunc {
+	doc, err := OpenAPISpec()
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		w.Header().Set("Content-Type", "application/yaml")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(doc)
+	}
+}
+
+// OpenAPISpec returns the OpenAPI 3 document of the sunchain REST routes, openapi.yaml, which is
+// embedded in the spec package by go generate. Paths are relative to the /sunchain/v1 prefix the
+// module registers its current routes under.
+func OpenAPISpec() ([]byte, error) {
+	files, err := fs.NewWithNamespace(spec.Sunchain)
+	if err != nil {
+		return nil, err
+	}
+	return fs.ReadFile(files, "/openapi.yaml")
+}
@@ -0,0 +1,57 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+	authclient "github.com/cosmos/cosmos-sdk/x/auth/client"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	govrest "github.com/cosmos/cosmos-sdk/x/gov/client/rest"
+
+	"github.com/trinhtan/cosmos-hackathon/x/sunchain/types"
+)
+
+// CategoryChangeProposalReq defines a category change proposal request body.
+type CategoryChangeProposalReq struct {
+	BaseReq rest.BaseReq `json:"base_req"`
+
+	Title       string           `json:"title"`
+	Description string           `json:"description"`
+	Set         []types.Category `json:"set"`
+	Remove      []string         `json:"remove"`
+	Proposer    sdk.AccAddress   `json:"proposer"`
+	Deposit     sdk.Coins        `json:"deposit"`
+}
+
+// ProposalRESTHandler returns a ProposalRESTHandler that exposes the category change REST handler with a given sub-route.
+func ProposalRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "sunchain_category_change",
+		Handler:  postCategoryChangeProposalHandler(cliCtx),
+	}
+}
+
+func postCategoryChangeProposalHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req CategoryChangeProposalReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		content := types.NewCategoryChangeProposal(req.Title, req.Description, req.Set, req.Remove)
+
+		msg := gov.NewMsgSubmitProposal(content, req.Deposit, req.Proposer)
+		if rest.CheckBadRequestError(w, msg.ValidateBasic()) {
+			return
+		}
+
+		authclient.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
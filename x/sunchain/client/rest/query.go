@@ -1,10 +1,9 @@
 package rest
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
-	"os/exec"
-	"strings"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -12,13 +11,13 @@ import (
 	"github.com/cosmos/cosmos-sdk/types/rest"
 	authclient "github.com/cosmos/cosmos-sdk/x/auth/client"
 	"github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
 
 	"github.com/gorilla/mux"
 )
 
 func resolveNameHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
 		vars := mux.Vars(r)
 		paramType := vars[restName]
 
@@ -34,7 +33,6 @@ func resolveNameHandler(cliCtx context.CLIContext, storeName string) http.Handle
 
 func whoIsHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
 		vars := mux.Vars(r)
 		paramType := vars[restName]
 
@@ -50,7 +48,6 @@ func whoIsHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc
 
 func namesHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
 		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/names", storeName), nil)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
@@ -62,7 +59,6 @@ func namesHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc
 
 func getDescriptionHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
 		vars := mux.Vars(r)
 		paramType := vars[restName]
 
@@ -78,7 +74,6 @@ func getDescriptionHandler(cliCtx context.CLIContext, storeName string) http.Han
 
 func getProductHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
 		vars := mux.Vars(r)
 		paramType := vars[restProduct]
 
@@ -94,7 +89,6 @@ func getProductHandler(cliCtx context.CLIContext, storeName string) http.Handler
 
 func productsHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
 		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/products", storeName), nil)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
@@ -104,23 +98,15 @@ func productsHandler(cliCtx context.CLIContext, storeName string) http.HandlerFu
 	}
 }
 
-func productsByOwnerHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+func productsByOwnerHandler(cliCtx context.CLIContext, storeName string, resolve addressResolver) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		vars := mux.Vars(r)
-		name := vars[accName]
-
-		cmd := exec.Command("bccli", "keys", "show", name, "-a")
-
-		stdout, err := cmd.Output()
-		s := string(stdout)
-		t := strings.Trim(s, "\n")
+		addr, status, err := resolve(cliCtx, mux.Vars(r))
 		if err != nil {
-			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			rest.WriteErrorResponse(w, status, err.Error())
 			return
 		}
 
-		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/productsByOwner/%s", storeName, t), nil)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/productsByOwner/%s", storeName, addr), nil)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
 			return
@@ -131,7 +117,6 @@ func productsByOwnerHandler(cliCtx context.CLIContext, storeName string) http.Ha
 
 func getSellHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
 		vars := mux.Vars(r)
 		paramType := vars[restSell]
 
@@ -147,7 +132,6 @@ func getSellHandler(cliCtx context.CLIContext, storeName string) http.HandlerFun
 
 func sellsHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
 		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/sells", storeName), nil)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
@@ -185,7 +169,6 @@ func reservationsHandler(cliCtx context.CLIContext, storeName string) http.Handl
 
 func reservationsBySellIDHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
 		vars := mux.Vars(r)
 		paramType := vars[restSell]
 		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/reservationsBySellID/%s", storeName, paramType), nil)
@@ -197,24 +180,70 @@ func reservationsBySellIDHandler(cliCtx context.CLIContext, storeName string) ht
 	}
 }
 
-func accAddressHandler(cliCtx context.CLIContext) http.HandlerFunc {
+func categoriesHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/categories", storeName), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func getCategoryHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
 		vars := mux.Vars(r)
-		name := vars[accName]
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/category/%s", storeName, vars[restCategory]), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
 
-		cmd := exec.Command("bccli", "keys", "show", name, "-a")
-		stdout, err := cmd.Output()
-		s := string(stdout)
-		t := strings.Trim(s, "\n")
+func productsByCategoryHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/productsByCategory/%s", storeName, vars[restCategory]), nil)
 		if err != nil {
-			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
 			return
 		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// addressResolver returns the account address a request refers to, or the HTTP status and error to reply with.
+type addressResolver func(cliCtx context.CLIContext, vars map[string]string) (sdk.AccAddress, int, error)
 
-		addr, err := sdk.AccAddressFromBech32(t)
+// resolveKeyName looks up the address of the key named in the request in the keyring of the REST server.
+func resolveKeyName(cliCtx context.CLIContext, vars map[string]string) (sdk.AccAddress, int, error) {
+	if cliCtx.Keybase == nil {
+		return nil, http.StatusNotImplemented, errors.New("no keyring configured on the REST server, use the address routes instead")
+	}
+	info, err := cliCtx.Keybase.Get(vars[accName])
+	if err != nil {
+		return nil, http.StatusNotFound, err
+	}
+	return info.GetAddress(), http.StatusOK, nil
+}
+
+// resolveAddress parses the bech32 address given in the request.
+func resolveAddress(_ context.CLIContext, vars map[string]string) (sdk.AccAddress, int, error) {
+	addr, err := sdk.AccAddressFromBech32(vars[accAddress])
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	return addr, http.StatusOK, nil
+}
+
+func accountHandler(cliCtx context.CLIContext, resolve addressResolver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		addr, status, err := resolve(cliCtx, mux.Vars(r))
 		if err != nil {
-			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			rest.WriteErrorResponse(w, status, err.Error())
 			return
 		}
 
@@ -240,29 +269,32 @@ func accAddressHandler(cliCtx context.CLIContext) http.HandlerFunc {
 	}
 }
 
-func queryBalanceHandler(cliCtx context.CLIContext) http.HandlerFunc {
+func balanceHandler(cliCtx context.CLIContext, resolve addressResolver) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		vars := mux.Vars(r)
-		name := vars[accName]
-
-		cmd := exec.Command("bccli", "keys", "show", name, "-a")
-		stdout, err := cmd.Output()
-		s := string(stdout)
-		t := strings.Trim(s, "\n")
+		addr, status, err := resolve(cliCtx, mux.Vars(r))
 		if err != nil {
-			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			rest.WriteErrorResponse(w, status, err.Error())
+			return
+		}
+
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
 			return
 		}
 
-		cmd = exec.Command("bccli", "query", "bank", "balances", t)
-		stdout, err = cmd.Output()
+		bz, err := cliCtx.Codec.MarshalJSON(bank.NewQueryAllBalancesParams(addr))
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		rest.PostProcessResponse(w, cliCtx, string(stdout))
+		res, height, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", bank.QuerierRoute, bank.QueryAllBalances), bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
 	}
 }
 
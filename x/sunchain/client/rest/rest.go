@@ -2,6 +2,7 @@ package rest
 
 import (
 	"fmt"
+	"net/http"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 
@@ -14,45 +15,117 @@ const (
 	restSell        = "sell"
 	restReservation = "reservation"
 	restOwner       = "owner"
+	restCategory    = "category"
 
 	accName    = "name"
 	accAddress = "address"
 )
 
+// pathID returns the id held by the path variable key. The deprecated routes have no id in their
+// path, for them the id of the body is returned.
+func pathID(r *http.Request, key string, bodyID string) string {
+	if id, ok := mux.Vars(r)[key]; ok {
+		return id
+	}
+	return bodyID
+}
+
+// apiVersion is the path prefix of the current version of the sunchain routes
+const apiVersion = "v1"
+
 // RegisterRoutes - Central function to define routes that get registered by the main application
 func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, storeName string) {
+	sr := r.PathPrefix("/" + storeName).Subrouter()
+	sr.Use(corsMiddleware(NewCORSConfigFromViper()))
+	sr.Methods(http.MethodOptions).HandlerFunc(preflightHandler)
+
+	registerV1Routes(cliCtx, sr.PathPrefix("/"+apiVersion).Subrouter(), storeName, newEventHub(cliCtx.NodeURI))
+	registerDeprecatedRoutes(cliCtx, sr, storeName)
+}
+
+// registerV1Routes registers the versioned routes. Entities are addressed by their id in the path,
+// and the actions on them are sub resources.
+func registerV1Routes(cliCtx context.CLIContext, r *mux.Router, storeName string, hub *eventHub) {
+	r.HandleFunc("/openapi.yaml", openAPIHandler()).Methods("GET")
+	r.HandleFunc("/events", eventsStreamHandler(hub)).Methods("GET")
+
+	r.HandleFunc("/tx/sign-bytes", signBytesHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/tx/broadcast", broadcastTxHandler(cliCtx)).Methods("POST")
+
+	r.HandleFunc("/products", productsHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc("/products", createProductHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/products/{%s}", restProduct), getProductHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/products/{%s}", restProduct), updateProductHandler(cliCtx)).Methods("PUT")
+
+	r.HandleFunc("/sells", sellsHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc("/sells", createSellHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/sells/{%s}", restSell), getSellHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/sells/{%s}", restSell), updateSellHandler(cliCtx)).Methods("PUT")
+	r.HandleFunc(fmt.Sprintf("/sells/{%s}", restSell), deleteSellHandler(cliCtx)).Methods("DELETE")
+	r.HandleFunc(fmt.Sprintf("/sells/{%s}/reservations", restSell), reservationsBySellIDHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/sells/{%s}/decision", restSell), decideSellHandler(cliCtx, storeName)).Methods("POST")
+
+	r.HandleFunc("/categories", categoriesHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/categories/{%s}", restCategory), getCategoryHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/categories/{%s}/products", restCategory), productsByCategoryHandler(cliCtx, storeName)).Methods("GET")
+
+	r.HandleFunc("/reservations", reservationsHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc("/reservations", createReservationHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/reservations/{%s}", restReservation), getReservationHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/reservations/{%s}", restReservation), updateReservationHandler(cliCtx)).Methods("PUT")
+	r.HandleFunc(fmt.Sprintf("/reservations/{%s}", restReservation), deleteReservationHandler(cliCtx)).Methods("DELETE")
+	r.HandleFunc(fmt.Sprintf("/reservations/{%s}/payment", restReservation), payReservationHandler(cliCtx)).Methods("POST")
+
+	r.HandleFunc(fmt.Sprintf("/names/{%s}/address", accName), accountHandler(cliCtx, resolveKeyName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/names/{%s}/products", accName), productsByOwnerHandler(cliCtx, storeName, resolveKeyName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/names/{%s}/balance", accName), balanceHandler(cliCtx, resolveKeyName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/names/{%s}/sign", accName), signHandler(cliCtx)).Methods("POST")
+
+	r.HandleFunc(fmt.Sprintf("/accounts/{%s}", accAddress), accountHandler(cliCtx, resolveAddress)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/accounts/{%s}/products", accAddress), productsByOwnerHandler(cliCtx, storeName, resolveAddress)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/accounts/{%s}/balance", accAddress), balanceHandler(cliCtx, resolveAddress)).Methods("GET")
+}
+
+// registerDeprecatedRoutes registers the routes used before v1, where ids of updated entities are
+// read from the body. They are kept for one release and answer with a Deprecation header. Routes
+// added along with v1 are only served under it.
+func registerDeprecatedRoutes(cliCtx context.CLIContext, r *mux.Router, storeName string) {
+	deprecated := deprecatedRoute(storeName)
+
+	r.HandleFunc("/products", deprecated(productsHandler(cliCtx, storeName))).Methods("GET")
+	r.HandleFunc("/products", deprecated(createProductHandler(cliCtx))).Methods("POST")
+	r.HandleFunc("/products", deprecated(updateProductHandler(cliCtx))).Methods("PUT")
+	r.HandleFunc(fmt.Sprintf("/products/{%s}", restProduct), deprecated(getProductHandler(cliCtx, storeName))).Methods("GET")
+
+	r.HandleFunc("/sells", deprecated(sellsHandler(cliCtx, storeName))).Methods("GET")
+	r.HandleFunc("/sells", deprecated(createSellHandler(cliCtx))).Methods("POST")
+	r.HandleFunc("/sells", deprecated(updateSellHandler(cliCtx))).Methods("PUT")
+	r.HandleFunc("/cancelSell", deprecated(deleteSellHandler(cliCtx))).Methods("POST")
+	r.HandleFunc("/sells/decideSell", deprecated(decideSellHandler(cliCtx, storeName))).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/sells/{%s}", restSell), deprecated(getSellHandler(cliCtx, storeName))).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/sells/{%s}/reservations", restSell), deprecated(reservationsBySellIDHandler(cliCtx, storeName))).Methods("GET")
+
+	r.HandleFunc("/reservations", deprecated(reservationsHandler(cliCtx, storeName))).Methods("GET")
+	r.HandleFunc("/reservations", deprecated(createReservationHandler(cliCtx))).Methods("POST")
+	r.HandleFunc("/reservations", deprecated(updateReservationHandler(cliCtx))).Methods("PUT")
+	r.HandleFunc("/reservations", deprecated(deleteReservationHandler(cliCtx))).Methods("DELETE")
+	r.HandleFunc("/reservations/payReservation", deprecated(payReservationHandler(cliCtx))).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/reservations/{%s}", restReservation), deprecated(getReservationHandler(cliCtx, storeName))).Methods("GET")
+
+	r.HandleFunc(fmt.Sprintf("/names/{%s}/address", accName), deprecated(accountHandler(cliCtx, resolveKeyName))).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/names/{%s}/products", accName), deprecated(productsByOwnerHandler(cliCtx, storeName, resolveKeyName))).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/names/{%s}/balance", accName), deprecated(balanceHandler(cliCtx, resolveKeyName))).Methods("GET")
+}
 
-	r.HandleFunc(fmt.Sprintf("/%s/tx/sign", storeName), signTxHandler(cliCtx)).Methods("POST")
-	r.HandleFunc(fmt.Sprintf("/%s/tx/sign", storeName), signTxHandler(cliCtx)).Methods("OPTIONS")
-
-	r.HandleFunc(fmt.Sprintf("/%s/products", storeName), createProductHandler(cliCtx)).Methods("POST")
-	r.HandleFunc(fmt.Sprintf("/%s/products", storeName), createProductHandler(cliCtx)).Methods("OPTIONS")
-
-	r.HandleFunc(fmt.Sprintf("/%s/products", storeName), productsHandler(cliCtx, storeName)).Methods("GET")
-	r.HandleFunc(fmt.Sprintf("/%s/products/{%s}", storeName, restProduct), getProductHandler(cliCtx, storeName)).Methods("GET")
-	r.HandleFunc(fmt.Sprintf("/%s/products", storeName), updateProductHandler(cliCtx)).Methods("PUT")
-	// r.HandleFunc(fmt.Sprintf("/%s/products/decideSell", storeName), changeProductOwnerHandler(cliCtx)).Methods("POST")
-
-	r.HandleFunc(fmt.Sprintf("/%s/sells", storeName), createSellHandler(cliCtx)).Methods("POST")
-	r.HandleFunc(fmt.Sprintf("/%s/sells", storeName), createSellHandler(cliCtx)).Methods("OPTIONS")
-	r.HandleFunc(fmt.Sprintf("/%s/sells", storeName), sellsHandler(cliCtx, storeName)).Methods("GET")
-	r.HandleFunc(fmt.Sprintf("/%s/sells/{%s}", storeName, restSell), getSellHandler(cliCtx, storeName)).Methods("GET")
-	r.HandleFunc(fmt.Sprintf("/%s/sells", storeName), updateSellHandler(cliCtx)).Methods("PUT")
-	r.HandleFunc(fmt.Sprintf("/%s/cancelSell", storeName), deleteSellHandler(cliCtx)).Methods("POST")
-	r.HandleFunc(fmt.Sprintf("/%s/cancelSell", storeName), deleteSellHandler(cliCtx)).Methods("OPTIONS")
-	r.HandleFunc(fmt.Sprintf("/%s/sells", storeName), sellsHandler(cliCtx, storeName)).Methods("GET")
-	r.HandleFunc(fmt.Sprintf("/%s/sells/{%s}/reservations", storeName, restSell), reservationsBySellIDHandler(cliCtx, storeName)).Methods("GET")
-	r.HandleFunc(fmt.Sprintf("/%s/sells/decideSell", storeName), decideSellHandler(cliCtx)).Methods("POST")
-
-	r.HandleFunc(fmt.Sprintf("/%s/reservations", storeName), createReservationHandler(cliCtx)).Methods("POST")
-	r.HandleFunc(fmt.Sprintf("/%s/reservations", storeName), createReservationHandler(cliCtx)).Methods("OPTIONS")
-	r.HandleFunc(fmt.Sprintf("/%s/reservations", storeName), reservationsHandler(cliCtx, storeName)).Methods("GET")
-	r.HandleFunc(fmt.Sprintf("/%s/reservations/{%s}", storeName, restReservation), getSellHandler(cliCtx, storeName)).Methods("GET")
-	r.HandleFunc(fmt.Sprintf("/%s/reservations", storeName), updateReservationHandler(cliCtx)).Methods("PUT")
-	r.HandleFunc(fmt.Sprintf("/%s/reservations", storeName), deleteReservationHandler(cliCtx)).Methods("DELETE")
-	r.HandleFunc(fmt.Sprintf("/%s/reservations/payReservation", storeName), payReservationHandler(cliCtx)).Methods("POST")
-
-	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}/address", storeName, accName), accAddressHandler(cliCtx)).Methods("GET")
-	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}/products", storeName, accName), productsByOwnerHandler(cliCtx, storeName)).Methods("GET")
-	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}/balance", storeName, accName), queryBalanceHandler(cliCtx)).Methods("GET")
+// deprecatedRoute returns a wrapper that marks the responses of a route kept from before v1 as
+// deprecated, with a link to the document of the routes that replace it.
+func deprecatedRoute(storeName string) func(http.HandlerFunc) http.HandlerFunc {
+	link := fmt.Sprintf(`</%s/%s/openapi.yaml>; rel="deprecation"`, storeName, apiVersion)
+	return func(handler http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", "true")
+			w.Header().Set("Link", link)
+			handler(w, r)
+		}
+	}
 }
@@ -0,0 +1,16 @@
+// Code generated by statik. DO NOT EDIT.
+
+package spec
+
+import (
+	"github.com/rakyll/statik/fs"
+)
+
+
+const Sunchain = "sunchain" // static asset namespace
+
+func init() {
+	data := "PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0c\x00	\x00openapi.yamlUT\x05\x00\x01\x80Cm8\xec=\xdbv\xdb8\x92\xef\xfa\x8a:\xdey\xe8\x99\x91,\xc7\x9d\xf8\xac\xf5\x16'\xe9m\xef\xf4%\x1b\xbb\xf7\xa5O\xd6\x07\"K\x12b\x12d\x00\xd0\x96:\x93\x7f\xdfS \xc0\x9b\xc0\x8b$\xc7\xe3d\x9a\x0f\xbe\x90`\xa1P\xf7*\\\x98\xa4(X\xcag\xf0\xfd\xf1\xc9\xf1\xf7#.\x16\xc9l\x04\xa0\xb9\x8ep\x06W\x99\x08V\x8c\x0bx\xf7\xe6\xea\x1a^\xbe\xbd\x1c\x01\x84\xa8\x02\xc9S\xcd\x131\x83\x7f\x8e\x00\x00~f\xf2\x16u\x1a\xb1\x00A&\x99F\x05\xc9\x02\xf4\nA9\x00q\x12f\x11\x82By\x87!\xcc70\x0f\x82\x88\x83D\xa5'\xe6\xa6<\x1e\x19X\xd7\x92	\xc5\x02\x02\xef`\x85	\x88D\x83\xe2K\x01Ll\xf4\x8a\x8b\xe51\\\xafp\x03\x12u&\xe9.d\x82\x9ec\x08W:\xbc^\x8f\xe1~\xc5\x83\x95\xc1!\x888\nm\x80\xa7L)T\xa0\x13\x98\xea\xf5\x94^\x98\xcc7\x1a\xd5\xd8\x00W\xc0D\x08\nEX4\x99\xcb\x84\x85\x01S\xfa\x18^\x190\n\xee\xb9^%\x99\x06f\xdeA	\xc9\xc2\xc0\xd6+\xe4\x12\x92{\x01\x01\x13\xb0bwH\xb7L#0\x9d\x80Ep\xbe\x01\x06\xb7\xb8q42\xb4\xcdi`\x80\xc3T\xb0\x18\xd5\xf4\x13\xfd\xfal\xb0t\xb4Y\xa1\xa3I\xa6\x88\x8c\xb8H$\xc2\xdd3`\x12Ai\x1eE\x8e\xc2\x99\x08Q\xc2\xb4\xa0\xff\"\x91\x90\x08\x04\x89\x112\x85\xe3\xbc'f0\x7f\x8d\xa9\xc4\x80\x19\x92\xaf\x90\x85(\x0du\xb9\x84,\x0d\x19q\x93\xe8\x12b\x84\xf4\xb7D\x16\x1a\xbcQh\xae7\xc0CX\xc8$6\xb7\xe6I\xb8\x19Sk\x03w\x1a0\x11`t\x85Q4\x86\xa9\xc2(R\xd3\x10\x03\x1e\"\xdd\xa2f0\x95H\x08\x9b\xbe\xd54e\x9bw\xe5\xff\xa045Y$\xd2@{\xfd\xe6\xa77\xd7o\x1c\x9cO\x04\xee\xf3\x18\xde\xfezu]\xbfg\xbaP4\x16z;\x7f^\xeb\xe5S\xe5\xbf\xcf\xd4g\x8cB\x1f\x8f\x00\xeeP\xd2{38zv|r4\"DP*R\x87	d2\x9a\x95\xe4\x9c\xde=\x1b\xa5L\xaf\xcc\xc3\xa9\xd5\xa1\xe3\x0d\x8b#\xba\x01\xb0D\x9d\xff\x01\xa0\xb28fr3\x83\xeb\x15'Q\x0e\xb2\xd8I#@\x92\xa24x\\\x863z\xe9\xd7\x14E\xaeeti\xb6T3\xf8\xfdg\xd4\xec\xbd\x85&Q\xa5\x89P\xa8\x1cx\x80\xa3\xd3\x93\x93\xa3\xf2\xdf\x86\x82\x92\xc8X\xa8\xcd\xce\xe9\n\x12\xa1Q\x14\xc8\xe6\x17K\xd3\x88\xe7\xf20-\xc7T^*Xa\xcc\xea\xef\xd0\xa57)\xce@i\xc9\xc5r\x040\xc5;\x14Z\xb5S\xe4JKd1\xc4\x15\xeb\x91\xbf\x02L\xc1\x95\xa1\xfe\xe4\n\x85\x867\xe6\xee\xc83\xbc\x7f\xda\x9b\x00oX\xb0\xca_\x07\xae\x80T'\x04\xb6\xd0(\x81keP\x83\xefR\x99\x84Y\xa0\xc7@\x822\x86\x8a\x1c\x00\xa9\x87\x0cQ\xfe\xd5HM\xc0\xa4\xe4$\xf7\x05\xf8\x9f\x0d\x92\x06\x11\xc2.d\x9a\x1d\xc3\x0f<\xd2(\x95\xd1\xbe \x89\xe7\\`H\xe2\x0f\x18\xa7z\x03\x0b\xf3\x18b\xa6\x83\x15*BNn\xe8\xa7\xd0\xc7\xf5!\x81\xe9pcM\x0870r\x1d\x8bH\xfdtA\x163\x1eR4R\xe5%\xbfC\x01\\\x98\x1b?1\xa5'\x06\xe8\xe4\xf2\xb5\xd5b\xc2\xab\x18\x80\"\xca,\xb8T\x1a\xeeW(\x80\xc1\\&\xf7\n%H\x0c\x12!0\xd0\xca\xa8=1\x90\xd8BTL4\x04QbL\x8d\xc1\xae0S\x92k\x04\xcdcL2\xa3:[\xc2\x9c\x03\xa9\x8d\xd2\x8as~\xcf	t\xca$\x8bQ[=\xcb\xaf	\xfcE\xe2b\x06G\xff1\x0d\x928M\x04\n\xad\xa6e\xcb\xe9\xdb\x9c\x8f\x97\xafs\x06\x1cYX\x03\xde\xfc\xf5^\xa0\xdc\xf9-2W\xbe\xceH\xcafu\xd2\x17\xa8\x00p1\xb3\x8chS\xce\xcb\xd0\xf9\x80\x889.\x13;\x90\xdf\x91 \xe9\x04$\xa6\x11\xcbIoe \xe6\x8a\xf8\xa1\xb8\x08p\xd4\xad\x93\x0d},/\\\xb38%\xff\xfe\xfctr\xb2\xafe1#\xb6|\xee\xb3(\x1a\xd7:7\x07\x93\xfc\x85\xa1&\xc5'\x08\xb9\xf9Q\xd3\x8aJ\x96L9zq\xf2}\xcd\x1a\xfa \x14Vt\xfaF\xca\xc4\xc8O=\x1e\xc8\x01\xa4\x89\xda6Z\xff\x85\xda\xb0\xc3\xb4#\x16\x91\x7f&'U\x0bBt\x19\xc5\x8c<\x8c/\xcd\x16\xd9\xe7\xdcC\xf3\x90T\xcex\xd7\xc2\x9f\x8a$\xc4\\)Y\x10$\x99\xd0 \xb2x\x8e\xd2\x06*\x1f3\x14\x01:\x19\xca\x95\xdb PJ\x1c\x99\xa6\x8f\x19J\x8e\x157\x9dw\x98\x89\x08\x95\x82yB\x81\x80\xb4\xf6\xc4\xab\xccK\xd4W|).h\xc8\xf6\xb9U\xe6J\xb4V\xa8\xb4$\xbc\x94\xbeH\xc2\x8d\xa3^~\x93K\x0cg\xa0e\x86\xa3\x0ei\xa9z\x9f\x0f*\x11%\x8cv\xdf\xd3%&\x05\xe6\xef\xf0\xa3\x93\x93\x9d\xdd\xe8U\x11\xc5\xed\xe2<\xb7\xd1\xdfO\xd2+CP\x15Q\x7f\xdep\xfc>\x10\x1eQ\xb7\xca\xfdb\xbf\xb7kQq\x87\x9e\\\xb86.P\xf6)EM\xca\x8aX\xfbzm\x81}=BV\x8c\xf6z}\x88\x98\xbdC\x95E\xda)tA\x90\nr\x8f&s4\x90\xdc#\xfc\xcb%\xce\x86m\xaa=\xb6\xfe\x89\x93\x9cE\x11\xb8\xa6>\xf9Z\xa2~[\x7fl\xe5\xcb\xdd-\x0dX\xbfu\xe8\xc6\xddA\xac\x91\xee\xf9~\xa4\xf3z\xa1\x8b\x8cG!\xb0\xaaJ\x81^1\x0d\x81D\n\x19\x819R\x8c<b\xe6s?L\x91\x96*#x\xbc\x88K,\x90c\xb84au\xe5\xd6\x0d\x0f\x81i-\xf9<\xd3\xd8h^\x807.\x7fK\x9cA\xaf\xcb\x08\x97\"ijAQ\xba4\xe2\xefuA\xf9\xb8,]m\x83V\xf6=)\xd3\xf0\xaa\x8a\xf9\x8e\xc6\xa1[\xca~\xb3\xd1\xc6\xf5\xba&g\xfb\x99u\xcb:5\xfdd\xff\xfal\x8d\xfbV\x80\xde\x1bd\xdb\xb1^\xbe>jUX\x8a\xa2\x9a\"\xda\xa2\xac}\xcc\xee\xd7\xd5\x9a\x89\xbd\xf6\x88\xe9\x80<x\x17W\xce\xa2\xe8\xd7\xc5\xf6\xed\xb6D\xc3\x05*\xff\x93\xa1\xdcl\x1b\xdd\xf2\x9a\x10\xc1R\x94\x9aW\x87[\xbfr\x15j{\n\x9d\x08X\xda>\x88\xd1\xca\xb6\x8dt\xab\xcd*\xaaL]\x02\x917z[k\xf0u\x18\x80\xdf\xd2\xf0k0\x00\x01\xd3\xb8Ld![>\xb55~\x96\xacv\xd9\xb8a\xfaA\xe2\x92+-7#\x8f\xee\x95~\xe7U\xf9>\xa5\x1e1\x13l\x99W\x19\x96\xc9\x1dJA\x85\xc3\xbcD\xe9Jn7\xb6\xcb\xcdM\xb0bbi:L\x13\xc5\"*\xdef\xf3\x98k\x8da\x01_\xafd\x92-W0]&w\xd3\xa2\xe5\xb4\x0dX[\xd6S\xa2i\x1bX\x89+\xef\xef\x123l\xd9\xa1\x92\x8a\xe3\xbc\xfa\x94\x93\x80\xca\n\xff\x8e\xb6)\xafW0)\xd9fT\x7fR^\\c\\Q\x98\xdd\x0c\x9ce\xdb\xe6p\x0bW\xd1\x96\xe9'\xfb\xf7f\x7f\x8f\xe9\x10\xfb\x85\xc5x\xd4\xaa}\xb9\xd3t\xbdu\x8b\xac{\xfcE\x04v\xf3\xa7\xe7\xac{\xce/-X\x8d\x14h\x8f\x98l\x98\x84\x15\xf6\xdd\xf5G\xd6\x9d\x15l7A\xbb\x8d\xd5U6w\xf79\xaa\x16\xfbi\xdd\xb5\xba\xd8\xb8\xfe\x1fB,\xbbI\xe9\xfa|\x00^\xd04\x81\x9a\xb5\xeac\x91u\x9av-.\xe4\xaa\xf2\xcc*\xa3\xb9u\x90\x1eV;\xfc\xb7\n_\x87\xb8\x08\x91E\x11\x9bG\xd8H\xf5\x1e\xd4\x93\x10\x0b\x0f\x17\xb0]\x93\xfb4\xd3\x95\xcc\x1e\x12\x01\x8aE\xe8\x8b]\xf2t\x99\xb0\xec\x14\xbd'\x98(\x13\xceO7K\xaeN4\xef\xed\xedi\x88\xfd\xc9\xb1*\x99\xd7\x0cK{\xf9\xda\x9fXxM\xca\x9fn\xbd\xee\xd6\x1fL\xcb\xf7\xc9\x86)\xcd\x8a\xb9\xe0q\x16C*y>\xcf\xd3.\x16y~\xfcui\xfcoi\xf8\xd45\x1e\xec\xba\x97\xe1\xfc\xcb\x17\xbd(\xcb*\x132\xd9\xdaf\xb1\xd0\xc5\xa7\xd6y7_\x17\xffv\x9e\xecx<\xbe\xd5\x97\x04UVz\xa8Y\xab\xdd-\xa2\xdfj\xf3n\xad[\xa2\xae\xacXR\x17\x9b\x1e\x06nG\xee\x03\xb2\xc3\xaa\xbfx\x08:W1><\x88\xf1/\xbe\x9a\xed6w\xc1Ee\xc1\x1e\x01\xa49\xee \xc0\xd4\x04<\x15~\xb8rS\x9b\x19,\xd7\x97}i.<\xa9\xd8\xe9\xb5]\xf3\xf6d\xf5\xf10\x17\xba\x8b\x02\xd34`\xb5\xb9OJ\x1aj[W\xd8\xaaz\x94\x86\xd7Z\x98\xbd\x8d\xdb\xc3*\xdd\xae\x99C\xccni9\x1b\xa9\xcf\x82\x96\xac\n\xeb\x9e|*\x94\xe7\x0e\x15|\xeb\xf6\xccO\x9e'\x98IT\x10}\xban\xaa*\xa9\xf5\x95\xa9{\xa7\x17\x95q\xf7g\x19\x95\x1e}\xe6\xb4\xae(u\xc7\xe6\x17\x84~=\xd9J=\xb6qh\x91\x93>I\xf9\xf62\x90\n\x8d\x0f\xb7\xa2;N\xcb\xe5S#n\xd2\xbfH@z$&\xcfC\xdem5\xb2\xd1\x90_h\x9e\x94\xf5\xf8-\x0d\xbf\x12\xeb\xb1{rBSj\xa1d\xf7\x8d\xa8\xca\x1fIQ\xde\xf353\xf2)\xa7'\xedv\xdf\xedH8$\x82\x9eg\x1b\x94\x90\xb2\x8d\xf1\xf9y \x8da\x9f\xea\xd6\xb7_\xf4\xeb\xed^\xc9\x8c\xc7;=\xb9h\xfam\xce\x81\xa7+=\xb5\x0dB,\x0c%*5\xeb\xf4\xf4TMrk\x88MFK\x1b\x90\xec\xde\x81[\xdc\xd0\x96\x0d\xcf~$_\x86\xb5D\xfd2\x07ta\xe6+\xeb\xf6\xdd>:LF\xfe\x81\x95\x99\xd0A1\xcf\x8f\xc8\x97\xabb\xe5\xca\xe1\xdc\xb2\xc3x\x08\x9fk\x97>>\xdb\xe7\xed:\xa3\xeb\x93\x80\x9d\x15\x8cr\xfe\xee\xbe\xb6\xe5l_\x8e\x97\x13y\x8f\xc3\xf2\x82\x92{[\xeb\x87\x9b\x06|H\x16\xceYD\xe5\xc1~]\xb5\x0d\x0f\xd7\xd5\x8b\x1c\xd07\xac\xabv\x84O\x8b\xd1\x14*tx\xf0r?\x81\xdd\x88\xb9?\x8f\xa9\xa7\xc7S\xcb'\x15\xe0\x11\x15wt\xd2\xdb\xf3^|)\x98\xce$\xd2^\x1e\x16\x86\xf4\xcb\xed\xdb5\xf7\x8b\x15nfcq\x05\xbb\x07\x9d\"\xeb\x1c\xa6\x0e\xaf\x1c65)\xdf%zm\x9a\xb3Cu\xe4\x90\xbe\xf7\xf5\x866\x80Q\xd3O6\xe6\xf9\xdc\xee\n\xcdb)\xe1b\x9e\x16\xe3\xf8\xb2\xf6\xf4!\xfd\xd9\xcb\x1c\xc1\xa3\xe1\xaa\xf6\x18!\xcc^L\xf3\x90\xfd\xc0P\xa4\x8f-e\xb4a\xc9\xf8\xe5\x19t8\xbd\xbd\x01\xc7!Z\xb2\xa7\x86\xfa\xd8\xb5W\xd8\xd1\xc7\xa5\"\xb2x4&=\x91\xe0b\x0f\xa6\x96\xcfH\xa0\x9a\x16\xc5\x8a\xce\xe5k\x07\x97B\x89\x99\x0b\xe2\xed=\xdaNLg\x0e\x8c:\xb2\xe3bc\xaf}u\xf2l\xe4w;[[\x83i\xc6\xac\xd9\x7fe\x8em\xa7\xce\xe9\xbd\xe1=\xd7\n\x02u\x04\xb6\xeb\x15;\xe1Qy}8:n\xa1\"e#ul\x82\xfa\x12\xc6\x9dP\xf9\x80\xf7\x18\x15\xaf\xf6r\xe3\x1f\xe8\xe9\xbf\xb2,|\xa7\xbe\xefX\xc4C\xa6\xed1\x1a\x03d\xc1\xeas\xbdwkK\xf6A HT\x9c\xa8gg\xeb\x0d\xc6)\xc6iz~\xba>_m\xfe\xf8\xe3\xfc^.\x17\xe7\xcf\xe5\xd9\x87\xf3\xd5\x8b\xc5\xc9Y\xba^\xdf\xb5 \xd9\x15\x1fY|\xc9\xf8\x01\xe4n\xb4\x8e\xfb\xca\xdc\xb3\xb7\x88v\xb4#z3\xf2D\x84\x17Q\x12\xdc\xda\xf6\x14	\x9a\x86\xc0\xf4\xb8z\x1a\x03_\x98\xf3h\xcc\xf9\x0b\x9d4\xe5B\xe3\xb2\xc8\xcc\xc0-\xe7\x99\xc1I]\xed\xf3\x03	\xea8[\x0d\xbe\xe1a\x0b\xde\xbdl\xac\x9cuP\x07M5\x019\x84\x1a\x06\x82\x0b\x7f-B\xe5Y\x04\xb4!\x90\xcd\x93L\xb7 4\x98e\xd5\xe3\x15\xea\x98\x92%\xd9\x9b\x02\x0d\x97n\x02\xe0\x99o\x98\xb4\xa2\xd6f6\xb0`<*\xf6\x91lE\xf8]\x85\xc6&6}$\xa8\xb8\xfb\xb26\xe8\xc5\xcf=\xae\x97\xf3\x13s\x9a\xd1\xf6YF\x8f\x81\xfb\x95.\xaa\xe0V\x88\x95\x17\xf3\xcaf\xbb\x07\xc3\xcb;\x957\xe9D\xb7s!a\xdf\"\xc2\xae\x05\x84}\xcb\x91\x07,E\xee\xdc\xd0\xd25(K\xf8\xa3\xa6\x13m\xe7D\xc5\x1f\xfe\xc9\x8d\x07\xe6F\x85\x019Gl\x92\xd2\xaa\x166\xb6\x1e\xdb\xb3\x828\xed\xaa\x800\xc1\xfc\xd4\x1d\\S\xfa\xb4A\xfd\xed\xf2)\x99\x7f\xc0\xda\x1e\xdc\xf2\xea\x16\x81\x82\xd5-\xcf\xb6\x0e\xa2\xf2]w,\xca\x8a\xf8n\x0f\x14\x87\xa1I\x97\x0d\x9c\xba\x9at;\x8aZx\xe3\xbf\xd2l\x1e\xf1\xe0\xe6\x16+e\xb9=	3XU\xacG\xcc\xc5\xf8&?\x97\xe6Azw\xe7\xda\xec\x08\xcc\xa6\xa3\xad\ngS\xdbo\xd1\xf0uu\xfa*\xe1\xc2\xc8\x8e\xbd\xe1	\x86<\xfaX\xce\xbd\xfe\x8e\x14\xa7\x14Y\xbc\x07G\xac\x06V^\xd6\xd4\xfc\xef\xb0^\xf3\x10|l\xbdoW\xf7\xabZ\xc0\xef\xed\xdf#\x11?\xda\x10\x7f\x856\xc6\xbfg\nb\x16\"0=j8\x1b\xf8\xf4\xd9\xdc\xb2\x8a8\x1b\xb5*SM\xe8.0X}\x7f\xea4\xa4\x91@5\x93\xa3\x8f)\xca{}~/\xc5\xed\xf2\xc5\xed9\xbeX\xfe\xb1X~H\xd3?\xd2\x8f\xab\x0d\xbb{\xf1\xe1\xf4yxvf\xba!\x8e\x0e#b\x88\"\x89\xc7\xc0b\xf24]44\x0d\x1d\xccV\x12\xe6p:\x9b\x11n\x0d\x02U\xf7\xf3z=p#\x02\xea\x13f\x92e\xd2v\x85\xef\xf0\xe30:\xd0yZcZ\xcf\xc4\xc5\x0d\x0f\xbb\x08A-g\xa3=\xedr\x8cq2\x1b\xf5X[\x87Eo\xc3v\xcb\xea\xb5\xe2>\xab\xe9m\xb8djH\x9b\x1b\x16~\xc8\x94\x8ekF\xb2eL\x0b\xac{\xeaA\xf6(\xbf\xa8'\xb3\xb8\xac\x06\xc0\x1fW{Be\x0f\xdf\xdb\x99\xdb\"\xeb\x1d\xb4j\x93\xfb\x8eW\x14\x8f3:\xb5\xb1\xdaC\xdep\x9e$\x112QM\xfdg\xa3\xd6a\xf8\xa4\xd3\xa6\xe0e\x99\xac\x95#\xf9\xf9\xbd}\xad\xaa\xe6\xaa\xaf\xad\xab~\xf56\xe41[\x0e\xe1fk4\xeeas\x97@YR^R\xb7e6Q\x1cM\xf4\xf8\x98\xbct]\x97\xd8\x98\x8a\xcbl4l@[v\x85\xaa \\,\xbb%\xca5\xec\x11\x0e\xaa\xb5\xccF\xad\xca\xe3\x93\xba\x01P\xad\xc6\x19>\xf4\xb7$\x80\x07\x90#\xe6\xe2-Y\x8c\xa1\x00\x8a\x08\xc8\nr&%\x8a\xa0_\x90\x1b\xee\xfc\x1d.\x90^\xc4\x02\x02U\xc6\x18,8\xed\xcc%\x84B{\xae\xac=\xfd5\x91\xe6\x7f\xe5\x1er\x01\x01\xa1R\xf4Ko\xfe\xec\x19\x8cW\xff\x86\xb9\xe8\x06\xce4\xf4\xfcM.Lh\xeb\xc1\x97\xcax)\xa3\xd3'E\xb3\x940\x1b\xb5ZY\x9f\xa0T\xea\x0b\x03\xa5`@3\xb3\x94qoYI\x0f\x10\x14\"\xd4P\xde\xd0\xa1\xd6\xdd\x16\xbfrZ\xe9l\xd4\xea\xbc|d\x1d\x10+\xf8\x83[[\xb8\x9d\x9b\x9a6\xedCL\x13\xc5\xab\x9b\xa8\xf2\xe3\xd9X\x9c\x88e\xf5\x88\xd9\xea{\x95.|\xa7\xc6Za\xed\xc7\x0f\x05\xd5\xbd\x7fo?\x81\xd9\x9e\x80\xf3\xbe7\xaeo\x16\xd5\xf5\xfaf\xc5\xd4\xaa\x17\x87.\x8fP\x0b\x1cm\xd2n(\xc5\xa2\xb7\x1e\x8ex\xfb\xa8\xfa\xa1\xd9\xa85<\xa9dV\x99\xe4c \xdc\xbb\"\xd2L\xf2\xde\xa1\x01\xc4l\xfd\x13\x8a\xa5^\xcd\xe0\xc5\xb3\xd36\xb9Xi\x9d\x8e\xcdOE\x07^\xf3t\xa1\xe0\xb7w\x97\x8e\xe1\xc6s\x17\xef\x0e\"*\xad\x8e\xd0\x1a%\x1d\x9a\xfe\x7f\xbf\x9fL\xce\xd9d\xf1r\xf2\xc3\xfbOg\xcf?\xff\xe5\xa8]>\xd7\x80\"HB:\xae\xff\xc7\x97\x93\xd3\x17g\xa6\xbb\x1a\"\xae\xfa^\x8d\x97\n\xd7:\x8c\xc0\xb7\xb8\x19\x1b\xbc\xc7y\xbd\xa7\x8b\xce\x8d\xdaI?\x9d\xcf\x9e\xb7P\x81M\xfexO?N&\xe7\xef\xff\xf6\xdd\x8d\xfb\xf3\xef\x7f\xfd\xdb_\x8e\xf6T\x9b\x1c\x8f\xb1\x93\xfd\xb1\xd9\xe6\x18\xb3hl\x82J7,OU\xab\x7f\x18\xa7/\xce\xda\xd8\xf4\xbf\x04\xcd\xf1\xa4P\x1f\xf7i\x858S\x9af\xb2\x15\xd2\x81\xe8\xee\x90\xf5*\xb7\xe8\xe0z:\xdeq\x18\xb3L\xc8:.\xe2\xcc.^\x0d\x8bnkZ\xf1\xec\xf4?G\xbeA\xee\x04\xe4\xf9\xc9yI,\x87\xe8n \xba\xa4\x86\x04\xe6\xef\xdfM\xfc\x0234\xac\x8e\xd9\xfa\xd2\x04\xd0\xf0\xec\xe4\xe0P\xf6\x80\xa0\xba\xc4\xe3\xfbV{T\xa8\xb3]\x05\x99	\xfe13k\\\xd5\xc1\xf9@\x01\xfb\xa86\xad\xdf!\x8b>I\x13\x95Y\xf8\x0e\x06\xef\xc2\xc8\x94\xc9!9u\xc3\xa5\xd3r\x00\xa7\x8a9\x84B\x00\xab\x01\xa7NR\x88\xf0\x0e\xa3\xed\xb3\x99\xea\x00\xfb\xc7TU\x9e\x93\xd3Rl#\xae4\x17\xcb\x1f\x10\x87F\xf0\x8d\xb8\xcay\xc0\x92\xfb\x07$\x8b5\"\x15\x10\x8d\x08\xd9\x0f.\xd8\x90\xc3\xe0\x8d!\x84\\b\xa0\xa3bA\xb5\xa5\xd3&\xb7g\xf4\xd9\x96n\xd1\xdb\xa2V\xf3|\xd7\xd9\xc8\x83Y\xe3\xc4\xddZ\x9fst\xb8\xb8\xb3\x13\xcb\xd3q\x1d\xf2\x05r\xf6,\x89|\xb9EE!\xdd	\x86y\x07\x96GT\x98qBS\xf4\xe9\xc2}\xf7E\x07\x9aZ\x96\xc7#\xef\xe4j\xf7\xb4j\xc3\xcc\x97\x1c\x9e\xf8\xd4\xaba\xee\xe7L\xe1\x8d\xc4\x8f\xa5\xf3\xf2G\xbft\xb9\xb6%b\xfdi\xa3-\x10\xda	\xf6\xc6\x11\x9c\xb3\x91GzJ\x1e\xbd\xa3\xaf.\x04v\xeb\xe3\xfd*\x89\x10b;\xc8\xad\x83\x91\x87\x92\x9b-\x99M\xb0\xe82\x9f\xe0\xa8\x1c\xb4\x06qrG\xfd%\xc0D\xa2W(\x0b~}\xe3\x9cyU\x1cT5\xb8\x9a\xeb\xfa\x1c;\xed\xb8|=.\x8a\x02\xefG\xed\x08\xfb\x90\x1d\x8c\xe8n%\x0e\x87\xcel\xd4a9\x1ai\xd5\xb3\x93\x13\xa5\xd9-V$vO\xba<\n1\x0e\x1e\xa2\xd9\x9e\xb9\x1b\xcb\xbf\xd8\x80\xca\x1d\x7fO\x04!\xbab\xb6~\xd9?\xe5\xe2\xf1\x83d\x93\xe2\xc4\x1efc\n(\xc0\x96\x12s\x03\x93\xb2\xcd\xb8\x18\x8a\xb7JekD\xae\xcc\xe5+\x01\xd4d\xf5U\xf3\xc0\x88=T\x99\xea\x01\xa4\xc7\xe9\x17W\xe2\xc1\xe5\xcc\xc3\xd5\xf70\x92|yRlU\xc7v\xd2\xdf\xca\x993\xbbr\xbbV)\xfc\xa2C\x1c^\x934k\xda:\x06\xe2Coh\xf1\xcb\xd9\xbf|\xb2w\xa2\xc2\xdbi}7QK\xe2^C\xa0\x0d	\xbabU\x9b\x1eh\x9fui\x99\xd6\xe8\xe8\xd2L\xef\xf9\x81o\xa1\xd7\x85b\xd7LZ\xb7\n7\xf2\x87\x96\x89\xcc\x1e\x19\x86r\xa7\xd7\xd6\xd8\xfd\x13T\x9d\x93T\xfb\xd0\xb1\x8f:f\x1dO\xfb\"\x9e\x16\x91\xd8\x1a\x9e\x0fp\x07Y\x9a\xb3\xd6\xde\xc6\xd5\x8f(\xcdF\xad\xf8T\xec\x98^w\xe9\xb5^\xcf\xba\x9c\xd7\x9d\xab<U\xbf\xa9\xd5\xdc\x7f\xe7\xa5\x87\x9d5\xffek9\xd2\xfe\x93\xe6\xd5\xaf/\xcdF\xad}\xfb\x86\xf9/\x9f\xec'\x99\xbf1\x9f*\xebE\xa1Qpx\xc5D\"x\xc0\"\xf8\xef\xab_\x7f\xa9\xa4\x8a\xf9wL\x0b\xca\x0c\xb6\xfe%*]\x82\xb1?\xc2\xd7\x16C\xfbe\xb6\xfc\xcb\xb0\xf9\xde\xf7\xfa\xc7\xdd\x9c\xb5/\xf6Tv\xe0\xefC\xd1\xa3\xa4C\x08J\xde\xf7\xecyQ\xf7f1\x17\x89]\xb8G\xe5\x8aQ\xa7\x1e\xef\xd1A\x01\xc7\xb4\xaf\x7f\xa0\xeaQU\x18v\xd1\xdf8	\xb1W[\x8a\xe9$\xb5\x11\xc1\x18X\xfe\xcb\xccs9\xd9\xa2+\xc4\x05\xa3\xa5\xdb@\xcf\xcd\xfd\xf2\xa3V;\xf2|\xe0\"3\xbd\x1e4k\x12x\x07i\x8b\xfb\xc5\xcb\xd4J\xa5l@\x14*\xd9\xfdM\x94,{\xdbE\xc9r\xc0\xc2\x8c\xd6\xa2W\x83U\xb4t\xe7\x9e	\x8d\xfd\xf3\x93\xd44S\x1d\x0d\xff\x7f\x00PK\x07\x08\x7f\xc0v\xad\x8a\x12\x00\x00\x87z\x00\x00PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\x7f\xc0v\xad\x8a\x12\x00\x00\x87z\x00\x00\x0c\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x00\x00\x00\x00openapi.yamlUT\x05\x00\x01\x80Cm8PK\x05\x06\x00\x00\x00\x00\x01\x00\x01\x00C\x00\x00\x00\xcd\x12\x00\x00\x00\x00"
+		fs.RegisterWithNamespace("sunchain", data)
+	}
+	
\ No newline at end of file
@@ -3,47 +3,37 @@ package rest
 import (
 	"crypto/rand"
 	"fmt"
-	"io/ioutil"
-	"log"
 	"net/http"
-	"os/exec"
+	"strconv"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/keys"
 	"github.com/trinhtan/cosmos-hackathon/x/sunchain/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/cosmos/cosmos-sdk/x/auth"
 	authclient "github.com/cosmos/cosmos-sdk/x/auth/client"
-)
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 
-const (
-	dir = "/home/ngo.van.nghia/Documents/POC/cosmos-hackathon/"
+	"github.com/gorilla/mux"
 )
 
 type createProducteReq struct {
-	BaseReq     rest.BaseReq `json:"base_req"`
-	Title       string       `json:"title"`
-	Description string       `json:"description"`
-	Category    string       `json:"category"`
-	Images      string       `json:"images"`
+	BaseReq     rest.BaseReq             `json:"base_req"`
+	Title       string                   `json:"title"`
+	Description string                   `json:"description"`
+	Category    string                   `json:"category"`
+	Images      []types.ProductImage     `json:"images"`
+	Attributes  []types.ProductAttribute `json:"attributes"`
 }
 
 func createProductHandler(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == http.MethodOptions {
-			w.Header().Set("Access-Control-Allow-Origin", "*")
-			w.Header().Set("Access-Control-Allow-Methods", "POST")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-			w.Header().Set("Access-Control-Max-Age", "3600")
-			w.WriteHeader(http.StatusNoContent)
-			return
-		}
-		// Set CORS headers for the main request.
-		w.Header().Set("Access-Control-Allow-Origin", "*")
 		var req createProducteReq
 
 		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
-			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
 			return
 		}
 
@@ -60,15 +50,8 @@ func createProductHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		b := make([]byte, 16)
-		_, err = rand.Read(b)
-		if err != nil {
-			log.Fatal(err)
-		}
-		productID := fmt.Sprintf("%x-%x-%x-%x-%x",
-			b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
-
-		msg := types.NewMsgCreateProduct(productID, req.Title, req.Description, req.Category, req.Images, addr)
+		metadata := types.NewProductMetadata(req.Title, req.Description, req.Category, req.Images, req.Attributes)
+		msg := types.NewMsgCreateProduct(metadata, addr)
 		err = msg.ValidateBasic()
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -80,21 +63,22 @@ func createProductHandler(cliCtx context.CLIContext) http.HandlerFunc {
 }
 
 type updateProductReq struct {
-	BaseReq     rest.BaseReq `json:"base_req"`
-	ProductID   string       `json:"productID"`
-	Title       string       `json:"title"`
-	Description string       `json:"description"`
-	Category    string       `json:"category"`
-	Images      string       `json:"images"`
+	BaseReq     rest.BaseReq             `json:"base_req"`
+	ProductID   string                   `json:"productID"`
+	Title       string                   `json:"title"`
+	Description string                   `json:"description"`
+	Category    string                   `json:"category"`
+	Images      []types.ProductImage     `json:"images"`
+	Attributes  []types.ProductAttribute `json:"attributes"`
 }
 
 func updateProductHandler(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req updateProductReq
 		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
-			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
 			return
 		}
+		req.ProductID = pathID(r, restProduct, req.ProductID)
 
 		baseReq := req.BaseReq.Sanitize()
 		if !baseReq.ValidateBasic(w) {
@@ -108,7 +92,8 @@ func updateProductHandler(cliCtx context.CLIContext) http.HandlerFunc {
 		}
 
 		// create the message
-		msg := types.NewMsgUpdateProduct(req.ProductID, req.Title, req.Description, req.Category, req.Images, addr)
+		metadata := types.NewProductMetadata(req.Title, req.Description, req.Category, req.Images, req.Attributes)
+		msg := types.NewMsgUpdateProduct(req.ProductID, metadata, addr)
 		err = msg.ValidateBasic()
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -130,7 +115,6 @@ func changeProductOwnerHandler(cliCtx context.CLIContext) http.HandlerFunc {
 
 		var req changeProductOwnerReq
 		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
-			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
 			return
 		}
 
@@ -166,20 +150,9 @@ type createSellReq struct {
 
 func createSellHandler(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == http.MethodOptions {
-			w.Header().Set("Access-Control-Allow-Origin", "*")
-			w.Header().Set("Access-Control-Allow-Methods", "POST")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-			w.Header().Set("Access-Control-Max-Age", "3600")
-			w.WriteHeader(http.StatusNoContent)
-			return
-		}
-		// Set CORS headers for the main request.
-		w.Header().Set("Access-Control-Allow-Origin", "*")
 		var req createSellReq
 
 		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
-			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
 			return
 		}
 
@@ -200,13 +173,11 @@ func createSellHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		b := make([]byte, 16)
-		_, err = rand.Read(b)
+		sellID, err := newID()
 		if err != nil {
-			log.Fatal(err)
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
 		}
-		sellID := fmt.Sprintf("%x-%x-%x-%x-%x",
-			b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
 
 		// create the message
 		msg := types.NewMsgCreateSell(sellID, req.ProductID, addr, coins)
@@ -230,9 +201,9 @@ func updateSellHandler(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req updateSellReq
 		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
-			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
 			return
 		}
+		req.SellID = pathID(r, restSell, req.SellID)
 
 		baseReq := req.BaseReq.Sanitize()
 		if !baseReq.ValidateBasic(w) {
@@ -270,21 +241,11 @@ type deleteSellReq struct {
 
 func deleteSellHandler(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == http.MethodOptions {
-			w.Header().Set("Access-Control-Allow-Origin", "*")
-			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS, PUT, DELETE")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-			w.Header().Set("Access-Control-Max-Age", "3600")
-			w.WriteHeader(http.StatusNoContent)
-			return
-		}
-		// Set CORS headers for the main request.
-		w.Header().Set("Access-Control-Allow-Origin", "*")
 		var req deleteSellReq
 		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
-			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
 			return
 		}
+		req.SellID = pathID(r, restSell, req.SellID)
 
 		baseReq := req.BaseReq.Sanitize()
 		if !baseReq.ValidateBasic(w) {
@@ -317,20 +278,9 @@ type createReservationReq struct {
 
 func createReservationHandler(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == http.MethodOptions {
-			w.Header().Set("Access-Control-Allow-Origin", "*")
-			w.Header().Set("Access-Control-Allow-Methods", "POST")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-			w.Header().Set("Access-Control-Max-Age", "3600")
-			w.WriteHeader(http.StatusNoContent)
-			return
-		}
-		// Set CORS headers for the main request.
-		w.Header().Set("Access-Control-Allow-Origin", "*")
 		var req createReservationReq
 
 		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
-			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
 			return
 		}
 
@@ -351,13 +301,11 @@ func createReservationHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		b := make([]byte, 16)
-		_, err = rand.Read(b)
+		reservationID, err := newID()
 		if err != nil {
-			log.Fatal(err)
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
 		}
-		reservationID := fmt.Sprintf("%x-%x-%x-%x-%x",
-			b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
 
 		// create the message
 		msg := types.NewMsgCreateReservation(reservationID, req.SellID, addr, coins)
@@ -381,9 +329,9 @@ func updateReservationHandler(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req updateReservationReq
 		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
-			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
 			return
 		}
+		req.ReservationID = pathID(r, restReservation, req.ReservationID)
 
 		baseReq := req.BaseReq.Sanitize()
 		if !baseReq.ValidateBasic(w) {
@@ -421,21 +369,11 @@ type deleteReservationReq struct {
 
 func deleteReservationHandler(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == http.MethodOptions {
-			w.Header().Set("Access-Control-Allow-Origin", "*")
-			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS, PUT, DELETE")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-			w.Header().Set("Access-Control-Max-Age", "3600")
-			w.WriteHeader(http.StatusNoContent)
-			return
-		}
-		// Set CORS headers for the main request.
-		w.Header().Set("Access-Control-Allow-Origin", "*")
 		var req deleteReservationReq
 		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
-			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
 			return
 		}
+		req.ReservationID = pathID(r, restReservation, req.ReservationID)
 
 		baseReq := req.BaseReq.Sanitize()
 		if !baseReq.ValidateBasic(w) {
@@ -460,68 +398,167 @@ func deleteReservationHandler(cliCtx context.CLIContext) http.HandlerFunc {
 	}
 }
 
-type signTxReq struct {
-	BaseReq       rest.BaseReq `json:"base_req"`
-	Tx            string       `json:"tx"`
-	Sequence      string       `json:"sequence"`
-	AccountNumber string       `json:"accountNumber"`
+// newID returns a random id for a sell or a reservation
+func newID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
 }
 
-func signTxHandler(cliCtx context.CLIContext) http.HandlerFunc {
+type signBytesReq struct {
+	Tx            auth.StdTx `json:"tx"`
+	Sequence      string     `json:"sequence"`
+	AccountNumber string     `json:"accountNumber"`
+}
+
+type signBytesRes struct {
+	ChainID       string `json:"chain_id"`
+	AccountNumber uint64 `json:"account_number"`
+	Sequence      uint64 `json:"sequence"`
+	SignBytes     string `json:"sign_bytes"`
+}
+
+// signBytesHandler returns the bytes the client has to sign for the given unsigned tx. The chain id
+// is read from the node, and the account number and sequence of the first signer are queried when
+// they are not given.
+func signBytesHandler(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == http.MethodOptions {
-			w.Header().Set("Access-Control-Allow-Origin", "*")
-			w.Header().Set("Access-Control-Allow-Methods", "POST")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-			w.Header().Set("Access-Control-Max-Age", "3600")
-			w.WriteHeader(http.StatusNoContent)
+
+		var req signBytesReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
 			return
 		}
-		// Set CORS headers for the main request.
-		w.Header().Set("Access-Control-Allow-Origin", "*")
 
-		var req signTxReq
-		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
-			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+		signers := req.Tx.GetSigners()
+		if len(signers) == 0 {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "tx has no signer")
 			return
 		}
 
-		baseReq := req.BaseReq.Sanitize()
-		if !baseReq.ValidateBasic(w) {
+		chainID, err := nodeChainID(cliCtx)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
 		}
 
-		filePath := dir + "unsignedTx.json"
-		data := []byte(req.Tx)
+		var accountNumber, sequence uint64
+		if req.AccountNumber == "" || req.Sequence == "" {
+			accGetter := authtypes.NewAccountRetriever(authclient.Codec, cliCtx)
+			accountNumber, sequence, err = accGetter.GetAccountNumberSequence(signers[0])
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+		if req.AccountNumber != "" {
+			accountNumber, err = strconv.ParseUint(req.AccountNumber, 10, 64)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, "invalid account number")
+				return
+			}
+		}
+		if req.Sequence != "" {
+			sequence, err = strconv.ParseUint(req.Sequence, 10, 64)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, "invalid sequence")
+				return
+			}
+		}
+
+		signBytes := auth.StdSignBytes(chainID, accountNumber, sequence, req.Tx.Fee, req.Tx.Msgs, req.Tx.Memo)
+		rest.PostProcessResponseBare(w, cliCtx, signBytesRes{
+			ChainID:       chainID,
+			AccountNumber: accountNumber,
+			Sequence:      sequence,
+			SignBytes:     string(signBytes),
+		})
+	}
+}
 
-		err := ioutil.WriteFile(filePath, data, 0644)
-		if err != nil {
-			panic(err)
+type signReq struct {
+	SignBytes string `json:"sign_bytes"`
+}
+
+// signHandler signs the given sign bytes with the named key of the keyring of the REST server, for
+// clients which don't hold the key of the account they sign for.
+func signHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		if _, status, err := resolveKeyName(cliCtx, vars); err != nil {
+			rest.WriteErrorResponse(w, status, err.Error())
+			return
+		}
+
+		var req signReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+		if req.SignBytes == "" {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "sign bytes must not be empty")
+			return
 		}
 
-		cmd := exec.Command("bccli", "tx", "sign", filePath, "--from", req.BaseReq.From, "--offline", "--chain-id", "band-consumer", "--sequence", req.Sequence, "--account-number", req.AccountNumber)
-		stdout, err := cmd.Output()
+		sig, pubKey, err := cliCtx.Keybase.Sign(vars[accName], keys.DefaultKeyPass, []byte(req.SignBytes))
 		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		rest.PostProcessResponseBare(w, cliCtx, authtypes.NewStdSignature(pubKey, sig))
+	}
+}
+
+type broadcastTxReq struct {
+	Tx   auth.StdTx `json:"tx"`
+	Mode string     `json:"mode"`
+}
+
+// broadcastTxHandler broadcasts a tx signed by the client through the node of the REST server.
+func broadcastTxHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		var req broadcastTxReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		if err := req.Tx.ValidateBasic(); err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		filePath = dir + "signedTx.json"
-		data = []byte(string(stdout))
-		err = ioutil.WriteFile(filePath, data, 0644)
+		txBytes, err := authclient.GetTxEncoder(cliCtx.Codec)(req.Tx)
 		if err != nil {
-			panic(err)
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
 		}
 
-		cmd = exec.Command("bccli", "tx", "broadcast", filePath)
-		stdout, err = cmd.Output()
+		mode := req.Mode
+		if mode == "" {
+			mode = flags.BroadcastSync
+		}
+		res, err := cliCtx.WithBroadcastMode(mode).BroadcastTx(txBytes)
 		if err != nil {
-			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
 		}
 
-		rest.PostProcessResponse(w, cliCtx, string(stdout))
+		rest.PostProcessResponseBare(w, cliCtx, res)
+	}
+}
+
+// nodeChainID returns the chain id of the node the REST server is connected to.
+func nodeChainID(cliCtx context.CLIContext) (string, error) {
+	node, err := cliCtx.GetNode()
+	if err != nil {
+		return "", err
+	}
+	status, err := node.Status()
+	if err != nil {
+		return "", err
 	}
+	return status.NodeInfo.Network, nil
 }
 
 type decideSellReq struct {
@@ -529,24 +566,32 @@ type decideSellReq struct {
 	ReservationID string       `json:"reservationID"`
 }
 
-func decideSellHandler(cliCtx context.CLIContext) http.HandlerFunc {
+// decideSellHandler builds the tx in which the seller accepts a reservation. On the v1 route the
+// reservation must belong to the sell of the path.
+func decideSellHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == http.MethodOptions {
-			w.Header().Set("Access-Control-Allow-Origin", "*")
-			w.Header().Set("Access-Control-Allow-Methods", "POST")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-			w.Header().Set("Access-Control-Max-Age", "3600")
-			w.WriteHeader(http.StatusNoContent)
-			return
-		}
-		// Set CORS headers for the main request.
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		var req deleteReservationReq
+		var req decideSellReq
 		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
-			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
 			return
 		}
 
+		if sellID, ok := mux.Vars(r)[restSell]; ok {
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/reservation/%s", storeName, req.ReservationID), nil)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+				return
+			}
+			var reservation types.Reservation
+			if err := cliCtx.Codec.UnmarshalJSON(res, &reservation); err != nil {
+				rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+			if reservation.SellID != sellID {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, "reservation is not for this sell")
+				return
+			}
+		}
+
 		baseReq := req.BaseReq.Sanitize()
 		if !baseReq.ValidateBasic(w) {
 			return
@@ -573,15 +618,16 @@ func decideSellHandler(cliCtx context.CLIContext) http.HandlerFunc {
 type payReservationReq struct {
 	BaseReq       rest.BaseReq `json:"base_req"`
 	ReservationID string       `json:"reservationID"`
+	MaxAmount     string       `json:"maxAmount"`
 }
 
 func payReservationHandler(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var req deleteReservationReq
+		var req payReservationReq
 		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
-			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
 			return
 		}
+		req.ReservationID = pathID(r, restReservation, req.ReservationID)
 
 		baseReq := req.BaseReq.Sanitize()
 		if !baseReq.ValidateBasic(w) {
@@ -594,8 +640,14 @@ func payReservationHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
+		maxAmount, err := sdk.ParseCoins(req.MaxAmount)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
 		// create the message
-		msg := types.NewMsgPayReservation(req.ReservationID, addr)
+		msg := types.NewMsgPayReservation(req.ReservationID, maxAmount, addr)
 		err = msg.ValidateBasic()
 		if err := msg.ValidateBasic(); err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
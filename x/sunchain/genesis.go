@@ -1,31 +1,123 @@
 package sunchain
 
 import (
+	"fmt"
+
+	"github.com/bandprotocol/bandchain/chain/x/oracle"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/trinhtan/cosmos-hackathon/x/sunchain/types"
 )
 
-// GenesisState is the band-consumer state that must be provided at genesis.
-type GenesisState struct{}
+// GenesisState is the band-consumer state that must be provided at genesis. The product count is
+// kept so that product ids are not made again from the same count. The quote oracle script is the
+// one deployed on BandChain which fiat prices are quoted by, and quotes can't be requested if it
+// is 0. The channel authority is the only address allowed to register channels after genesis, and
+// none can if it is empty.
+type GenesisState struct {
+	Categories          []Category            `json:"categories"`
+	ProductCount        uint64                `json:"product_count"`
+	QuoteOracleScriptID oracle.OracleScriptID `json:"quote_oracle_script_id"`
+	ChannelAuthority    sdk.AccAddress        `json:"channel_authority"`
+	Channels            []SourceChannel       `json:"channels"`
+}
 
 // NewGenesisState creates a new genesis state.
-func NewGenesisState() GenesisState {
-	return GenesisState{}
+func NewGenesisState(
+	categories []Category, productCount uint64, quoteOracleScriptID oracle.OracleScriptID,
+	channelAuthority sdk.AccAddress, channels []SourceChannel,
+) GenesisState {
+	return GenesisState{
+		Categories:          categories,
+		ProductCount:        productCount,
+		QuoteOracleScriptID: quoteOracleScriptID,
+		ChannelAuthority:    channelAuthority,
+		Channels:            channels,
+	}
 }
 
 func ValidateGenesis(data GenesisState) error {
+	if _, err := sortCategories(data.Categories); err != nil {
+		return err
+	}
+	registered := make(map[string]bool, len(data.Channels))
+	for _, channel := range data.Channels {
+		if err := channel.Validate(); err != nil {
+			return err
+		}
+		key := string(types.ChannelStoreKey(channel.ChainName, channel.SourcePort))
+		if registered[key] {
+			return fmt.Errorf("duplicate channel of %s on port %s", channel.ChainName, channel.SourcePort)
+		}
+		registered[key] = true
+	}
 	return nil
 }
 
 // DefaultGenesisState returns the default genesis state.
 func DefaultGenesisState() GenesisState {
-	return GenesisState{}
+	return NewGenesisState(DefaultCategories(), 0, 0, nil, []SourceChannel{})
 }
 
 func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) []abci.ValidatorUpdate {
+	categories, err := sortCategories(data.Categories)
+	if err != nil {
+		panic(err)
+	}
+	for _, category := range categories {
+		if err := k.SetCategory(ctx, category); err != nil {
+			panic(err)
+		}
+	}
+	k.SetProductCount(ctx, data.ProductCount)
+	// products of chains started from genesis have structured metadata from the beginning
+	k.SetProductMetadataMigrated(ctx)
+	k.SetQuoteOracleScriptID(ctx, data.QuoteOracleScriptID)
+	if !data.ChannelAuthority.Empty() {
+		k.SetChannelAuthority(ctx, data.ChannelAuthority)
+	}
+	for _, channel := range data.Channels {
+		k.SetChannel(ctx, channel.ChainName, channel.SourcePort, channel.SourceChannel)
+	}
 	return []abci.ValidatorUpdate{}
 }
 
 func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
-	return GenesisState{}
+	return NewGenesisState(
+		k.GetCategories(ctx), k.GetProductCount(ctx), k.GetQuoteOracleScriptID(ctx),
+		k.GetChannelAuthority(ctx), k.GetChannels(ctx),
+	)
+}
+
+// sortCategories orders the categories so that every parent comes before its children, and fails if
+// a category is invalid, duplicated or has a parent which is not in the list.
+func sortCategories(categories []Category) ([]Category, error) {
+	names := make(map[string]bool, len(categories))
+	for _, category := range categories {
+		if err := category.Validate(); err != nil {
+			return nil, err
+		}
+		if names[category.Name] {
+			return nil, fmt.Errorf("duplicate category %s", category.Name)
+		}
+		names[category.Name] = true
+	}
+
+	sorted := make([]Category, 0, len(categories))
+	added := map[string]bool{"": true}
+	for len(sorted) < len(categories) {
+		progress := false
+		for _, category := range categories {
+			if !added[category.Name] && added[category.Parent] {
+				sorted = append(sorted, category)
+				added[category.Name] = true
+				progress = true
+			}
+		}
+		if !progress {
+			return nil, fmt.Errorf("categories have missing parents or a cycle")
+		}
+	}
+	return sorted, nil
 }
@@ -1,15 +1,10 @@
 package sunchain
 
 import (
-	"encoding/binary"
-	"encoding/hex"
-	"fmt"
-	"strconv"
-	"strings"
-
 	"github.com/bandprotocol/bandchain/chain/x/oracle"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	channel "github.com/cosmos/cosmos-sdk/x/ibc/04-channel"
 	channeltypes "github.com/cosmos/cosmos-sdk/x/ibc/04-channel/types"
 
@@ -43,13 +38,19 @@ func NewHandler(keeper Keeper) sdk.Handler {
 			return handleMsgDeleteReservation(ctx, keeper, msg)
 		case MsgPayReservation:
 			return handleMsgPayReservation(ctx, keeper, msg)
+		case MsgCreateFiatSell:
+			return handleMsgCreateFiatSell(ctx, keeper, msg)
+		case MsgCreateFiatReservation:
+			return handleMsgCreateFiatReservation(ctx, keeper, msg)
+		case MsgRequestQuote:
+			return handleMsgRequestQuote(ctx, keeper, msg)
 		case MsgSetSourceChannel:
 			return handleSetSourceChannel(ctx, msg, keeper)
 
 		case channeltypes.MsgPacket:
 			var responsePacket oracle.OracleResponsePacketData
 			if err := types.ModuleCdc.UnmarshalJSON(msg.GetData(), &responsePacket); err == nil {
-				return handleOracleRespondPacketData(ctx, responsePacket, keeper)
+				return handleOracleRespondPacketData(ctx, msg.Packet, responsePacket, keeper)
 			}
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "cannot unmarshal oracle packet data")
 		default:
@@ -63,127 +64,101 @@ func handleBuyGold(ctx sdk.Context, msg MsgBuyGold, keeper Keeper) (*sdk.Result,
 	if err != nil {
 		return nil, err
 	}
-	// TODO: Set all bandchain parameter here
-	bandChainID := "bandchain"
-	port := "sunchain"
-	oracleScriptID := oracle.OracleScriptID(3)
-	calldata := make([]byte, 8)
-	binary.LittleEndian.PutUint64(calldata, 1000000)
-	askCount := int64(1)
-	minCount := int64(1)
-
-	channelID, err := keeper.GetChannel(ctx, bandChainID, port)
-
-	if err != nil {
-		return nil, sdkerrors.Wrapf(
-			sdkerrors.ErrUnknownRequest,
-			"not found channel to bandchain",
-		)
-	}
-	sourceChannelEnd, found := keeper.ChannelKeeper.GetChannel(ctx, port, channelID)
-	if !found {
-		return nil, sdkerrors.Wrapf(
-			sdkerrors.ErrUnknownRequest,
-			"unknown channel %s port sunchain",
-			channelID,
-		)
-	}
-	destinationPort := sourceChannelEnd.Counterparty.PortID
-	destinationChannel := sourceChannelEnd.Counterparty.ChannelID
-	sequence, found := keeper.ChannelKeeper.GetNextSequenceSend(
-		ctx, port, channelID,
-	)
-	if !found {
-		return nil, sdkerrors.Wrapf(
-			sdkerrors.ErrUnknownRequest,
-			"unknown sequence number for channel %s port oracle",
-			channelID,
-		)
-	}
-	packet := oracle.NewOracleRequestPacketData(
-		fmt.Sprintf("Order:%d", orderID), oracleScriptID, hex.EncodeToString(calldata),
-		askCount, minCount,
-	)
-	err = keeper.ChannelKeeper.SendPacket(ctx, channel.NewPacket(packet.GetBytes(),
-		sequence, port, channelID, destinationPort, destinationChannel,
-		1000000000, // Arbitrarily high timeout for now
-	))
+	err = keeper.RequestGoldPrice(ctx, orderID)
 	if err != nil {
 		return nil, err
 	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Buyer.String()),
+	))
 	return &sdk.Result{Events: ctx.EventManager().Events().ToABCIEvents()}, nil
 }
 
+// handleSetSourceChannel registers a channel to another chain. Only the channel authority set at
+// genesis may register channels, as oracle responses are trusted based on the channel they arrive on.
 func handleSetSourceChannel(ctx sdk.Context, msg MsgSetSourceChannel, keeper Keeper) (*sdk.Result, error) {
+	authority := keeper.GetChannelAuthority(ctx)
+	if authority.Empty() || !authority.Equals(msg.Signer) {
+		return nil, sdkerrors.Wrapf(
+			types.ErrUnauthorizedPermission, "%s is not the channel authority", msg.Signer,
+		)
+	}
 	keeper.SetChannel(ctx, msg.ChainName, msg.SourcePort, msg.SourceChannel)
 	return &sdk.Result{Events: ctx.EventManager().Events().ToABCIEvents()}, nil
 }
 
-func handleOracleRespondPacketData(ctx sdk.Context, packet oracle.OracleResponsePacketData, keeper Keeper) (*sdk.Result, error) {
-	clientID := strings.Split(packet.ClientID, ":")
-	if len(clientID) != 2 {
-		return nil, sdkerrors.Wrapf(types.ErrUnknownClientID, "unknown client id %s", packet.ClientID)
-	}
-	orderID, err := strconv.ParseUint(clientID[1], 10, 64)
-	if err != nil {
-		return nil, err
-	}
-	rawResult, err := hex.DecodeString(packet.Result)
-	if err != nil {
-		return nil, err
-	}
-	result, err := types.DecodeResult(rawResult)
-	if err != nil {
-		return nil, err
-	}
-
-	// Assume multiplier should be 1000000
-	order, err := keeper.GetOrder(ctx, orderID)
+func handleOracleRespondPacketData(
+	ctx sdk.Context, ibcPacket channel.Packet, packet oracle.OracleResponsePacketData, keeper Keeper,
+) (*sdk.Result, error) {
+	err := keeper.HandleOracleResponse(ctx, ibcPacket, packet)
 	if err != nil {
 		return nil, err
 	}
-	// TODO: Calculate collateral percentage
-	goldAmount := order.Amount[0].Amount.Int64() / int64(result.Px)
-	if goldAmount == 0 {
-		escrowAddress := types.GetEscrowAddress()
-		err = keeper.BankKeeper.SendCoins(ctx, escrowAddress, order.Owner, order.Amount)
-		if err != nil {
-			return nil, err
-		}
-		order.Status = types.Completed
-		keeper.SetOrder(ctx, orderID, order)
-	} else {
-		goldToken := sdk.NewCoin("gold", sdk.NewInt(goldAmount))
-		keeper.BankKeeper.AddCoins(ctx, order.Owner, sdk.NewCoins(goldToken))
-		order.Gold = goldToken
-		order.Status = types.Active
-		keeper.SetOrder(ctx, orderID, order)
-	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+	))
 	return &sdk.Result{Events: ctx.EventManager().Events().ToABCIEvents()}, nil
 }
 
-// handleCreateProduct handles a message to set product
+// handleCreateProduct handles a message to set product. The id of the new product is returned as
+// the data of the result and in the product event. The product is put on sale right away when the
+// message has a minimum price.
 func handleMsgCreateProduct(ctx sdk.Context, keeper Keeper, msg MsgCreateProduct) (*sdk.Result, error) {
 
-	key := "Product-" + msg.ProductID
+	metadata := msg.Metadata()
+	category, err := keeper.ValidateProductMetadata(ctx, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	productID := types.NewProductID(keeper.GetNextProductCount(ctx), msg.Signer, metadata)
+	key := "Product-" + productID
 
 	if keeper.IsProductPresent(ctx, key) {
-		return nil, sdkerrors.Wrap(types.ErrProductAlreadyExists, msg.ProductID)
+		return nil, sdkerrors.Wrap(types.ErrProductAlreadyExists, productID)
+	}
+
+	if err := keeper.ChargeListingFee(ctx, msg.Signer, category); err != nil {
+		return nil, err
 	}
 
 	var product = Product{
-		ProductID:   msg.ProductID,
+		ProductID:   productID,
 		Title:       msg.Title,
 		Description: msg.Description,
 		Category:    msg.Category,
 		Images:      msg.Images,
+		Attributes:  msg.Attributes,
 		Owner:       msg.Signer,
 		Selling:     false,
 		SellID:      "",
 	}
 
 	keeper.SetProduct(ctx, key, product)
-	return &sdk.Result{}, nil // return
+	keeper.SetProductCategory(ctx, productID, "", product.Category)
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeProduct,
+			sdk.NewAttribute(types.AttributeKeyAction, types.ActionCreate),
+			sdk.NewAttribute(types.AttributeKeyProductID, product.ProductID),
+			sdk.NewAttribute(types.AttributeKeyOwner, product.Owner.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Signer.String()),
+		),
+	})
+
+	if !msg.MinPrice.Empty() {
+		if _, err := handleMsgCreateSell(ctx, keeper, NewMsgCreateSell(msg.SellID, productID, msg.Signer, msg.MinPrice)); err != nil {
+			return nil, err
+		}
+	}
+	return &sdk.Result{Data: []byte(productID), Events: ctx.EventManager().Events().ToABCIEvents()}, nil
 }
 
 // handleMsgUpdateProduct handles a message to set product
@@ -195,27 +170,50 @@ func handleMsgUpdateProduct(ctx sdk.Context, keeper Keeper, msg MsgUpdateProduct
 		return nil, sdkerrors.Wrap(types.ErrProductDoesNotExist, msg.ProductID)
 	}
 
-	owner, err := keeper.GetProductOwner(ctx, key)
+	newInfo, err := keeper.GetProduct(ctx, key)
 	if err != nil {
 		return &sdk.Result{}, err
 	}
 
-	if !msg.Signer.Equals(owner) {
+	if !msg.Signer.Equals(newInfo.Owner) {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "Incorrect Owner") // If not, throw an error
 	}
 
-	var newInfo = Product{
-		ProductID:   msg.ProductID,
-		Title:       msg.Title,
-		Description: msg.Description,
-		Category:    msg.Category,
-		Images:      msg.Images,
-		Owner:       msg.Signer,
-		Selling:     false,
+	category, err := keeper.ValidateProductUpdate(ctx, newInfo, msg.Metadata())
+	if err != nil {
+		return nil, err
 	}
 
+	// the listing fee is only paid again when the product moves to another category
+	previousCategory := newInfo.Category
+	if previousCategory != msg.Category {
+		if err := keeper.ChargeListingFee(ctx, msg.Signer, category); err != nil {
+			return nil, err
+		}
+	}
+
+	newInfo.Title = msg.Title
+	newInfo.Description = msg.Description
+	newInfo.Category = msg.Category
+	newInfo.Images = msg.Images
+	newInfo.Attributes = msg.Attributes
+
 	keeper.SetProduct(ctx, key, newInfo)
-	return &sdk.Result{}, nil // return
+	keeper.SetProductCategory(ctx, newInfo.ProductID, previousCategory, newInfo.Category)
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeProduct,
+			sdk.NewAttribute(types.AttributeKeyAction, types.ActionUpdate),
+			sdk.NewAttribute(types.AttributeKeyProductID, newInfo.ProductID),
+			sdk.NewAttribute(types.AttributeKeyOwner, newInfo.Owner.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Signer.String()),
+		),
+	})
+	return &sdk.Result{Events: ctx.EventManager().Events().ToABCIEvents()}, nil
 }
 
 // handleMsgCreateSell handles a message to set sell
@@ -253,7 +251,21 @@ func handleMsgCreateSell(ctx sdk.Context, keeper Keeper, msg MsgCreateSell) (*sd
 
 	keeper.SetProduct(ctx, keyProduct, product)
 	keeper.SetSell(ctx, keySell, sell)
-	return &sdk.Result{}, nil // return
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeSell,
+			sdk.NewAttribute(types.AttributeKeyAction, types.ActionCreate),
+			sdk.NewAttribute(types.AttributeKeySellID, sell.SellID),
+			sdk.NewAttribute(types.AttributeKeyProductID, sell.ProductID),
+			sdk.NewAttribute(types.AttributeKeyOwner, sell.Seller.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Signer.String()),
+		),
+	})
+	return &sdk.Result{Events: ctx.EventManager().Events().ToABCIEvents()}, nil
 }
 
 // handleMsgUpdateSell handles a message to update sell
@@ -277,7 +289,21 @@ func handleMsgUpdateSell(ctx sdk.Context, keeper Keeper, msg MsgUpdateSell) (*sd
 	sell.MinPrice = msg.MinPrice
 
 	keeper.SetSell(ctx, key, sell)
-	return &sdk.Result{}, nil // return
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeSell,
+			sdk.NewAttribute(types.AttributeKeyAction, types.ActionUpdate),
+			sdk.NewAttribute(types.AttributeKeySellID, sell.SellID),
+			sdk.NewAttribute(types.AttributeKeyProductID, sell.ProductID),
+			sdk.NewAttribute(types.AttributeKeyOwner, sell.Seller.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Signer.String()),
+		),
+	})
+	return &sdk.Result{Events: ctx.EventManager().Events().ToABCIEvents()}, nil
 }
 
 // Handle a message to delete sell
@@ -324,7 +350,21 @@ func handleMsgDeleteSell(ctx sdk.Context, keeper Keeper, msg MsgDeleteSell) (*sd
 
 	keeper.SetProduct(ctx, keyProduct, product)
 	keeper.DeleteSell(ctx, keySell)
-	return &sdk.Result{}, nil
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeSell,
+			sdk.NewAttribute(types.AttributeKeyAction, types.ActionDelete),
+			sdk.NewAttribute(types.AttributeKeySellID, sell.SellID),
+			sdk.NewAttribute(types.AttributeKeyProductID, sell.ProductID),
+			sdk.NewAttribute(types.AttributeKeyOwner, sell.Seller.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Signer.String()),
+		),
+	})
+	return &sdk.Result{Events: ctx.EventManager().Events().ToABCIEvents()}, nil
 }
 
 // handleMsgCreateReservation handles a message to set reservation
@@ -336,10 +376,15 @@ func handleMsgCreateReservation(ctx sdk.Context, keeper Keeper, msg MsgCreateRes
 		return nil, sdkerrors.Wrap(types.ErrReservationAlreadyExists, msg.ReservationID)
 	}
 
-	if !keeper.IsSellPresent(ctx, "Sell-"+msg.SellID) {
+	sell, err := keeper.GetSell(ctx, "Sell-"+msg.SellID)
+	if err != nil {
 		return nil, sdkerrors.Wrap(types.ErrSellDoesNotExist, msg.SellID)
 	}
 
+	if sell.IsFiatPriced() {
+		return nil, sdkerrors.Wrapf(types.ErrPricingMismatch, "sell %s is priced in %s", msg.SellID, sell.Currency)
+	}
+
 	var reservation = Reservation{
 		ReservationID: msg.ReservationID,
 		SellID:        msg.SellID,
@@ -349,7 +394,23 @@ func handleMsgCreateReservation(ctx sdk.Context, keeper Keeper, msg MsgCreateRes
 	}
 
 	keeper.SetReservation(ctx, key, reservation)
-	return &sdk.Result{}, nil // return
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeReservation,
+			sdk.NewAttribute(types.AttributeKeyAction, types.ActionCreate),
+			sdk.NewAttribute(types.AttributeKeyReservationID, reservation.ReservationID),
+			sdk.NewAttribute(types.AttributeKeySellID, sell.SellID),
+			sdk.NewAttribute(types.AttributeKeyProductID, sell.ProductID),
+			sdk.NewAttribute(types.AttributeKeyOwner, sell.Seller.String()),
+			sdk.NewAttribute(types.AttributeKeyBuyer, reservation.Buyer.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Signer.String()),
+		),
+	})
+	return &sdk.Result{Events: ctx.EventManager().Events().ToABCIEvents()}, nil
 }
 
 // handleMsgUpdateReservation handles a message to set reservation
@@ -370,10 +431,35 @@ func handleMsgUpdateReservation(ctx sdk.Context, keeper Keeper, msg MsgUpdateRes
 		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "Incorrect Owner")
 	}
 
+	if reservation.FiatPrice != 0 {
+		return nil, sdkerrors.Wrapf(types.ErrPricingMismatch, "reservation %s is priced in fiat", msg.ReservationID)
+	}
+
+	sell, err := keeper.GetSell(ctx, "Sell-"+reservation.SellID)
+	if err != nil {
+		return nil, sdkerrors.Wrap(types.ErrSellDoesNotExist, reservation.SellID)
+	}
+
 	reservation.Price = msg.Price
 
 	keeper.SetReservation(ctx, keyReservation, reservation)
-	return &sdk.Result{}, nil // return
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeReservation,
+			sdk.NewAttribute(types.AttributeKeyAction, types.ActionUpdate),
+			sdk.NewAttribute(types.AttributeKeyReservationID, reservation.ReservationID),
+			sdk.NewAttribute(types.AttributeKeySellID, sell.SellID),
+			sdk.NewAttribute(types.AttributeKeyProductID, sell.ProductID),
+			sdk.NewAttribute(types.AttributeKeyOwner, sell.Seller.String()),
+			sdk.NewAttribute(types.AttributeKeyBuyer, reservation.Buyer.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Signer.String()),
+		),
+	})
+	return &sdk.Result{Events: ctx.EventManager().Events().ToABCIEvents()}, nil
 }
 
 // Handle a message to delete reservation
@@ -393,8 +479,29 @@ func handleMsgDeleteReservation(ctx sdk.Context, keeper Keeper, msg MsgDeleteRes
 		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "Incorrect Owner")
 	}
 
+	sell, err := keeper.GetSell(ctx, "Sell-"+reservation.SellID)
+	if err != nil {
+		return nil, sdkerrors.Wrap(types.ErrSellDoesNotExist, reservation.SellID)
+	}
+
 	keeper.DeleteReservation(ctx, keyReservation)
-	return &sdk.Result{}, nil
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeReservation,
+			sdk.NewAttribute(types.AttributeKeyAction, types.ActionDelete),
+			sdk.NewAttribute(types.AttributeKeyReservationID, reservation.ReservationID),
+			sdk.NewAttribute(types.AttributeKeySellID, sell.SellID),
+			sdk.NewAttribute(types.AttributeKeyProductID, sell.ProductID),
+			sdk.NewAttribute(types.AttributeKeyOwner, sell.Seller.String()),
+			sdk.NewAttribute(types.AttributeKeyBuyer, reservation.Buyer.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Signer.String()),
+		),
+	})
+	return &sdk.Result{Events: ctx.EventManager().Events().ToABCIEvents()}, nil
 }
 
 // Handle a message to delete reservation
@@ -420,7 +527,23 @@ func handleMsgDecideSell(ctx sdk.Context, keeper Keeper, msg MsgDecideSell) (*sd
 	reservation.Decide = true
 
 	keeper.SetReservation(ctx, keyReservation, reservation)
-	return &sdk.Result{}, nil
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeReservation,
+			sdk.NewAttribute(types.AttributeKeyAction, types.ActionDecide),
+			sdk.NewAttribute(types.AttributeKeyReservationID, reservation.ReservationID),
+			sdk.NewAttribute(types.AttributeKeySellID, sell.SellID),
+			sdk.NewAttribute(types.AttributeKeyProductID, sell.ProductID),
+			sdk.NewAttribute(types.AttributeKeyOwner, sell.Seller.String()),
+			sdk.NewAttribute(types.AttributeKeyBuyer, reservation.Buyer.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Signer.String()),
+		),
+	})
+	return &sdk.Result{Events: ctx.EventManager().Events().ToABCIEvents()}, nil
 }
 
 // Handle a message to delete reservation
@@ -455,7 +578,36 @@ func handleMsgPayReservation(ctx sdk.Context, keeper Keeper, msg MsgPayReservati
 		return &sdk.Result{}, err
 	}
 
-	err = keeper.BankKeeper.SendCoins(ctx, reservation.Buyer, sell.Seller, reservation.Price)
+	var settlement = Settlement{
+		SellID:        sell.SellID,
+		ReservationID: reservation.ReservationID,
+		ProductID:     sell.ProductID,
+		Seller:        sell.Seller,
+		Buyer:         reservation.Buyer,
+		Amount:        reservation.Price,
+		Height:        ctx.BlockHeight(),
+	}
+
+	if sell.IsFiatPriced() {
+		quote, err := keeper.GetFreshQuote(ctx, sell.Currency, sell.Denom)
+		if err != nil {
+			return nil, err
+		}
+		settlement.Amount = sdk.NewCoins(sdk.NewCoin(sell.Denom, quote.CoinAmount(reservation.FiatPrice)))
+		settlement.Currency = sell.Currency
+		settlement.FiatPrice = reservation.FiatPrice
+		settlement.Quote = &quote
+
+		if msg.MaxAmount.Empty() {
+			return nil, sdkerrors.Wrap(types.ErrPaymentAboveMax, "a maximum amount is required to pay a fiat priced sell")
+		}
+	}
+
+	if !msg.MaxAmount.Empty() && !settlement.Amount.IsAllLTE(msg.MaxAmount) {
+		return nil, sdkerrors.Wrapf(types.ErrPaymentAboveMax, "%s above %s", settlement.Amount, msg.MaxAmount)
+	}
+
+	err = keeper.BankKeeper.SendCoins(ctx, reservation.Buyer, sell.Seller, settlement.Amount)
 	if err != nil {
 		return nil, err
 	}
@@ -488,5 +640,166 @@ func handleMsgPayReservation(ctx sdk.Context, keeper Keeper, msg MsgPayReservati
 
 	keeper.DeleteSell(ctx, keySell)
 	keeper.SetProduct(ctx, keyProduct, product)
-	return &sdk.Result{}, nil
+	keeper.SetSettlement(ctx, settlement)
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeReservation,
+			sdk.NewAttribute(types.AttributeKeyAction, types.ActionPay),
+			sdk.NewAttribute(types.AttributeKeyReservationID, reservation.ReservationID),
+			sdk.NewAttribute(types.AttributeKeySellID, sell.SellID),
+			sdk.NewAttribute(types.AttributeKeyProductID, sell.ProductID),
+			sdk.NewAttribute(types.AttributeKeyOwner, sell.Seller.String()),
+			sdk.NewAttribute(types.AttributeKeyBuyer, reservation.Buyer.String()),
+		),
+		sdk.NewEvent(
+			types.EventTypeProduct,
+			sdk.NewAttribute(types.AttributeKeyAction, types.ActionTransfer),
+			sdk.NewAttribute(types.AttributeKeyProductID, product.ProductID),
+			sdk.NewAttribute(types.AttributeKeyOwner, product.Owner.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Signer.String()),
+		),
+	})
+	return &sdk.Result{Events: ctx.EventManager().Events().ToABCIEvents()}, nil
+}
+
+// handleMsgCreateFiatSell handles a message to set sell priced in a reference currency
+func handleMsgCreateFiatSell(ctx sdk.Context, keeper Keeper, msg MsgCreateFiatSell) (*sdk.Result, error) {
+
+	keySell := "Sell-" + msg.SellID
+	keyProduct := "Product-" + msg.ProductID
+
+	if keeper.IsSellPresent(ctx, keySell) {
+		return nil, sdkerrors.Wrap(types.ErrSellAlreadyExists, msg.SellID)
+	}
+
+	if !keeper.IsProductPresent(ctx, keyProduct) {
+		return nil, sdkerrors.Wrap(types.ErrProductDoesNotExist, msg.ProductID)
+	}
+
+	product, err := keeper.GetProduct(ctx, keyProduct)
+	if err != nil {
+		return &sdk.Result{}, err
+	}
+
+	if !msg.Signer.Equals(product.Owner) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "Incorrect Owner")
+	}
+
+	var sell = Sell{
+		SellID:       msg.SellID,
+		ProductID:    msg.ProductID,
+		Seller:       msg.Signer,
+		Currency:     msg.Currency,
+		FiatMinPrice: msg.FiatMinPrice,
+		Denom:        msg.Denom,
+	}
+
+	product.Selling = true
+	product.SellID = msg.SellID
+
+	keeper.SetProduct(ctx, keyProduct, product)
+	keeper.SetSell(ctx, keySell, sell)
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeSell,
+			sdk.NewAttribute(types.AttributeKeyAction, types.ActionCreate),
+			sdk.NewAttribute(types.AttributeKeySellID, sell.SellID),
+			sdk.NewAttribute(types.AttributeKeyProductID, sell.ProductID),
+			sdk.NewAttribute(types.AttributeKeyOwner, sell.Seller.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Signer.String()),
+		),
+	})
+	return &sdk.Result{Events: ctx.EventManager().Events().ToABCIEvents()}, nil
+}
+
+// handleMsgCreateFiatReservation handles a message to set reservation on a sell priced in a reference currency
+func handleMsgCreateFiatReservation(ctx sdk.Context, keeper Keeper, msg MsgCreateFiatReservation) (*sdk.Result, error) {
+
+	key := "Reservation-" + msg.ReservationID
+
+	if keeper.IsReservationPresent(ctx, key) {
+		return nil, sdkerrors.Wrap(types.ErrReservationAlreadyExists, msg.ReservationID)
+	}
+
+	sell, err := keeper.GetSell(ctx, "Sell-"+msg.SellID)
+	if err != nil {
+		return nil, sdkerrors.Wrap(types.ErrSellDoesNotExist, msg.SellID)
+	}
+
+	if !sell.IsFiatPriced() {
+		return nil, sdkerrors.Wrapf(types.ErrPricingMismatch, "sell %s is priced in coins", msg.SellID)
+	}
+
+	if msg.FiatPrice < sell.FiatMinPrice {
+		return nil, sdkerrors.Wrapf(types.ErrPriceTooLow, "%d%s", sell.FiatMinPrice, sell.Currency)
+	}
+
+	var reservation = Reservation{
+		ReservationID: msg.ReservationID,
+		SellID:        msg.SellID,
+		Buyer:         msg.Signer,
+		FiatPrice:     msg.FiatPrice,
+		Decide:        false,
+	}
+
+	keeper.SetReservation(ctx, key, reservation)
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeReservation,
+			sdk.NewAttribute(types.AttributeKeyAction, types.ActionCreate),
+			sdk.NewAttribute(types.AttributeKeyReservationID, reservation.ReservationID),
+			sdk.NewAttribute(types.AttributeKeySellID, sell.SellID),
+			sdk.NewAttribute(types.AttributeKeyProductID, sell.ProductID),
+			sdk.NewAttribute(types.AttributeKeyOwner, sell.Seller.String()),
+			sdk.NewAttribute(types.AttributeKeyBuyer, reservation.Buyer.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Signer.String()),
+		),
+	})
+	return &sdk.Result{Events: ctx.EventManager().Events().ToABCIEvents()}, nil
+}
+
+func handleMsgRequestQuote(ctx sdk.Context, keeper Keeper, msg MsgRequestQuote) (*sdk.Result, error) {
+	err := keeper.RequestQuote(ctx, msg.Currency, msg.Denom)
+	if err != nil {
+		return nil, err
+	}
+	return &sdk.Result{Events: ctx.EventManager().Events().ToABCIEvents()}, nil
+}
+
+// NewCategoryChangeProposalHandler creates the gov handler of category change proposals.
+func NewCategoryChangeProposalHandler(keeper Keeper) govtypes.Handler {
+	return func(ctx sdk.Context, content govtypes.Content) error {
+		switch c := content.(type) {
+		case types.CategoryChangeProposal:
+			return handleCategoryChangeProposal(ctx, keeper, c)
+		default:
+			return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized %s proposal content type: %T", ModuleName, c)
+		}
+	}
+}
+
+func handleCategoryChangeProposal(ctx sdk.Context, keeper Keeper, proposal types.CategoryChangeProposal) error {
+	for _, category := range proposal.Set {
+		if err := keeper.SetCategory(ctx, category); err != nil {
+			return err
+		}
+	}
+	for _, name := range proposal.Remove {
+		if err := keeper.RemoveCategory(ctx, name); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -0,0 +1,422 @@
+package sunchain
+
+import (
+	"testing"
+
+	"github.com/bandprotocol/bandchain/chain/x/oracle"
+	oracletypes "github.com/bandprotocol/bandchain/chain/x/oracle/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	channel "github.com/cosmos/cosmos-sdk/x/ibc/04-channel"
+	channelexported "github.com/cosmos/cosmos-sdk/x/ibc/04-channel/exported"
+	channeltypes "github.com/cosmos/cosmos-sdk/x/ibc/04-channel/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/trinhtan/cosmos-hackathon/x/sunchain/types"
+)
+
+const (
+	oracleChannel     = "sunoraclechan"
+	bandOraclePort    = "oracle"
+	bandOracleChannel = "bandoraclechan"
+)
+
+// mockChannelKeeper knows the oracle channel to BandChain and records the packets sent on it
+type mockChannelKeeper struct {
+	sent []channelexported.PacketI
+}
+
+func (m *mockChannelKeeper) GetChannel(ctx sdk.Context, srcPort, srcChan string) (channel.Channel, bool) {
+	if srcPort != types.OraclePort || srcChan != oracleChannel {
+		return channel.Channel{}, false
+	}
+	return channel.Channel{Counterparty: channeltypes.NewCounterparty(bandOraclePort, bandOracleChannel)}, true
+}
+
+func (m *mockChannelKeeper) GetNextSequenceSend(ctx sdk.Context, portID, channelID string) (uint64, bool) {
+	return uint64(len(m.sent) + 1), true
+}
+
+func (m *mockChannelKeeper) SendPacket(ctx sdk.Context, packet channelexported.PacketI) error {
+	m.sent = append(m.sent, packet)
+	return nil
+}
+
+func (m *mockChannelKeeper) PacketExecuted(ctx sdk.Context, packet channelexported.PacketI, acknowledgement []byte) error {
+	return nil
+}
+
+func (m *mockChannelKeeper) ChanCloseInit(ctx sdk.Context, portID, channelID string) error {
+	return nil
+}
+
+func (m *mockChannelKeeper) TimeoutExecuted(ctx sdk.Context, packet channelexported.PacketI) error {
+	return nil
+}
+
+func createTestInput(t *testing.T) (sdk.Context, Keeper, *mockChannelKeeper) {
+	key := sdk.NewKVStoreKey(StoreKey)
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+
+	channelKeeper := &mockChannelKeeper{}
+	keeper := NewKeeper(cdc, key, nil, channelKeeper)
+	ctx := sdk.NewContext(ms, abci.Header{Height: 1}, false, log.NewNopLogger())
+	return ctx, keeper, channelKeeper
+}
+
+func newAddress() sdk.AccAddress {
+	return sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+}
+
+func newOracleResponseMsg(t *testing.T, srcPort, srcChannel, dstPort, dstChannel string,
+	response oracle.OracleResponsePacketData,
+) channeltypes.MsgPacket {
+	data, err := types.ModuleCdc.MarshalJSON(response)
+	require.NoError(t, err)
+	return channeltypes.MsgPacket{
+		Packet: channeltypes.NewPacket(data, 1, srcPort, srcChannel, dstPort, dstChannel, types.OracleRequestTimeout),
+	}
+}
+
+func TestSetSourceChannelRequiresAuthority(t *testing.T) {
+	ctx, keeper, _ := createTestInput(t)
+	handler := NewHandler(keeper)
+	authority := newAddress()
+
+	// nobody can register channels when there is no authority
+	msg := NewMsgSetSourceChannel(types.BandChainName, types.OraclePort, oracleChannel, authority)
+	_, err := handler(ctx, msg)
+	require.True(t, types.ErrUnauthorizedPermission.Is(err), err)
+	require.False(t, keeper.HasChannel(ctx, types.BandChainName, types.OraclePort))
+
+	keeper.SetChannelAuthority(ctx, authority)
+
+	msg = NewMsgSetSourceChannel(types.BandChainName, types.OraclePort, oracleChannel, newAddress())
+	_, err = handler(ctx, msg)
+	require.True(t, types.ErrUnauthorizedPermission.Is(err), err)
+	require.False(t, keeper.HasChannel(ctx, types.BandChainName, types.OraclePort))
+
+	msg = NewMsgSetSourceChannel(types.BandChainName, types.OraclePort, oracleChannel, authority)
+	_, err = handler(ctx, msg)
+	require.NoError(t, err)
+	channelID, err := keeper.GetChannel(ctx, types.BandChainName, types.OraclePort)
+	require.NoError(t, err)
+	require.Equal(t, oracleChannel, channelID)
+}
+
+func TestSetSourceChannelValidateBasic(t *testing.T) {
+	signer := newAddress()
+	require.NoError(t, NewMsgSetSourceChannel(types.BandChainName, types.OraclePort, oracleChannel, signer).ValidateBasic())
+	require.Error(t, NewMsgSetSourceChannel("", types.OraclePort, oracleChannel, signer).ValidateBasic())
+	require.Error(t, NewMsgSetSourceChannel(types.BandChainName, "bank", oracleChannel, signer).ValidateBasic())
+	require.Error(t, NewMsgSetSourceChannel(types.BandChainName, types.OraclePort, "x", signer).ValidateBasic())
+	require.Error(t, NewMsgSetSourceChannel(types.BandChainName, types.OraclePort, oracleChannel, nil).ValidateBasic())
+}
+
+func TestOracleResponseRejectedFromUnregisteredChannel(t *testing.T) {
+	ctx, keeper, _ := createTestInput(t)
+	handler := NewHandler(keeper)
+	clientID := types.OrderClientID(1)
+	keeper.SetPendingRequest(ctx, types.NewPendingRequest(
+		clientID, types.GoldOracleScriptID, nil, types.OrderOracleCallback, oracleChannel, 1, ctx.BlockHeight(),
+	))
+	response := oracle.OracleResponsePacketData{ClientID: clientID, RequestID: 1}
+
+	// no channel to bandchain is registered yet
+	msg := newOracleResponseMsg(t, bandOraclePort, bandOracleChannel, types.OraclePort, oracleChannel, response)
+	_, err := handler(ctx, msg)
+	require.True(t, types.ErrInvalidPacketSource.Is(err), err)
+
+	keeper.SetChannel(ctx, types.BandChainName, types.OraclePort, oracleChannel)
+
+	for _, msg := range []channeltypes.MsgPacket{
+		newOracleResponseMsg(t, bandOraclePort, bandOracleChannel, types.OraclePort, "otherchannel", response),
+		newOracleResponseMsg(t, bandOraclePort, bandOracleChannel, "transfer", oracleChannel, response),
+		newOracleResponseMsg(t, bandOraclePort, "forgedchannel", types.OraclePort, oracleChannel, response),
+		newOracleResponseMsg(t, "forged", bandOracleChannel, types.OraclePort, oracleChannel, response),
+	} {
+		_, err := handler(ctx, msg)
+		require.True(t, types.ErrInvalidPacketSource.Is(err), err)
+	}
+
+	require.True(t, keeper.HasPendingRequest(ctx, clientID))
+	require.False(t, keeper.IsRequestResolved(ctx, response.RequestID))
+}
+
+func TestGenesisChannels(t *testing.T) {
+	ctx, keeper, _ := createTestInput(t)
+	authority := newAddress()
+	genesis := NewGenesisState(DefaultCategories(), 0, 0, authority, []SourceChannel{
+		NewSourceChannel(types.BandChainName, types.OraclePort, oracleChannel),
+		NewSourceChannel("band-cosmoshub", types.TransferPort, "transferchan"),
+	})
+	require.NoError(t, ValidateGenesis(genesis))
+	InitGenesis(ctx, keeper, genesis)
+
+	exported := ExportGenesis(ctx, keeper)
+	require.Equal(t, authority, exported.ChannelAuthority)
+	require.ElementsMatch(t, genesis.Channels, exported.Channels)
+
+	genesis.Channels = append(genesis.Channels, genesis.Channels[0])
+	require.Error(t, ValidateGenesis(genesis))
+}
+
+func TestGenesisProductCount(t *testing.T) {
+	ctx, keeper, _ := createTestInput(t)
+	keeper.GetNextProductCount(ctx)
+	keeper.GetNextProductCount(ctx)
+
+	genesis := ExportGenesis(ctx, keeper)
+	require.Equal(t, uint64(2), genesis.ProductCount)
+
+	ctx, keeper, _ = createTestInput(t)
+	InitGenesis(ctx, keeper, genesis)
+	require.Equal(t, uint64(3), keeper.GetNextProductCount(ctx))
+}
+
+func TestCreateProductOnSale(t *testing.T) {
+	ctx, keeper, _ := createTestInput(t)
+	handler := NewHandler(keeper)
+	require.NoError(t, keeper.SetCategory(ctx, NewCategory("other", "", "", nil, nil)))
+	owner := newAddress()
+
+	msg := NewMsgCreateProduct(ProductMetadata{Title: "ring", Category: "other"}, owner)
+	msg.SellID = "sell-1"
+	require.Error(t, msg.ValidateBasic())
+	msg.MinPrice = sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+	require.NoError(t, msg.ValidateBasic())
+
+	res, err := handler(ctx, msg)
+	require.NoError(t, err)
+	sell, err := keeper.GetSell(ctx, "Sell-sell-1")
+	require.NoError(t, err)
+	require.Equal(t, msg.MinPrice, sell.MinPrice)
+	require.True(t, hasEvent(res.Events, EventTypeProduct, AttributeKeyProductID, sell.ProductID))
+
+	product, err := keeper.GetProduct(ctx, "Product-"+sell.ProductID)
+	require.NoError(t, err)
+	require.True(t, product.Selling)
+	require.Equal(t, "sell-1", product.SellID)
+}
+
+func TestUpdateMigratedProduct(t *testing.T) {
+	ctx, keeper, _ := createTestInput(t)
+	handler := NewHandler(keeper)
+	// the bank keeper is nil, so the update would panic if the listing fee was charged again
+	require.NoError(t, keeper.SetCategory(ctx, NewCategory("jewelry", "", "", sdk.NewCoins(sdk.NewInt64Coin("stake", 10)), nil)))
+	owner := newAddress()
+	migrated := ProductImage{URI: "https://a.io/1.png"}
+	keeper.SetProduct(ctx, "Product-1", Product{
+		ProductID: "1", Title: "ring", Category: "jewelry", Images: []ProductImage{migrated}, Owner: owner,
+	})
+	keeper.SetProductCategory(ctx, "1", "", "jewelry")
+
+	msg := NewMsgUpdateProduct("1", ProductMetadata{
+		Title: "gold ring", Category: "jewelry", Images: []ProductImage{migrated},
+	}, owner)
+	require.NoError(t, msg.ValidateBasic())
+	_, err := handler(ctx, msg)
+	require.NoError(t, err)
+	product, err := keeper.GetProduct(ctx, "Product-1")
+	require.NoError(t, err)
+	require.Equal(t, "gold ring", product.Title)
+
+	// only the images the product was migrated with may have no hash
+	msg.Images = append(msg.Images, ProductImage{URI: "https://a.io/2.png"})
+	require.NoError(t, msg.ValidateBasic())
+	_, err = handler(ctx, msg)
+	require.Error(t, err)
+	require.Error(t, NewMsgCreateProduct(msg.Metadata(), owner).ValidateBasic())
+}
+
+func TestPricesRecordedInTheSameBlock(t *testing.T) {
+	ctx, keeper, _ := createTestInput(t)
+	keeper.SetPrice(ctx, types.NewPrice(100, 1, 10, 1000))
+	keeper.SetPrice(ctx, types.NewPrice(200, 2, 10, 1000))
+	keeper.SetPrice(ctx, types.NewPrice(300, 3, 11, 1010))
+	keeper.SetPrice(ctx, types.NewPrice(400, 4, 12, 1020))
+
+	prices := keeper.GetPrices(ctx, 1000, 1010, types.MaxPricesPerQuery)
+	require.Len(t, prices, 3)
+	require.Equal(t, uint64(100), prices[0].Px)
+	require.Equal(t, uint64(200), prices[1].Px)
+	require.Equal(t, uint64(300), prices[2].Px)
+
+	prices = keeper.GetPrices(ctx, 0, 2000, 2)
+	require.Len(t, prices, 2)
+	require.Equal(t, uint64(300), prices[0].Px)
+	require.Equal(t, uint64(400), prices[1].Px)
+
+	latest, err := keeper.GetLatestPrice(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(400), latest.Px)
+}
+
+func TestOracleCallbackFailureClearsPendingRequest(t *testing.T) {
+	ctx, keeper, _ := createTestInput(t)
+	handler := NewHandler(keeper)
+	keeper.SetChannel(ctx, types.BandChainName, types.OraclePort, oracleChannel)
+	keeper.SetQuoteOracleScriptID(ctx, 4)
+
+	require.NoError(t, keeper.RequestQuote(ctx, "USD", "stake"))
+	clientID := types.QuoteClientID("USD", "stake", ctx.BlockHeight())
+	require.True(t, keeper.HasPendingRequest(ctx, clientID))
+
+	// a result which is not hex makes the quote callback fail
+	response := oracle.OracleResponsePacketData{
+		ClientID: clientID, RequestID: 7, ResolveStatus: oracletypes.Success, Result: "not hex",
+	}
+	msg := newOracleResponseMsg(t, bandOraclePort, bandOracleChannel, types.OraclePort, oracleChannel, response)
+	res, err := handler(ctx, msg)
+	require.NoError(t, err)
+	require.False(t, keeper.HasPendingRequest(ctx, clientID))
+	require.True(t, keeper.IsRequestResolved(ctx, response.RequestID))
+	require.True(t, hasEvent(res.Events, types.EventTypeOracleRequest, types.AttributeKeyAction, types.ActionFail))
+	_, err = keeper.GetQuote(ctx, "USD", "stake")
+	require.Error(t, err)
+
+	// the quote can be requested again
+	require.NoError(t, keeper.RequestQuote(ctx, "USD", "stake"))
+}
+
+func TestPendingRequestsExpire(t *testing.T) {
+	ctx, keeper, _ := createTestInput(t)
+	keeper.SetChannel(ctx, types.BandChainName, types.OraclePort, oracleChannel)
+	keeper.SetQuoteOracleScriptID(ctx, 4)
+	clientID := types.QuoteClientID("USD", "stake", ctx.BlockHeight())
+
+	require.NoError(t, keeper.RequestQuote(ctx, "USD", "stake"))
+	require.True(t, types.ErrItemDuplication.Is(keeper.RequestQuote(ctx, "USD", "stake")))
+
+	// an expired request is replaced by a new one
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + types.OracleRequestExpiry + 1)
+	require.NoError(t, keeper.RequestQuote(ctx, "USD", "stake"))
+	require.False(t, keeper.HasPendingRequest(ctx, clientID))
+	clientID = types.QuoteClientID("USD", "stake", ctx.BlockHeight())
+	request, err := keeper.GetPendingRequest(ctx, clientID)
+	require.NoError(t, err)
+	require.Equal(t, ctx.BlockHeight(), request.RequestHeight)
+
+	// and cleaned up at the end of the block otherwise
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + types.OracleRequestExpiry)
+	keeper.ExpirePendingRequests(ctx)
+	require.True(t, keeper.HasPendingRequest(ctx, clientID))
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	keeper.ExpirePendingRequests(ctx)
+	require.False(t, keeper.HasPendingRequest(ctx, clientID))
+}
+
+func TestQuotesRequireAnOracleScript(t *testing.T) {
+	ctx, keeper, _ := createTestInput(t)
+	keeper.SetChannel(ctx, types.BandChainName, types.OraclePort, oracleChannel)
+	InitGenesis(ctx, keeper, DefaultGenesisState())
+	require.True(t, types.ErrOracleScriptNotSet.Is(keeper.RequestQuote(ctx, "USD", "stake")))
+
+	genesis := DefaultGenesisState()
+	genesis.QuoteOracleScriptID = 4
+	InitGenesis(ctx, keeper, genesis)
+	require.NoError(t, keeper.RequestQuote(ctx, "USD", "stake"))
+	require.Equal(t, genesis.QuoteOracleScriptID, ExportGenesis(ctx, keeper).QuoteOracleScriptID)
+}
+
+func TestLateQuoteResponseDoesNotAnswerNewRequest(t *testing.T) {
+	ctx, keeper, _ := createTestInput(t)
+	handler := NewHandler(keeper)
+	keeper.SetChannel(ctx, types.BandChainName, types.OraclePort, oracleChannel)
+	keeper.SetQuoteOracleScriptID(ctx, 4)
+	expiredID := types.QuoteClientID("USD", "stake", ctx.BlockHeight())
+	require.NoError(t, keeper.RequestQuote(ctx, "USD", "stake"))
+
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + types.OracleRequestExpiry + 1)
+	require.NoError(t, keeper.RequestQuote(ctx, "USD", "stake"))
+
+	response := oracle.OracleResponsePacketData{ClientID: expiredID, RequestID: 7, ResolveStatus: oracletypes.Success}
+	msg := newOracleResponseMsg(t, bandOraclePort, bandOracleChannel, types.OraclePort, oracleChannel, response)
+	_, err := handler(ctx, msg)
+	require.True(t, types.ErrPendingRequestNotFound.Is(err), err)
+	require.True(t, keeper.HasPendingRequest(ctx, types.QuoteClientID("USD", "stake", ctx.BlockHeight())))
+	_, err = keeper.GetQuote(ctx, "USD", "stake")
+	require.Error(t, err)
+}
+
+func TestOnlyExpiredRequestsAreExpired(t *testing.T) {
+	ctx, keeper, _ := createTestInput(t)
+	for i, height := range []int64{1, 5, 10} {
+		keeper.SetPendingRequest(ctx, types.NewPendingRequest(
+			types.OrderClientID(uint64(i)), types.GoldOracleScriptID, nil, types.OrderOracleCallback,
+			"channel-0", uint64(i), height,
+		))
+	}
+	// moving a request to another height leaves no entry at the previous one
+	keeper.SetPendingRequest(ctx, types.NewPendingRequest(
+		types.OrderClientID(0), types.GoldOracleScriptID, nil, types.OrderOracleCallback, "channel-0", 0, 20,
+	))
+
+	keeper.ExpirePendingRequests(ctx.WithBlockHeight(5 + types.OracleRequestExpiry + 1))
+	require.True(t, keeper.HasPendingRequest(ctx, types.OrderClientID(0)))
+	require.False(t, keeper.HasPendingRequest(ctx, types.OrderClientID(1)))
+	require.True(t, keeper.HasPendingRequest(ctx, types.OrderClientID(2)))
+}
+
+func TestPayFiatReservationBoundedByMaxAmount(t *testing.T) {
+	ctx, keeper, _ := createTestInput(t)
+	handler := NewHandler(keeper)
+	seller, buyer := newAddress(), newAddress()
+	keeper.SetSell(ctx, "Sell-sell-1", types.Sell{
+		SellID: "sell-1", ProductID: "product-1", Seller: seller, Currency: "USD", FiatMinPrice: 100, Denom: "stake",
+	})
+	keeper.SetReservation(ctx, "Reservation-reservation-1", types.Reservation{
+		ReservationID: "reservation-1", SellID: "sell-1", Buyer: buyer, FiatPrice: 100, Decide: true,
+	})
+	keeper.SetQuote(ctx, types.NewQuote("USD", "stake", types.QuoteMultiplier, 1, ctx.BlockHeight()))
+
+	for _, maxAmount := range []sdk.Coins{nil, sdk.NewCoins(sdk.NewInt64Coin("stake", 99))} {
+		msg := NewMsgPayReservation("reservation-1", maxAmount, buyer)
+		require.NoError(t, msg.ValidateBasic())
+		_, err := handler(ctx, msg)
+		require.True(t, types.ErrPaymentAboveMax.Is(err), err)
+	}
+	require.True(t, keeper.IsSellPresent(ctx, "Sell-sell-1"))
+	require.True(t, keeper.IsReservationPresent(ctx, "Reservation-reservation-1"))
+}
+
+func TestSettlementsOfAReusedSellID(t *testing.T) {
+	ctx, keeper, _ := createTestInput(t)
+	keeper.SetSettlement(ctx, types.Settlement{SellID: "sell-1", ReservationID: "reservation-1", Height: 10})
+	keeper.SetSettlement(ctx, types.Settlement{SellID: "sell-1", ReservationID: "reservation-2", Height: 20})
+	keeper.SetSettlement(ctx, types.Settlement{SellID: "sell-10", ReservationID: "reservation-3", Height: 30})
+
+	settlement, err := keeper.GetSettlement(ctx, "sell-1")
+	require.NoError(t, err)
+	require.Equal(t, "reservation-2", settlement.ReservationID)
+	require.Len(t, keeper.GetSettlements(ctx, "sell-1"), 2)
+
+	_, err = keeper.GetSettlement(ctx, "sell-2")
+	require.True(t, types.ErrSettlementNotFound.Is(err), err)
+}
+
+func hasEvent(events []abci.Event, eventType, key, value string) bool {
+	for _, event := range events {
+		if event.Type != eventType {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if string(attr.Key) == key && string(attr.Value) == value {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+
+	"github.com/trinhtan/cosmos-hackathon/x/sunchain/types"
+)
+
+// GetCategory gets the category with the given name from the registry.
+func (k Keeper) GetCategory(ctx sdk.Context, name string) (types.Category, error) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.CategoryStoreKey(name))
+	if bz == nil {
+		return types.Category{}, sdkerrors.Wrapf(types.ErrCategoryNotFound, "category %s", name)
+	}
+	var category types.Category
+	k.cdc.MustUnmarshalBinaryBare(bz, &category)
+	return category, nil
+}
+
+// IsCategoryPresent checks if the category is present in the registry or not
+func (k Keeper) IsCategoryPresent(ctx sdk.Context, name string) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(types.CategoryStoreKey(name))
+}
+
+// GetCategories returns all the categories of the registry ordered by name.
+func (k Keeper) GetCategories(ctx sdk.Context) []types.Category {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.CategoryStoreKeyPrefix)
+	defer iterator.Close()
+	categories := []types.Category{}
+	for ; iterator.Valid(); iterator.Next() {
+		var category types.Category
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &category)
+		categories = append(categories, category)
+	}
+	return categories
+}
+
+// SetCategory adds or updates a category of the registry. The parent of the category must exist
+// and must not be the category itself or one of its descendants.
+func (k Keeper) SetCategory(ctx sdk.Context, category types.Category) error {
+	if err := category.Validate(); err != nil {
+		return err
+	}
+	for parent := category.Parent; parent != ""; {
+		if parent == category.Name {
+			return sdkerrors.Wrapf(types.ErrInvalidCategory, "category %s cannot be its own ancestor", category.Name)
+		}
+		ancestor, err := k.GetCategory(ctx, parent)
+		if err != nil {
+			return sdkerrors.Wrapf(types.ErrCategoryNotFound, "parent %s of category %s", parent, category.Name)
+		}
+		parent = ancestor.Parent
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.CategoryStoreKey(category.Name), k.cdc.MustMarshalBinaryBare(category))
+	return nil
+}
+
+// RemoveCategory removes a category from the registry. Categories which still have sub categories
+// or products cannot be removed.
+func (k Keeper) RemoveCategory(ctx sdk.Context, name string) error {
+	if !k.IsCategoryPresent(ctx, name) {
+		return sdkerrors.Wrapf(types.ErrCategoryNotFound, "category %s", name)
+	}
+	for _, category := range k.GetCategories(ctx) {
+		if category.Parent == name {
+			return sdkerrors.Wrapf(types.ErrCategoryInUse, "category %s is the parent of %s", name, category.Name)
+		}
+	}
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.CategoryProductsStoreKeyPrefix(name))
+	defer iterator.Close()
+	if iterator.Valid() {
+		return sdkerrors.Wrapf(types.ErrCategoryInUse, "category %s has products", name)
+	}
+	store.Delete(types.CategoryStoreKey(name))
+	return nil
+}
+
+// GetSubCategories returns the names of the category and of all its descendants.
+func (k Keeper) GetSubCategories(ctx sdk.Context, name string) []string {
+	children := make(map[string][]string)
+	for _, category := range k.GetCategories(ctx) {
+		children[category.Parent] = append(children[category.Parent], category.Name)
+	}
+	names := []string{name}
+	for i := 0; i < len(names); i++ {
+		names = append(names, children[names[i]]...)
+	}
+	return names
+}
+
+// SetProductCategory indexes the product under its category, removing it from its previous one.
+func (k Keeper) SetProductCategory(ctx sdk.Context, productID, previous, category string) {
+	store := ctx.KVStore(k.storeKey)
+	if previous != "" {
+		store.Delete(types.CategoryProductStoreKey(previous, productID))
+	}
+	store.Set(types.CategoryProductStoreKey(category, productID), []byte{})
+}
+
+// GetProductIDsByCategory returns the ids of the products listed in the category or in one of its
+// descendants.
+func (k Keeper) GetProductIDsByCategory(ctx sdk.Context, name string) []string {
+	store := ctx.KVStore(k.storeKey)
+	productIDs := []string{}
+	for _, category := range k.GetSubCategories(ctx, name) {
+		prefix := types.CategoryProductsStoreKeyPrefix(category)
+		iterator := sdk.KVStorePrefixIterator(store, prefix)
+		for ; iterator.Valid(); iterator.Next() {
+			productIDs = append(productIDs, string(iterator.Key()[len(prefix):]))
+		}
+		iterator.Close()
+	}
+	return productIDs
+}
+
+// ChargeListingFee makes the owner of a product pay the listing fee of its category to the fee
+// collector, so that it is distributed to validators and delegators like transaction fees.
+func (k Keeper) ChargeListingFee(ctx sdk.Context, owner sdk.AccAddress, category types.Category) error {
+	if category.ListingFee.IsZero() {
+		return nil
+	}
+	feeCollector := supply.NewModuleAddress(auth.FeeCollectorName)
+	if err := k.BankKeeper.SendCoins(ctx, owner, feeCollector, category.ListingFee); err != nil {
+		return sdkerrors.Wrapf(err, "listing fee of category %s", category.Name)
+	}
+	return nil
+}
@@ -24,3 +24,32 @@ func (k Keeper) HasChannel(ctx sdk.Context, chainName string, port string) bool
 	store := ctx.KVStore(k.storeKey)
 	return store.Has(types.ChannelStoreKey(chainName, port))
 }
+
+// GetChannels returns the channels registered to other chains.
+func (k Keeper) GetChannels(ctx sdk.Context) []types.SourceChannel {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.ChannelStoreKeyPrefix)
+	defer iterator.Close()
+	channels := []types.SourceChannel{}
+	for ; iterator.Valid(); iterator.Next() {
+		chainName, port, ok := types.ParseChannelStoreKey(iterator.Key())
+		if !ok {
+			continue
+		}
+		channels = append(channels, types.NewSourceChannel(chainName, port, string(iterator.Value())))
+	}
+	return channels
+}
+
+// SetChannelAuthority sets the address allowed to register channels. Channels can only be
+// registered at genesis when it is empty.
+func (k Keeper) SetChannelAuthority(ctx sdk.Context, authority sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.ChannelAuthorityStoreKey, authority)
+}
+
+// GetChannelAuthority returns the address allowed to register channels.
+func (k Keeper) GetChannelAuthority(ctx sdk.Context) sdk.AccAddress {
+	store := ctx.KVStore(k.storeKey)
+	return store.Get(types.ChannelAuthorityStoreKey)
+}
@@ -11,22 +11,27 @@ import (
 )
 
 type Keeper struct {
-	storeKey      sdk.StoreKey
-	cdc           *codec.Codec
-	BankKeeper    types.BankKeeper
-	ChannelKeeper types.ChannelKeeper
+	storeKey        sdk.StoreKey
+	cdc             *codec.Codec
+	BankKeeper      types.BankKeeper
+	ChannelKeeper   types.ChannelKeeper
+	oracleCallbacks map[string]types.OracleCallback
 }
 
 // NewKeeper creates a new band consumer Keeper instance.
 func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, bankKeeper types.BankKeeper,
 	channelKeeper types.ChannelKeeper,
 ) Keeper {
-	return Keeper{
-		storeKey:      key,
-		cdc:           cdc,
-		BankKeeper:    bankKeeper,
-		ChannelKeeper: channelKeeper,
+	k := Keeper{
+		storeKey:        key,
+		cdc:             cdc,
+		BankKeeper:      bankKeeper,
+		ChannelKeeper:   channelKeeper,
+		oracleCallbacks: make(map[string]types.OracleCallback),
 	}
+	k.RegisterOracleCallback(types.OrderOracleCallback, k.resolveOrder)
+	k.RegisterOracleCallback(types.QuoteOracleCallback, k.resolveQuote)
+	return k
 }
 
 // GetOrderCount returns the current number of all orders ever exist.
@@ -49,12 +54,38 @@ func (k Keeper) GetNextOrderCount(ctx sdk.Context) uint64 {
 	return orderCount + 1
 }
 
+// GetProductCount returns the current number of all products ever created.
+func (k Keeper) GetProductCount(ctx sdk.Context) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.ProductsCountStoreKey)
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+// SetProductCount sets the number of all products ever created, which product ids are made from.
+func (k Keeper) SetProductCount(ctx sdk.Context, productCount uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.ProductsCountStoreKey, sdk.Uint64ToBigEndian(productCount))
+}
+
+// GetNextProductCount increments and returns the current number of products.
+// If the global product count is not set, it initializes it with value 0.
+func (k Keeper) GetNextProductCount(ctx sdk.Context) uint64 {
+	productCount := k.GetProductCount(ctx)
+	store := ctx.KVStore(k.storeKey)
+	bz := sdk.Uint64ToBigEndian(productCount + 1)
+	store.Set(types.ProductsCountStoreKey, bz)
+	return productCount + 1
+}
+
 // GetProduct gets the entire Product metadata struct for a name
 func (k Keeper) GetProduct(ctx sdk.Context, key string) (types.Product, error) {
 	store := ctx.KVStore(k.storeKey)
 
 	if !k.IsProductPresent(ctx, key) {
-		return types.NewProduct(), sdkerrors.Wrapf(sdkerrors.ErrKeyNotFound, "Key not found", key)
+		return types.NewProduct(), sdkerrors.Wrapf(sdkerrors.ErrKeyNotFound, "Key not found: %s", key)
 	}
 
 	bz := store.Get([]byte(key))
@@ -126,16 +157,58 @@ func (k Keeper) GetProductCategory(ctx sdk.Context, key string) (string, error)
 	return product.Category, nil
 }
 
-// GetProductImages gets product description
-func (k Keeper) GetProductImages(ctx sdk.Context, key string) (string, error) {
+// GetProductImages gets product images
+func (k Keeper) GetProductImages(ctx sdk.Context, key string) ([]types.ProductImage, error) {
 	product, err := k.GetProduct(ctx, key)
 	if err != nil {
-		return "Product does not exist!", err
+		return nil, err
 	}
 
 	return product.Images, nil
 }
 
+// ValidateProductMetadata checks the metadata a product is created or updated with against the
+// size limits and against the rules of its category in the registry, and returns the category.
+func (k Keeper) ValidateProductMetadata(ctx sdk.Context, metadata types.ProductMetadata) (types.Category, error) {
+	if err := metadata.Validate(); err != nil {
+		return types.Category{}, err
+	}
+	return k.validateProductCategory(ctx, metadata)
+}
+
+// ValidateProductUpdate checks the metadata a product is updated with like ValidateProductMetadata,
+// except that the images the product was migrated with, which have no hash, can be kept.
+func (k Keeper) ValidateProductUpdate(ctx sdk.Context, product types.Product, metadata types.ProductMetadata) (types.Category, error) {
+	if err := metadata.ValidateUpdate(); err != nil {
+		return types.Category{}, err
+	}
+	migrated := make(map[string]bool, len(product.Images))
+	for _, image := range product.Images {
+		if image.Hash == "" {
+			migrated[image.URI] = true
+		}
+	}
+	for _, image := range metadata.Images {
+		if image.Hash == "" && !migrated[image.URI] {
+			return types.Category{}, sdkerrors.Wrapf(types.ErrInvalidMetadata, "image %s has no hash", image.URI)
+		}
+	}
+	return k.validateProductCategory(ctx, metadata)
+}
+
+// validateProductCategory checks the metadata of a product against the rules of its category in
+// the registry, and returns the category.
+func (k Keeper) validateProductCategory(ctx sdk.Context, metadata types.ProductMetadata) (types.Category, error) {
+	category, err := k.GetCategory(ctx, metadata.Category)
+	if err != nil {
+		return types.Category{}, err
+	}
+	if err := category.ValidateProduct(metadata); err != nil {
+		return types.Category{}, err
+	}
+	return category, nil
+}
+
 // GetProductsIterator gets an iterator over all product in which the keys are the productID and the values are the product
 func (k Keeper) GetProductsIterator(ctx sdk.Context) sdk.Iterator {
 	store := ctx.KVStore(k.storeKey)
@@ -153,7 +226,7 @@ func (k Keeper) GetSell(ctx sdk.Context, key string) (types.Sell, error) {
 	store := ctx.KVStore(k.storeKey)
 
 	if !k.IsSellPresent(ctx, key) {
-		return types.NewSell(), sdkerrors.Wrapf(sdkerrors.ErrKeyNotFound, "Key not found", key)
+		return types.NewSell(), sdkerrors.Wrapf(sdkerrors.ErrKeyNotFound, "Key not found: %s", key)
 	}
 
 	bz := store.Get([]byte(key))
@@ -167,7 +240,7 @@ func (k Keeper) GetSell(ctx sdk.Context, key string) (types.Sell, error) {
 
 // SetSell sets the entire sell metadata struct for a sell
 func (k Keeper) SetSell(ctx sdk.Context, key string, sell types.Sell) {
-	if sell.Seller.Empty() || len(sell.ProductID) == 0 || sell.MinPrice.Empty() {
+	if sell.Seller.Empty() || len(sell.ProductID) == 0 || (sell.MinPrice.Empty() && !sell.IsFiatPriced()) {
 		return
 	}
 
@@ -199,7 +272,7 @@ func (k Keeper) GetReservation(ctx sdk.Context, key string) (types.Reservation,
 	store := ctx.KVStore(k.storeKey)
 
 	if !k.IsReservationPresent(ctx, key) {
-		return types.NewReservation(), sdkerrors.Wrapf(sdkerrors.ErrKeyNotFound, "Key not found", key)
+		return types.NewReservation(), sdkerrors.Wrapf(sdkerrors.ErrKeyNotFound, "Key not found: %s", key)
 	}
 
 	bz := store.Get([]byte(key))
@@ -213,7 +286,7 @@ func (k Keeper) GetReservation(ctx sdk.Context, key string) (types.Reservation,
 
 // SetReservation sets the entire sell metadata struct for a reservation
 func (k Keeper) SetReservation(ctx sdk.Context, key string, reservation types.Reservation) {
-	if reservation.Buyer.Empty() || len(reservation.SellID) == 0 || reservation.Price.Empty() {
+	if reservation.Buyer.Empty() || len(reservation.SellID) == 0 || (reservation.Price.Empty() && reservation.FiatPrice == 0) {
 		return
 	}
 
@@ -0,0 +1,69 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/trinhtan/cosmos-hackathon/x/sunchain/types"
+)
+
+// AddDefaultCategories adds the default categories missing from the registry, so that chains
+// started before the registry have the categories products are migrated to.
+func (k Keeper) AddDefaultCategories(ctx sdk.Context) error {
+	for _, category := range types.DefaultCategories() {
+		if k.IsCategoryPresent(ctx, category.Name) {
+			continue
+		}
+		if err := k.SetCategory(ctx, category); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsProductMetadataMigrated checks if the products in store have structured metadata
+func (k Keeper) IsProductMetadataMigrated(ctx sdk.Context) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(types.ProductMetadataMigratedStoreKey)
+}
+
+// SetProductMetadataMigrated records that the products in store have structured metadata
+func (k Keeper) SetProductMetadataMigrated(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.ProductMetadataMigratedStoreKey, []byte{1})
+}
+
+// MigrateProductsV0 moves the products stored in the layout from before structured metadata to
+// the current one, and returns the number of products migrated. The free text category of each
+// product is mapped to the registry category with the same name, or to the fallback category, and
+// the product is indexed under it. It runs at the ProductMetadataUpgrade, after the default
+// categories are added, and does nothing when the products were already migrated.
+func (k Keeper) MigrateProductsV0(ctx sdk.Context) int {
+	if k.IsProductMetadataMigrated(ctx) {
+		return 0
+	}
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, []byte("Product-"))
+
+	products := make(map[string]types.Product)
+	keys := []string{}
+	for ; iterator.Valid(); iterator.Next() {
+		var product types.ProductV0
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &product)
+		category := product.CategoryName()
+		if !k.IsCategoryPresent(ctx, category) {
+			category = types.FallbackCategory
+		}
+		key := string(iterator.Key())
+		keys = append(keys, key)
+		products[key] = product.Migrate(category)
+	}
+	iterator.Close()
+
+	for _, key := range keys {
+		product := products[key]
+		store.Set([]byte(key), k.cdc.MustMarshalBinaryBare(product))
+		k.SetProductCategory(ctx, product.ProductID, "", product.Category)
+	}
+	k.SetProductMetadataMigrated(ctx)
+	return len(keys)
+}
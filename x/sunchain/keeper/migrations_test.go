@@ -0,0 +1,71 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/trinhtan/cosmos-hackathon/x/sunchain/types"
+)
+
+func TestMigrateProductsV0(t *testing.T) {
+	key := sdk.NewKVStoreKey(types.StoreKey)
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	require.NoError(t, ms.LoadLatestVersion())
+	cdc := codec.New()
+	types.RegisterCodec(cdc)
+	keeper := NewKeeper(cdc, key, nil, nil)
+	ctx := sdk.NewContext(ms, abci.Header{Height: 1}, false, log.NewNopLogger())
+
+	owner := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	for key, product := range map[string]types.ProductV0{
+		"Product-1": {ProductID: "1", Title: "Ring", Category: "Jewelry", Images: `["https://a.io/1.png","ipfs://QmImage"]`, Owner: owner},
+		"Product-2": {ProductID: "2", Title: "Shoes", Category: " shoes ", Images: "https://a.io/2.png", Owner: owner, Selling: true, SellID: "sell-2"},
+		"Product-3": {ProductID: "3", Title: "Watch", Category: "Watches & Clocks", Owner: owner},
+	} {
+		ctx.KVStore(keeper.storeKey).Set([]byte(key), cdc.MustMarshalBinaryBare(product))
+	}
+	keeper.SetSell(ctx, "Sell-sell-2", types.Sell{
+		SellID: "sell-2", ProductID: "2", Seller: owner, MinPrice: sdk.NewCoins(sdk.NewInt64Coin("stake", 1)),
+	})
+
+	require.NoError(t, keeper.AddDefaultCategories(ctx))
+	require.Equal(t, 3, keeper.MigrateProductsV0(ctx))
+
+	product, err := keeper.GetProduct(ctx, "Product-1")
+	require.NoError(t, err)
+	require.Equal(t, []types.ProductImage{{URI: "https://a.io/1.png"}, {URI: "ipfs://QmImage"}}, product.Images)
+	require.Equal(t, owner, product.Owner)
+	require.Equal(t, "jewelry", product.Category)
+
+	product, err = keeper.GetProduct(ctx, "Product-2")
+	require.NoError(t, err)
+	require.Equal(t, []types.ProductImage{{URI: "https://a.io/2.png"}}, product.Images)
+	require.Equal(t, "shoes", product.Category)
+	require.True(t, product.Selling)
+	require.Equal(t, "sell-2", product.SellID)
+
+	product, err = keeper.GetProduct(ctx, "Product-3")
+	require.NoError(t, err)
+	require.Empty(t, product.Images)
+	require.Equal(t, types.FallbackCategory, product.Category)
+	require.True(t, keeper.IsSellPresent(ctx, "Sell-sell-2"))
+
+	require.ElementsMatch(t, []string{"1", "2"}, keeper.GetProductIDsByCategory(ctx, "fashion"))
+	require.Equal(t, []string{"3"}, keeper.GetProductIDsByCategory(ctx, types.FallbackCategory))
+
+	// the products are only migrated once
+	require.Equal(t, 0, keeper.MigrateProductsV0(ctx))
+	product, err = keeper.GetProduct(ctx, "Product-1")
+	require.NoError(t, err)
+	require.Equal(t, "jewelry", product.Category)
+}
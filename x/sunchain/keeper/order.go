@@ -1,12 +1,17 @@
 package keeper
 
 import (
+	"encoding/hex"
+	"strconv"
 	"strings"
 
+	"github.com/bandprotocol/bandchain/chain/x/oracle"
+	oracletypes "github.com/bandprotocol/bandchain/chain/x/oracle/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	transfer "github.com/cosmos/cosmos-sdk/x/ibc/20-transfer"
 
+	"github.com/trinhtan/cosmos-hackathon/x/sunchain/borsh"
 	"github.com/trinhtan/cosmos-hackathon/x/sunchain/types"
 )
 
@@ -34,7 +39,9 @@ func (k Keeper) AddOrder(ctx sdk.Context, buyer sdk.AccAddress, amount sdk.Coins
 	if err != nil {
 		return 0, err
 	}
-	k.SetOrder(ctx, orderID, types.NewOrder(buyer, amount))
+	order := types.NewOrder(buyer, amount)
+	k.SetOrder(ctx, orderID, order)
+	emitOrderEvent(ctx, types.ActionCreate, orderID, order)
 
 	return orderID, nil
 }
@@ -56,3 +63,102 @@ func (k Keeper) GetOrder(ctx sdk.Context, id uint64) (types.Order, error) {
 	k.cdc.MustUnmarshalBinaryBare(bz, &order)
 	return order, nil
 }
+
+// RequestGoldPrice asks BandChain for the gold price used to resolve the given order.
+func (k Keeper) RequestGoldPrice(ctx sdk.Context, orderID uint64) error {
+	// TODO: Set all bandchain parameter here
+	calldata, err := borsh.Marshal(types.GoldCalldata{Multiplier: 1000000})
+	if err != nil {
+		return err
+	}
+	askCount := int64(1)
+	minCount := int64(1)
+
+	return k.RequestOracleData(
+		ctx, types.OrderClientID(orderID), types.GoldOracleScriptID, calldata,
+		askCount, minCount, types.OrderOracleCallback,
+	)
+}
+
+// resolveOrder records the gold price from the oracle response and mints gold for the order.
+func (k Keeper) resolveOrder(
+	ctx sdk.Context, request types.PendingRequest, response oracle.OracleResponsePacketData,
+) error {
+	if request.OracleScriptID != types.GoldOracleScriptID {
+		return sdkerrors.Wrapf(
+			types.ErrUnexpectedOracleScript,
+			"request %s was made with oracle script %d", request.ClientID, request.OracleScriptID,
+		)
+	}
+	orderID, err := types.ParseOrderClientID(request.ClientID)
+	if err != nil {
+		return err
+	}
+	order, err := k.GetOrder(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	if order.Status != types.Pending {
+		return sdkerrors.Wrapf(types.ErrInvalidOrderStatus, "order %d is not pending", orderID)
+	}
+	if response.ResolveStatus != oracletypes.Success {
+		return k.refundOrder(ctx, orderID, order)
+	}
+
+	rawResult, err := hex.DecodeString(response.Result)
+	if err != nil {
+		return err
+	}
+	result, err := types.DecodeResult(rawResult)
+	if err != nil {
+		return err
+	}
+	if result.Px == 0 {
+		return sdkerrors.Wrapf(types.ErrBadDataValue, "gold price must not be zero")
+	}
+	k.SetPrice(ctx, types.NewPrice(result.Px, response.RequestID, ctx.BlockHeight(), ctx.BlockTime().Unix()))
+	twap, err := k.GetTWAP(ctx, types.GoldPriceTWAPWindow)
+	if err != nil {
+		return err
+	}
+
+	// Assume multiplier should be 1000000
+	// TODO: Calculate collateral percentage
+	goldAmount := order.Amount[0].Amount.Int64() / int64(twap.Px)
+	if goldAmount == 0 {
+		return k.refundOrder(ctx, orderID, order)
+	}
+	goldToken := sdk.NewCoin("gold", sdk.NewInt(goldAmount))
+	_, err = k.BankKeeper.AddCoins(ctx, order.Owner, sdk.NewCoins(goldToken))
+	if err != nil {
+		return err
+	}
+	order.Gold = goldToken
+	order.Status = types.Active
+	k.SetOrder(ctx, orderID, order)
+	emitOrderEvent(ctx, types.ActionResolve, orderID, order)
+	return nil
+}
+
+// refundOrder returns escrowed collateral to the owner and completes the order.
+func (k Keeper) refundOrder(ctx sdk.Context, orderID uint64, order types.Order) error {
+	escrowAddress := types.GetEscrowAddress()
+	err := k.BankKeeper.SendCoins(ctx, escrowAddress, order.Owner, order.Amount)
+	if err != nil {
+		return err
+	}
+	order.Status = types.Completed
+	k.SetOrder(ctx, orderID, order)
+	emitOrderEvent(ctx, types.ActionRefund, orderID, order)
+	return nil
+}
+
+// emitOrderEvent emits the event of a change to the given order.
+func emitOrderEvent(ctx sdk.Context, action string, orderID uint64, order types.Order) {
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeOrder,
+		sdk.NewAttribute(types.AttributeKeyAction, action),
+		sdk.NewAttribute(types.AttributeKeyOrderID, strconv.FormatUint(orderID, 10)),
+		sdk.NewAttribute(types.AttributeKeyOwner, order.Owner.String()),
+	))
+}
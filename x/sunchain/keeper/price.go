@@ -0,0 +1,92 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/trinhtan/cosmos-hackathon/x/sunchain/types"
+)
+
+// SetPrice saves the given gold price to the price history at its height and request id.
+func (k Keeper) SetPrice(ctx sdk.Context, price types.Price) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.PriceStoreKey(price.Height, int64(price.RequestID)), k.cdc.MustMarshalBinaryBare(price))
+}
+
+// GetLatestPrice gets the most recent gold price from the price history.
+func (k Keeper) GetLatestPrice(ctx sdk.Context) (types.Price, error) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStoreReversePrefixIterator(store, types.PriceStoreKeyPrefix)
+	defer iterator.Close()
+	if !iterator.Valid() {
+		return types.Price{}, sdkerrors.Wrapf(sdkerrors.ErrKeyNotFound, "no gold price recorded")
+	}
+	var price types.Price
+	k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &price)
+	return price, nil
+}
+
+// GetPrices gets the latest gold prices recorded with block time between from and to, inclusive,
+// up to limit of them and oldest first. The history is walked back from the latest price and only
+// as far as from.
+func (k Keeper) GetPrices(ctx sdk.Context, from, to int64, limit int) []types.Price {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStoreReversePrefixIterator(store, types.PriceStoreKeyPrefix)
+	defer iterator.Close()
+	prices := []types.Price{}
+	for ; iterator.Valid() && len(prices) < limit; iterator.Next() {
+		var price types.Price
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &price)
+		if price.Time < from {
+			break
+		}
+		if price.Time <= to {
+			prices = append(prices, price)
+		}
+	}
+	for i, j := 0, len(prices)-1; i < j; i, j = i+1, j-1 {
+		prices[i], prices[j] = prices[j], prices[i]
+	}
+	return prices
+}
+
+// GetTWAP returns the time-weighted average gold price over the last window seconds.
+// Each price is weighted by how long it stayed the latest price inside the window. If no
+// time has passed since the only price in the window was recorded, the latest price is used.
+func (k Keeper) GetTWAP(ctx sdk.Context, window int64) (types.TWAP, error) {
+	latest, err := k.GetLatestPrice(ctx)
+	if err != nil {
+		return types.TWAP{}, err
+	}
+	now := ctx.BlockTime().Unix()
+	windowStart := now - window
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStoreReversePrefixIterator(store, types.PriceStoreKeyPrefix)
+	defer iterator.Close()
+
+	sum := sdk.ZeroInt()
+	totalWeight := int64(0)
+	end := now
+	for ; iterator.Valid(); iterator.Next() {
+		var price types.Price
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &price)
+		start := price.Time
+		if start < windowStart {
+			start = windowStart
+		}
+		if weight := end - start; weight > 0 {
+			sum = sum.Add(sdk.NewIntFromUint64(price.Px).MulRaw(weight))
+			totalWeight += weight
+		}
+		if price.Time <= windowStart {
+			break
+		}
+		end = price.Time
+	}
+
+	if totalWeight == 0 {
+		return types.TWAP{Px: latest.Px, From: windowStart, To: now}, nil
+	}
+	return types.TWAP{Px: sum.QuoRaw(totalWeight).Uint64(), From: windowStart, To: now}, nil
+}
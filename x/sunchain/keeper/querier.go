@@ -3,6 +3,7 @@ package keeper
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	// "github.com/cosmos/cosmos-sdk/codec"
 
@@ -15,6 +16,12 @@ import (
 const (
 	QueryOrder = "order"
 
+	QueryLatestPrice = "price"
+	QueryPrices      = "prices"
+	QueryTWAP        = "twap"
+	QueryQuote       = "quote"
+	QuerySettlement  = "settlement"
+
 	QueryProduct  = "product"
 	QueryProducts = "products"
 
@@ -25,6 +32,10 @@ const (
 	QueryReservations         = "reservations"
 	QueryReservationsBySellID = "reservationsBySellID"
 	QueryProductsByOwner      = "productsByOwner"
+
+	QueryCategory           = "category"
+	QueryCategories         = "categories"
+	QueryProductsByCategory = "productsByCategory"
 )
 
 // NewQuerier is the module level router for state queries.
@@ -33,6 +44,16 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 		switch path[0] {
 		case QueryOrder:
 			return queryOrder(ctx, path[1:], req, keeper)
+		case QueryLatestPrice:
+			return queryLatestPrice(ctx, keeper)
+		case QueryPrices:
+			return queryPrices(ctx, path[1:], keeper)
+		case QueryTWAP:
+			return queryTWAP(ctx, path[1:], keeper)
+		case QueryQuote:
+			return queryQuote(ctx, path[1:], keeper)
+		case QuerySettlement:
+			return querySettlement(ctx, path[1:], keeper)
 		case QueryProduct:
 			return queryProduct(ctx, path[1:], req, keeper)
 		case QueryProducts:
@@ -49,6 +70,12 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return queryReservations(ctx, req, keeper)
 		case QueryReservationsBySellID:
 			return queryReservationsBySellID(ctx, path[1:], req, keeper)
+		case QueryCategory:
+			return queryCategory(ctx, path[1:], keeper)
+		case QueryCategories:
+			return queryCategories(ctx, keeper)
+		case QueryProductsByCategory:
+			return queryProductsByCategory(ctx, path[1:], keeper)
 		default:
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unknown sunchain query endpoint")
 		}
@@ -73,6 +100,74 @@ func queryOrder(
 	return keeper.cdc.MustMarshalJSON(order), nil
 }
 
+// queryLatestPrice is a query function to get the most recent gold price.
+func queryLatestPrice(ctx sdk.Context, keeper Keeper) ([]byte, error) {
+	price, err := keeper.GetLatestPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return keeper.cdc.MustMarshalJSON(price), nil
+}
+
+// queryPrices is a query function to get the latest gold prices recorded between two unix times.
+func queryPrices(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	if len(path) < 2 {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "must specify the time range")
+	}
+	from, err := strconv.ParseInt(path[0], 10, 64)
+	if err != nil {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "wrong format for from time %s", err.Error())
+	}
+	to, err := strconv.ParseInt(path[1], 10, 64)
+	if err != nil {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "wrong format for to time %s", err.Error())
+	}
+	prices := types.QueryResPrices(keeper.GetPrices(ctx, from, to, types.MaxPricesPerQuery))
+	return keeper.cdc.MustMarshalJSON(prices), nil
+}
+
+// queryTWAP is a query function to get time-weighted average gold price over a window in seconds.
+func queryTWAP(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	window := types.GoldPriceTWAPWindow
+	if len(path) > 0 {
+		var err error
+		window, err = strconv.ParseInt(path[0], 10, 64)
+		if err != nil || window <= 0 {
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "wrong format for window %s", path[0])
+		}
+	}
+	twap, err := keeper.GetTWAP(ctx, window)
+	if err != nil {
+		return nil, err
+	}
+	return keeper.cdc.MustMarshalJSON(twap), nil
+}
+
+// queryQuote is a query function to get the latest quote of a denom in a currency.
+func queryQuote(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	if len(path) < 2 {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "must specify the currency and denom")
+	}
+	// IBC denoms contain slashes, so the rest of the path is the denom
+	quote, err := keeper.GetQuote(ctx, path[0], strings.Join(path[1:], "/"))
+	if err != nil {
+		return nil, err
+	}
+	return keeper.cdc.MustMarshalJSON(quote), nil
+}
+
+// querySettlement is a query function to get the latest settlement of a paid sell.
+func querySettlement(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	if len(path) == 0 {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "must specify the sell id")
+	}
+	settlement, err := keeper.GetSettlement(ctx, path[0])
+	if err != nil {
+		return nil, err
+	}
+	return keeper.cdc.MustMarshalJSON(settlement), nil
+}
+
 // nolint: unparam
 func queryProduct(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, error) {
 
@@ -242,3 +337,40 @@ func queryProductsByOwner(ctx sdk.Context, path []string, req abci.RequestQuery,
 
 	return res, nil
 }
+
+// queryCategory is a query function to get a category of the registry by name.
+func queryCategory(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	if len(path) == 0 {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "must specify the category name")
+	}
+	category, err := keeper.GetCategory(ctx, path[0])
+	if err != nil {
+		return nil, err
+	}
+	return keeper.cdc.MustMarshalJSON(category), nil
+}
+
+// queryCategories is a query function to get all the categories of the registry.
+func queryCategories(ctx sdk.Context, keeper Keeper) ([]byte, error) {
+	categories := types.QueryResCategories(keeper.GetCategories(ctx))
+	return keeper.cdc.MustMarshalJSON(categories), nil
+}
+
+// queryProductsByCategory is a query function to get the products of a category and of its sub categories.
+func queryProductsByCategory(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	if len(path) == 0 {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "must specify the category name")
+	}
+	if !keeper.IsCategoryPresent(ctx, path[0]) {
+		return nil, sdkerrors.Wrapf(types.ErrCategoryNotFound, "category %s", path[0])
+	}
+	productsList := types.QueryResProducts{}
+	for _, productID := range keeper.GetProductIDsByCategory(ctx, path[0]) {
+		product, err := keeper.GetProduct(ctx, "Product-"+productID)
+		if err != nil {
+			continue
+		}
+		productsList = append(productsList, product)
+	}
+	return keeper.cdc.MustMarshalJSON(productsList), nil
+}
@@ -0,0 +1,140 @@
+package keeper
+
+import (
+	"encoding/hex"
+
+	"github.com/bandprotocol/bandchain/chain/x/oracle"
+	oracletypes "github.com/bandprotocol/bandchain/chain/x/oracle/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/trinhtan/cosmos-hackathon/x/sunchain/types"
+)
+
+// RequestQuote asks BandChain for the price of one unit of denom in the given currency. Only one
+// request of a currency and denom is pending at a time, and an expired one is replaced.
+func (k Keeper) RequestQuote(ctx sdk.Context, currency string, denom string) error {
+	// TODO: Set all bandchain parameter here
+	askCount := int64(1)
+	minCount := int64(1)
+
+	oracleScriptID := k.GetQuoteOracleScriptID(ctx)
+	if oracleScriptID == 0 {
+		return sdkerrors.Wrap(types.ErrOracleScriptNotSet, "quote oracle script is not set in genesis")
+	}
+
+	for _, pending := range k.getPendingQuoteRequests(ctx, currency, denom) {
+		if !pending.IsExpired(ctx.BlockHeight()) {
+			return sdkerrors.Wrapf(types.ErrItemDuplication, "request %s is already pending", pending.ClientID)
+		}
+		k.expireRequest(ctx, pending)
+	}
+
+	calldata, err := types.EncodeQuoteCalldata(currency, denom)
+	if err != nil {
+		return err
+	}
+	return k.RequestOracleData(
+		ctx, types.QuoteClientID(currency, denom, ctx.BlockHeight()), oracleScriptID,
+		calldata, askCount, minCount, types.QuoteOracleCallback,
+	)
+}
+
+// getPendingQuoteRequests returns the pending requests of the given currency and denom.
+func (k Keeper) getPendingQuoteRequests(ctx sdk.Context, currency string, denom string) []types.PendingRequest {
+	store := ctx.KVStore(k.storeKey)
+	prefix := types.PendingRequestStoreKey(types.QuoteClientIDsPrefix(currency, denom))
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	requests := []types.PendingRequest{}
+	for ; iterator.Valid(); iterator.Next() {
+		var request types.PendingRequest
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &request)
+		requests = append(requests, request)
+	}
+	return requests
+}
+
+// resolveQuote stores the quote from the oracle response as the latest quote of its currency and denom.
+func (k Keeper) resolveQuote(
+	ctx sdk.Context, request types.PendingRequest, response oracle.OracleResponsePacketData,
+) error {
+	if request.OracleScriptID != k.GetQuoteOracleScriptID(ctx) {
+		return sdkerrors.Wrapf(
+			types.ErrUnexpectedOracleScript,
+			"request %s was made with oracle script %d", request.ClientID, request.OracleScriptID,
+		)
+	}
+	currency, denom, err := types.ParseQuoteClientID(request.ClientID)
+	if err != nil {
+		return err
+	}
+	if response.ResolveStatus != oracletypes.Success {
+		// Nothing to store, the caller can request a new quote.
+		return nil
+	}
+	rawResult, err := hex.DecodeString(response.Result)
+	if err != nil {
+		return err
+	}
+	result, err := types.DecodeResult(rawResult)
+	if err != nil {
+		return err
+	}
+	if result.Px == 0 {
+		return sdkerrors.Wrapf(types.ErrBadDataValue, "quote rate must not be zero")
+	}
+	k.SetQuote(ctx, types.NewQuote(currency, denom, result.Px, response.RequestID, ctx.BlockHeight()))
+	return nil
+}
+
+// SetQuoteOracleScriptID sets the oracle script on BandChain that returns the price of a coin in a
+// currency. Quotes can't be requested while it is 0.
+func (k Keeper) SetQuoteOracleScriptID(ctx sdk.Context, oracleScriptID oracle.OracleScriptID) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.QuoteOracleScriptIDStoreKey, sdk.Uint64ToBigEndian(uint64(oracleScriptID)))
+}
+
+// GetQuoteOracleScriptID returns the oracle script quotes are requested from, or 0 if it is not set.
+func (k Keeper) GetQuoteOracleScriptID(ctx sdk.Context) oracle.OracleScriptID {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.QuoteOracleScriptIDStoreKey)
+	if bz == nil {
+		return 0
+	}
+	return oracle.OracleScriptID(sdk.BigEndianToUint64(bz))
+}
+
+// SetQuote saves the given quote as the latest quote of its currency and denom.
+func (k Keeper) SetQuote(ctx sdk.Context, quote types.Quote) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.QuoteStoreKey(quote.Currency, quote.Denom), k.cdc.MustMarshalBinaryBare(quote))
+}
+
+// GetQuote gets the latest quote of the given currency and denom from the store.
+func (k Keeper) GetQuote(ctx sdk.Context, currency string, denom string) (types.Quote, error) {
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has(types.QuoteStoreKey(currency, denom)) {
+		return types.Quote{}, sdkerrors.Wrapf(types.ErrQuoteNotFound, "%s in %s", denom, currency)
+	}
+	bz := store.Get(types.QuoteStoreKey(currency, denom))
+	var quote types.Quote
+	k.cdc.MustUnmarshalBinaryBare(bz, &quote)
+	return quote, nil
+}
+
+// GetFreshQuote gets the latest quote of the given currency and denom if it is still usable for payment.
+func (k Keeper) GetFreshQuote(ctx sdk.Context, currency string, denom string) (types.Quote, error) {
+	quote, err := k.GetQuote(ctx, currency, denom)
+	if err != nil {
+		return types.Quote{}, err
+	}
+	if !quote.IsFresh(ctx.BlockHeight()) {
+		return types.Quote{}, sdkerrors.Wrapf(
+			types.ErrQuoteExpired,
+			"quote of %s in %s was received at height %d", denom, currency, quote.Height,
+		)
+	}
+	return quote, nil
+}
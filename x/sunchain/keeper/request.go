@@ -0,0 +1,288 @@
+package keeper
+
+import (
+	"encoding/hex"
+	"strconv"
+
+	"github.com/bandprotocol/bandchain/chain/x/oracle"
+	oracletypes "github.com/bandprotocol/bandchain/chain/x/oracle/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	channel "github.com/cosmos/cosmos-sdk/x/ibc/04-channel"
+
+	"github.com/trinhtan/cosmos-hackathon/x/sunchain/types"
+)
+
+// RegisterOracleCallback registers the function that handles oracle responses of the given kind.
+func (k Keeper) RegisterOracleCallback(kind string, callback types.OracleCallback) {
+	if _, ok := k.oracleCallbacks[kind]; ok {
+		panic("oracle callback " + kind + " already registered")
+	}
+	k.oracleCallbacks[kind] = callback
+}
+
+// RequestOracleData sends an oracle request packet to BandChain and tracks it as pending until
+// its response is dispatched to the callback registered for the given kind. A request whose
+// pending request with the same client id expired replaces it.
+func (k Keeper) RequestOracleData(
+	ctx sdk.Context, clientID string, oracleScriptID oracle.OracleScriptID, calldata []byte,
+	askCount int64, minCount int64, callback string,
+) error {
+	if _, ok := k.oracleCallbacks[callback]; !ok {
+		return sdkerrors.Wrapf(types.ErrOracleCallbackNotFound, "callback %s", callback)
+	}
+	if pending, err := k.GetPendingRequest(ctx, clientID); err == nil {
+		if !pending.IsExpired(ctx.BlockHeight()) {
+			return sdkerrors.Wrapf(types.ErrItemDuplication, "request %s is already pending", clientID)
+		}
+		k.expireRequest(ctx, pending)
+	}
+
+	port := types.OraclePort
+	channelID, err := k.GetChannel(ctx, types.BandChainName, port)
+	if err != nil {
+		return sdkerrors.Wrapf(
+			sdkerrors.ErrUnknownRequest,
+			"not found channel to bandchain",
+		)
+	}
+	sourceChannelEnd, found := k.ChannelKeeper.GetChannel(ctx, port, channelID)
+	if !found {
+		return sdkerrors.Wrapf(
+			sdkerrors.ErrUnknownRequest,
+			"unknown channel %s port %s",
+			channelID, port,
+		)
+	}
+	destinationPort := sourceChannelEnd.Counterparty.PortID
+	destinationChannel := sourceChannelEnd.Counterparty.ChannelID
+	sequence, found := k.ChannelKeeper.GetNextSequenceSend(
+		ctx, port, channelID,
+	)
+	if !found {
+		return sdkerrors.Wrapf(
+			sdkerrors.ErrUnknownRequest,
+			"unknown sequence number for channel %s port %s",
+			channelID, port,
+		)
+	}
+	packet := oracle.NewOracleRequestPacketData(
+		clientID, oracleScriptID, hex.EncodeToString(calldata),
+		askCount, minCount,
+	)
+	err = k.ChannelKeeper.SendPacket(ctx, channel.NewPacket(packet.GetBytes(),
+		sequence, port, channelID, destinationPort, destinationChannel,
+		types.OracleRequestTimeout,
+	))
+	if err != nil {
+		return err
+	}
+	k.SetPendingRequest(ctx, types.NewPendingRequest(
+		clientID, oracleScriptID, calldata, callback, channelID, sequence, ctx.BlockHeight(),
+	))
+	return nil
+}
+
+// HandleOracleResponse checks that the response comes from the BandChain channel and answers an
+// outstanding request, then dispatches it to the callback the request was made with.
+func (k Keeper) HandleOracleResponse(
+	ctx sdk.Context, ibcPacket channel.Packet, response oracle.OracleResponsePacketData,
+) error {
+	if err := k.validateOraclePacketSource(ctx, ibcPacket); err != nil {
+		return err
+	}
+	if k.IsRequestResolved(ctx, response.RequestID) {
+		return sdkerrors.Wrapf(types.ErrRequestAlreadyResolved, "request %d", response.RequestID)
+	}
+	request, err := k.GetPendingRequest(ctx, response.ClientID)
+	if err != nil {
+		return err
+	}
+	if request.SourceChannel != ibcPacket.GetDestChannel() {
+		return sdkerrors.Wrapf(
+			types.ErrInvalidPacketSource,
+			"request %s was sent on channel %s", request.ClientID, request.SourceChannel,
+		)
+	}
+	k.DeletePendingRequest(ctx, request.ClientID)
+	k.SetRequestResolved(ctx, response.RequestID)
+	k.dispatchOracleResponse(ctx, request, response)
+	return nil
+}
+
+// ExpirePendingRequests expires the pending requests which waited too long for their response.
+// Only the requests expiring up to the current height are read, through the index by expiry height.
+func (k Keeper) ExpirePendingRequests(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(
+		types.PendingRequestExpiryStoreKeyPrefix,
+		types.PendingRequestsExpiryStoreKeyPrefix(ctx.BlockHeight()+1),
+	)
+	expired := []types.PendingRequest{}
+	for ; iterator.Valid(); iterator.Next() {
+		clientID := string(iterator.Key()[len(types.PendingRequestExpiryStoreKeyPrefix)+8:])
+		request, err := k.GetPendingRequest(ctx, clientID)
+		if err != nil {
+			panic(err)
+		}
+		expired = append(expired, request)
+	}
+	iterator.Close()
+
+	for _, request := range expired {
+		k.expireRequest(ctx, request)
+	}
+}
+
+// expireRequest removes the pending request and dispatches a failed response to its callback, so
+// that the callback can release what the request holds.
+func (k Keeper) expireRequest(ctx sdk.Context, request types.PendingRequest) {
+	k.DeletePendingRequest(ctx, request.ClientID)
+	response := oracle.OracleResponsePacketData{ClientID: request.ClientID, ResolveStatus: oracletypes.Failure}
+	emitOracleRequestEvent(ctx, types.ActionExpire, request, response, nil)
+	k.dispatchOracleResponse(ctx, request, response)
+}
+
+// dispatchOracleResponse runs the callback of the request with its response. The error of a failed
+// callback is recorded in an event rather than returned, as reverting the response would leave the
+// request pending. The changes of the failed callback are discarded and it is run again with a
+// failed response, so that it can release what the request holds.
+func (k Keeper) dispatchOracleResponse(
+	ctx sdk.Context, request types.PendingRequest, response oracle.OracleResponsePacketData,
+) {
+	callback, ok := k.oracleCallbacks[request.Callback]
+	if !ok {
+		err := sdkerrors.Wrapf(types.ErrOracleCallbackNotFound, "callback %s", request.Callback)
+		emitOracleRequestEvent(ctx, types.ActionFail, request, response, err)
+		return
+	}
+
+	err := runOracleCallback(ctx, callback, request, response)
+	if err == nil {
+		emitOracleRequestEvent(ctx, types.ActionResolve, request, response, nil)
+		return
+	}
+	ctx.Logger().Error("oracle callback failed", "client_id", request.ClientID, "error", err)
+	emitOracleRequestEvent(ctx, types.ActionFail, request, response, err)
+
+	if response.ResolveStatus != oracletypes.Failure {
+		response.ResolveStatus = oracletypes.Failure
+		if err := runOracleCallback(ctx, callback, request, response); err != nil {
+			ctx.Logger().Error("oracle callback failed", "client_id", request.ClientID, "error", err)
+			emitOracleRequestEvent(ctx, types.ActionFail, request, response, err)
+		}
+	}
+}
+
+// runOracleCallback runs the callback on a cached context whose changes and events are only kept
+// if it succeeds.
+func runOracleCallback(
+	ctx sdk.Context, callback types.OracleCallback, request types.PendingRequest,
+	response oracle.OracleResponsePacketData,
+) error {
+	cacheCtx, write := ctx.CacheContext()
+	if err := callback(cacheCtx, request, response); err != nil {
+		return err
+	}
+	write()
+	ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	return nil
+}
+
+// emitOracleRequestEvent emits the event of a change to the given oracle request.
+func emitOracleRequestEvent(
+	ctx sdk.Context, action string, request types.PendingRequest, response oracle.OracleResponsePacketData,
+	err error,
+) {
+	attributes := []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyAction, action),
+		sdk.NewAttribute(types.AttributeKeyClientID, request.ClientID),
+		sdk.NewAttribute(types.AttributeKeyRequestID, strconv.FormatInt(int64(response.RequestID), 10)),
+	}
+	if err != nil {
+		attributes = append(attributes, sdk.NewAttribute(types.AttributeKeyError, err.Error()))
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(types.EventTypeOracleRequest, attributes...))
+}
+
+// validateOraclePacketSource checks that the packet was received on the channel registered to BandChain.
+func (k Keeper) validateOraclePacketSource(ctx sdk.Context, packet channel.Packet) error {
+	channelID, err := k.GetChannel(ctx, types.BandChainName, types.OraclePort)
+	if err != nil {
+		return sdkerrors.Wrap(types.ErrInvalidPacketSource, "channel to bandchain is not registered")
+	}
+	if packet.GetDestPort() != types.OraclePort || packet.GetDestChannel() != channelID {
+		return sdkerrors.Wrapf(
+			types.ErrInvalidPacketSource,
+			"packet received on port %s channel %s", packet.GetDestPort(), packet.GetDestChannel(),
+		)
+	}
+	channelEnd, found := k.ChannelKeeper.GetChannel(ctx, types.OraclePort, channelID)
+	if !found {
+		return sdkerrors.Wrapf(types.ErrInvalidPacketSource, "unknown channel %s port %s", channelID, types.OraclePort)
+	}
+	if packet.GetSourcePort() != channelEnd.Counterparty.PortID ||
+		packet.GetSourceChannel() != channelEnd.Counterparty.ChannelID {
+		return sdkerrors.Wrapf(
+			types.ErrInvalidPacketSource,
+			"packet sent from port %s channel %s", packet.GetSourcePort(), packet.GetSourceChannel(),
+		)
+	}
+	return nil
+}
+
+// SetPendingRequest saves the outstanding oracle request to the store and indexes it by the height
+// it expires at.
+func (k Keeper) SetPendingRequest(ctx sdk.Context, request types.PendingRequest) {
+	k.DeletePendingRequest(ctx, request.ClientID)
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.PendingRequestStoreKey(request.ClientID), k.cdc.MustMarshalBinaryBare(request))
+	store.Set(types.PendingRequestExpiryStoreKey(request.ExpiryHeight(), request.ClientID), []byte{0x01})
+}
+
+// GetPendingRequest gets the outstanding oracle request with the given client id from the store.
+func (k Keeper) GetPendingRequest(ctx sdk.Context, clientID string) (types.PendingRequest, error) {
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has(types.PendingRequestStoreKey(clientID)) {
+		return types.PendingRequest{}, sdkerrors.Wrapf(types.ErrPendingRequestNotFound, "client id %s", clientID)
+	}
+	bz := store.Get(types.PendingRequestStoreKey(clientID))
+	var request types.PendingRequest
+	k.cdc.MustUnmarshalBinaryBare(bz, &request)
+	return request, nil
+}
+
+// HasPendingRequest checks if an oracle request with the given client id is outstanding.
+func (k Keeper) HasPendingRequest(ctx sdk.Context, clientID string) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(types.PendingRequestStoreKey(clientID))
+}
+
+// DeletePendingRequest removes the outstanding oracle request with the given client id from the store.
+func (k Keeper) DeletePendingRequest(ctx sdk.Context, clientID string) {
+	request, err := k.GetPendingRequest(ctx, clientID)
+	if err != nil {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.PendingRequestStoreKey(clientID))
+	store.Delete(types.PendingRequestExpiryStoreKey(request.ExpiryHeight(), clientID))
+}
+
+// GetPendingRequestsIterator gets an iterator over all outstanding oracle requests.
+func (k Keeper) GetPendingRequestsIterator(ctx sdk.Context) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.PendingRequestStoreKeyPrefix)
+}
+
+// SetRequestResolved marks the given BandChain request id as handled.
+func (k Keeper) SetRequestResolved(ctx sdk.Context, requestID oracle.RequestID) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.ResolvedRequestStoreKey(int64(requestID)), []byte{0x01})
+}
+
+// IsRequestResolved checks if the response of the given BandChain request id was already handled.
+func (k Keeper) IsRequestResolved(ctx sdk.Context, requestID oracle.RequestID) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(types.ResolvedRequestStoreKey(int64(requestID)))
+}
@@ -0,0 +1,44 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/trinhtan/cosmos-hackathon/x/sunchain/types"
+)
+
+// SetSettlement saves the settlement of a paid reservation to the store.
+func (k Keeper) SetSettlement(ctx sdk.Context, settlement types.Settlement) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(
+		types.SettlementStoreKey(settlement.SellID, settlement.Height, settlement.ReservationID),
+		k.cdc.MustMarshalBinaryBare(settlement),
+	)
+}
+
+// GetSettlement gets the latest settlement of the given sell from the store.
+func (k Keeper) GetSettlement(ctx sdk.Context, sellID string) (types.Settlement, error) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStoreReversePrefixIterator(store, types.SettlementsStoreKeyPrefix(sellID))
+	defer iterator.Close()
+	if !iterator.Valid() {
+		return types.Settlement{}, sdkerrors.Wrapf(types.ErrSettlementNotFound, "sell %s", sellID)
+	}
+	var settlement types.Settlement
+	k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &settlement)
+	return settlement, nil
+}
+
+// GetSettlements gets all the settlements of the given sell from the store, oldest first.
+func (k Keeper) GetSettlements(ctx sdk.Context, sellID string) []types.Settlement {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.SettlementsStoreKeyPrefix(sellID))
+	defer iterator.Close()
+	settlements := []types.Settlement{}
+	for ; iterator.Valid(); iterator.Next() {
+		var settlement types.Settlement
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &settlement)
+		settlements = append(settlements, settlement)
+	}
+	return settlements
+}
@@ -0,0 +1,108 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// MaxCategoryDescriptionLength is the size limit of the description of a category
+const MaxCategoryDescriptionLength = 1024
+
+// FallbackCategory is the default category products from before the registry are listed in when
+// their category matches none of the registry
+const FallbackCategory = "other"
+
+// Category is a product category of the registry. Categories form a tree through their parent,
+// and the rules of a category only apply to the products listed directly in it.
+type Category struct {
+	Name        string `json:"name"`
+	Parent      string `json:"parent"`
+	Description string `json:"description"`
+	// ListingFee is paid by the owner of a product when the product is listed in the category
+	ListingFee sdk.Coins `json:"listingFee"`
+	// RequiredAttributes are the attribute keys every product of the category must have
+	RequiredAttributes []string `json:"requiredAttributes"`
+}
+
+// NewCategory creates a new Category instance.
+func NewCategory(name, parent, description string, listingFee sdk.Coins, requiredAttributes []string) Category {
+	return Category{
+		Name:               name,
+		Parent:             parent,
+		Description:        description,
+		ListingFee:         listingFee,
+		RequiredAttributes: requiredAttributes,
+	}
+}
+
+// Validate checks that the category is well formed. Whether its parent exists is checked by the keeper.
+func (category Category) Validate() error {
+	if len(category.Name) > MaxCategoryLength || !categoryRegexp.MatchString(category.Name) {
+		return sdkerrors.Wrapf(ErrInvalidCategory, "invalid name %q", category.Name)
+	}
+	if category.Parent != "" && (len(category.Parent) > MaxCategoryLength || !categoryRegexp.MatchString(category.Parent)) {
+		return sdkerrors.Wrapf(ErrInvalidCategory, "invalid parent %q", category.Parent)
+	}
+	if category.Parent == category.Name {
+		return sdkerrors.Wrapf(ErrInvalidCategory, "category %s cannot be its own parent", category.Name)
+	}
+	if len(category.Description) > MaxCategoryDescriptionLength {
+		return sdkerrors.Wrapf(ErrInvalidCategory, "description is longer than %d bytes", MaxCategoryDescriptionLength)
+	}
+	if !category.ListingFee.IsValid() {
+		return sdkerrors.Wrapf(ErrInvalidCategory, "invalid listing fee %s", category.ListingFee)
+	}
+	if len(category.RequiredAttributes) > MaxAttributes {
+		return sdkerrors.Wrapf(ErrInvalidCategory, "more than %d required attributes", MaxAttributes)
+	}
+	keys := make(map[string]bool, len(category.RequiredAttributes))
+	for _, key := range category.RequiredAttributes {
+		if len(key) > MaxAttributeKeyLength || !attributeKeyRegexp.MatchString(key) || keys[key] {
+			return sdkerrors.Wrapf(ErrInvalidCategory, "invalid or duplicate required attribute %q", key)
+		}
+		keys[key] = true
+	}
+	return nil
+}
+
+// ValidateProduct checks that the metadata of a product follows the rules of the category
+func (category Category) ValidateProduct(metadata ProductMetadata) error {
+	keys := make(map[string]bool, len(metadata.Attributes))
+	for _, attribute := range metadata.Attributes {
+		keys[attribute.Key] = true
+	}
+	for _, key := range category.RequiredAttributes {
+		if !keys[key] {
+			return sdkerrors.Wrapf(ErrInvalidMetadata, "products of category %s must have attribute %s", category.Name, key)
+		}
+	}
+	return nil
+}
+
+// implement fmt.Stringer
+func (category Category) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`
+	Name: %s
+	Parent: %s
+	Description: %s
+	ListingFee: %s
+	RequiredAttributes: %s`, category.Name, category.Parent, category.Description, category.ListingFee,
+		strings.Join(category.RequiredAttributes, ", ")))
+}
+
+// DefaultCategories are the categories of the registry at genesis
+func DefaultCategories() []Category {
+	return []Category{
+		NewCategory("art", "", "Paintings, prints and sculptures", nil, nil),
+		NewCategory("collectibles", "", "Coins, stamps, cards and memorabilia", nil, nil),
+		NewCategory("electronics", "", "Phones, computers and other devices", nil, nil),
+		NewCategory("fashion", "", "Clothing, shoes and accessories", nil, nil),
+		NewCategory("jewelry", "fashion", "Rings, necklaces and watches", nil, nil),
+		NewCategory("shoes", "fashion", "Shoes and boots", nil, nil),
+		NewCategory("home", "", "Furniture and decoration", nil, nil),
+		NewCategory("other", "", "Products that fit no other category", nil, nil),
+	}
+}
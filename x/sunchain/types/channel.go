@@ -0,0 +1,67 @@
+package types
+
+import (
+	"strings"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	host "github.com/cosmos/cosmos-sdk/x/ibc/24-host"
+)
+
+// TransferPort is the port gold orders receive their collateral on
+const TransferPort = "transfer"
+
+// ChannelPorts are the ports channels to other chains can be registered on
+var ChannelPorts = []string{OraclePort, TransferPort}
+
+// SourceChannel is a channel registered to talk to another chain on one of the ChannelPorts
+type SourceChannel struct {
+	ChainName     string `json:"chain_name"`
+	SourcePort    string `json:"source_port"`
+	SourceChannel string `json:"source_channel"`
+}
+
+// NewSourceChannel creates a new SourceChannel instance.
+func NewSourceChannel(chainName, sourcePort, sourceChannel string) SourceChannel {
+	return SourceChannel{
+		ChainName:     chainName,
+		SourcePort:    sourcePort,
+		SourceChannel: sourceChannel,
+	}
+}
+
+// Validate checks that the channel is registered on one of the ChannelPorts with valid identifiers.
+func (sc SourceChannel) Validate() error {
+	if sc.ChainName == "" {
+		return sdkerrors.Wrap(ErrInvalidBasicMsg, "chain name must not be empty")
+	}
+	if !isChannelPort(sc.SourcePort) {
+		return sdkerrors.Wrapf(
+			ErrInvalidBasicMsg, "channels can only be registered on ports %s", strings.Join(ChannelPorts, ", "),
+		)
+	}
+	if err := host.DefaultChannelIdentifierValidator(sc.SourceChannel); err != nil {
+		return sdkerrors.Wrap(ErrInvalidBasicMsg, err.Error())
+	}
+	return nil
+}
+
+func isChannelPort(port string) bool {
+	for _, p := range ChannelPorts {
+		if p == port {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseChannelStoreKey returns the chain name and port of a key generated by ChannelStoreKey. Only
+// keys of the ChannelPorts can be parsed, as the chain name and port are not delimited.
+func ParseChannelStoreKey(key []byte) (chainName, port string, ok bool) {
+	name := string(key[len(ChannelStoreKeyPrefix):])
+	for _, p := range ChannelPorts {
+		if strings.HasSuffix(name, p) && len(name) > len(p) {
+			return strings.TrimSuffix(name, p), p, true
+		}
+	}
+	return "", "", false
+}
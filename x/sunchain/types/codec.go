@@ -28,9 +28,15 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgUpdateSell{}, "sunchain/UpdateSell", nil)
 	cdc.RegisterConcrete(MsgDeleteSell{}, "sunchain/DeleteSell", nil)
 	cdc.RegisterConcrete(MsgDecideSell{}, "sunchain/DecideSell", nil)
+	cdc.RegisterConcrete(MsgCreateFiatSell{}, "sunchain/CreateFiatSell", nil)
 
 	cdc.RegisterConcrete(MsgCreateReservation{}, "sunchain/CreateReservation", nil)
 	cdc.RegisterConcrete(MsgUpdateReservation{}, "sunchain/UpdateReservation", nil)
 	cdc.RegisterConcrete(MsgDeleteReservation{}, "sunchain/DeleteReservation", nil)
 	cdc.RegisterConcrete(MsgPayReservation{}, "sunchain/PayReservation", nil)
+	cdc.RegisterConcrete(MsgCreateFiatReservation{}, "sunchain/CreateFiatReservation", nil)
+
+	cdc.RegisterConcrete(MsgRequestQuote{}, "sunchain/RequestQuote", nil)
+
+	cdc.RegisterConcrete(CategoryChangeProposal{}, "sunchain/CategoryChangeProposal", nil)
 }
@@ -25,4 +25,25 @@ var (
 	ErrReservationDoesNotExist  = sdkerrors.Register(ModuleName, 15, "reservation does not exist")
 	ErrReservationAlreadyExists = sdkerrors.Register(ModuleName, 16, "reservation already exists")
 	ErrReservationNotDecided    = sdkerrors.Register(ModuleName, 17, "reservation not decided")
+
+	ErrInvalidPacketSource    = sdkerrors.Register(ModuleName, 18, "packet not from registered channel")
+	ErrUnexpectedOracleScript = sdkerrors.Register(ModuleName, 19, "unexpected oracle script")
+	ErrPendingRequestNotFound = sdkerrors.Register(ModuleName, 20, "pending request not found")
+	ErrRequestAlreadyResolved = sdkerrors.Register(ModuleName, 21, "request already resolved")
+	ErrInvalidOrderStatus     = sdkerrors.Register(ModuleName, 22, "invalid order status")
+	ErrOracleCallbackNotFound = sdkerrors.Register(ModuleName, 23, "oracle callback not found")
+	ErrQuoteNotFound          = sdkerrors.Register(ModuleName, 24, "quote not found")
+	ErrQuoteExpired           = sdkerrors.Register(ModuleName, 25, "quote expired")
+	ErrPricingMismatch        = sdkerrors.Register(ModuleName, 26, "pricing mismatch")
+	ErrPriceTooLow            = sdkerrors.Register(ModuleName, 27, "price lower than minimum price")
+	ErrSettlementNotFound     = sdkerrors.Register(ModuleName, 28, "settlement not found")
+
+	ErrInvalidMetadata = sdkerrors.Register(ModuleName, 29, "invalid product metadata")
+
+	ErrInvalidCategory  = sdkerrors.Register(ModuleName, 30, "invalid category")
+	ErrCategoryNotFound = sdkerrors.Register(ModuleName, 31, "category not found")
+	ErrCategoryInUse    = sdkerrors.Register(ModuleName, 32, "category in use")
+	ErrPaymentAboveMax  = sdkerrors.Register(ModuleName, 33, "payment above maximum amount")
+
+	ErrOracleScriptNotSet = sdkerrors.Register(ModuleName, 34, "oracle script not set")
 )
@@ -0,0 +1,41 @@
+package types
+
+// sunchain module event types
+const (
+	EventTypeProduct     = "product"
+	EventTypeSell        = "sell"
+	EventTypeReservation = "reservation"
+	EventTypeOrder       = "order"
+	// EventTypeOracleRequest is emitted when an oracle request is resolved, fails or expires
+	EventTypeOracleRequest = "oracle_request"
+
+	// AttributeKeyAction is the change that happened to the entity of the event
+	AttributeKeyAction        = "action"
+	AttributeKeyProductID     = "product_id"
+	AttributeKeySellID        = "sell_id"
+	AttributeKeyReservationID = "reservation_id"
+	AttributeKeyOrderID       = "order_id"
+	AttributeKeyClientID      = "client_id"
+	AttributeKeyRequestID     = "request_id"
+	// AttributeKeyError is the error an oracle callback failed with
+	AttributeKeyError = "error"
+	// AttributeKeyOwner is the owner of the product the event is about, or of the order
+	AttributeKeyOwner = "owner"
+	AttributeKeyBuyer = "buyer"
+
+	AttributeValueCategory = ModuleName
+)
+
+// Values of AttributeKeyAction
+const (
+	ActionCreate   = "create"
+	ActionUpdate   = "update"
+	ActionDelete   = "delete"
+	ActionDecide   = "decide"
+	ActionPay      = "pay"
+	ActionTransfer = "transfer"
+	ActionResolve  = "resolve"
+	ActionRefund   = "refund"
+	ActionFail     = "fail"
+	ActionExpire   = "expire"
+)
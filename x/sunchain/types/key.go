@@ -21,11 +21,49 @@ var (
 	// OrdersCountStoreKey is a key that help getting to current orders count state variable
 	OrdersCountStoreKey = append(GlobalStoreKeyPrefix, []byte("OrdersCount")...)
 
+	// ProductsCountStoreKey is a key that help getting to current products count state variable
+	ProductsCountStoreKey = append(GlobalStoreKeyPrefix, []byte("ProductsCount")...)
+
+	// ChannelAuthorityStoreKey is a key that help getting to the address allowed to register channels
+	ChannelAuthorityStoreKey = append(GlobalStoreKeyPrefix, []byte("ChannelAuthority")...)
+
+	// QuoteOracleScriptIDStoreKey is a key that help getting to the oracle script quotes are requested from
+	QuoteOracleScriptIDStoreKey = append(GlobalStoreKeyPrefix, []byte("QuoteOracleScriptID")...)
+
+	// ProductMetadataMigratedStoreKey is a key that help getting to whether the products in store were
+	// migrated to structured metadata
+	ProductMetadataMigratedStoreKey = append(GlobalStoreKeyPrefix, []byte("ProductMetadataMigrated")...)
+
 	// ChannelStoreKeyPrefix is a prefix for storing channel
 	ChannelStoreKeyPrefix = []byte{0x01}
 
 	// OrderStoreKeyPrefix is a prefix for storing order
 	OrderStoreKeyPrefix = []byte{0x02}
+
+	// PendingRequestStoreKeyPrefix is a prefix for storing outstanding oracle requests by client id
+	PendingRequestStoreKeyPrefix = []byte{0x03}
+
+	// ResolvedRequestStoreKeyPrefix is a prefix for storing BandChain request ids that were already handled
+	ResolvedRequestStoreKeyPrefix = []byte{0x04}
+
+	// PriceStoreKeyPrefix is a prefix for storing gold price history
+	PriceStoreKeyPrefix = []byte{0x05}
+
+	// QuoteStoreKeyPrefix is a prefix for storing the latest quote of each currency and denom
+	QuoteStoreKeyPrefix = []byte{0x06}
+
+	// SettlementStoreKeyPrefix is a prefix for storing settlement of each paid reservation of a sell
+	SettlementStoreKeyPrefix = []byte{0x07}
+
+	// CategoryStoreKeyPrefix is a prefix for storing the categories of the product registry
+	CategoryStoreKeyPrefix = []byte{0x08}
+
+	// CategoryProductStoreKeyPrefix is a prefix for indexing products by category
+	CategoryProductStoreKeyPrefix = []byte{0x09}
+
+	// PendingRequestExpiryStoreKeyPrefix is a prefix for indexing outstanding oracle requests by the
+	// height they expire at
+	PendingRequestExpiryStoreKeyPrefix = []byte{0x0A}
 )
 
 // ChannelStoreKey is a function to generate key for each verified channel in store
@@ -40,6 +78,74 @@ func OrderStoreKey(orderID uint64) []byte {
 	return append(OrderStoreKeyPrefix, uint64ToBytes(orderID)...)
 }
 
+// PendingRequestStoreKey is a function to generate key for each outstanding oracle request in store
+func PendingRequestStoreKey(clientID string) []byte {
+	return append(PendingRequestStoreKeyPrefix, []byte(clientID)...)
+}
+
+// PendingRequestsExpiryStoreKeyPrefix is a function to generate the prefix of the keys of the
+// outstanding oracle requests which expire before the given height
+func PendingRequestsExpiryStoreKeyPrefix(height int64) []byte {
+	return append(PendingRequestExpiryStoreKeyPrefix, uint64ToBytes(uint64(height))...)
+}
+
+// PendingRequestExpiryStoreKey is a function to generate key for each outstanding oracle request in
+// the index by expiry height
+func PendingRequestExpiryStoreKey(expiryHeight int64, clientID string) []byte {
+	return append(PendingRequestsExpiryStoreKeyPrefix(expiryHeight), []byte(clientID)...)
+}
+
+// ResolvedRequestStoreKey is a function to generate key for each handled BandChain request id in store
+func ResolvedRequestStoreKey(requestID int64) []byte {
+	return append(ResolvedRequestStoreKeyPrefix, uint64ToBytes(uint64(requestID))...)
+}
+
+// PriceStoreKey is a function to generate key for gold price of each BandChain request recorded at
+// each height in store, so that several prices recorded in the same block are all kept
+func PriceStoreKey(height int64, requestID int64) []byte {
+	buf := append(PriceStoreKeyPrefix, uint64ToBytes(uint64(height))...)
+	return append(buf, uint64ToBytes(uint64(requestID))...)
+}
+
+// QuoteStoreKey is a function to generate key for the latest quote of a currency and denom in store
+func QuoteStoreKey(currency, denom string) []byte {
+	buf := append(QuoteStoreKeyPrefix, []byte(currency)...)
+	buf = append(buf, byte(':'))
+	buf = append(buf, []byte(denom)...)
+	return buf
+}
+
+// SettlementsStoreKeyPrefix is a function to generate the prefix of the keys of the settlements of
+// a sell in store
+func SettlementsStoreKeyPrefix(sellID string) []byte {
+	buf := append(SettlementStoreKeyPrefix, []byte(sellID)...)
+	return append(buf, byte(0x00))
+}
+
+// SettlementStoreKey is a function to generate key for settlement of each paid reservation in
+// store. Settlements of a sell are ordered by height, so that a reused sell ID doesn't overwrite
+// the settlements recorded before.
+func SettlementStoreKey(sellID string, height int64, reservationID string) []byte {
+	buf := append(SettlementsStoreKeyPrefix(sellID), uint64ToBytes(uint64(height))...)
+	return append(buf, []byte(reservationID)...)
+}
+
+// CategoryStoreKey is a function to generate key for each category in store
+func CategoryStoreKey(name string) []byte {
+	return append(CategoryStoreKeyPrefix, []byte(name)...)
+}
+
+// CategoryProductsStoreKeyPrefix is a function to generate the prefix of the products of a category in store
+func CategoryProductsStoreKeyPrefix(category string) []byte {
+	buf := append(CategoryProductStoreKeyPrefix, []byte(category)...)
+	return append(buf, byte(0))
+}
+
+// CategoryProductStoreKey is a function to generate key for each product of a category in store
+func CategoryProductStoreKey(category, productID string) []byte {
+	return append(CategoryProductsStoreKeyPrefix(category), []byte(productID)...)
+}
+
 func uint64ToBytes(num uint64) []byte {
 	result := make([]byte, 8)
 	binary.BigEndian.PutUint64(result, num)
@@ -0,0 +1,58 @@
+package types
+
+import (
+	"encoding/json"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ProductMetadataUpgrade is the name of the software upgrade which moves the products in store to
+// the layout with structured metadata
+const ProductMetadataUpgrade = "product-metadata"
+
+// ProductV0 is the layout products were stored in before structured metadata, when the images
+// were a JSON list of URIs held in a string. It is only decoded to migrate the products in store.
+type ProductV0 struct {
+	ProductID   string         `json:"productID"`
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Category    string         `json:"category"`
+	Images      string         `json:"images"`
+	Owner       sdk.AccAddress `json:"owner"`
+	Selling     bool           `json:"selling"`
+	SellID      string         `json:"sellID"`
+}
+
+// Migrate returns the product in the current layout, listed in the given category of the registry.
+// The images keep their URI, and have no hash as their content is unknown. Images which are not a
+// JSON list are kept as a single URI.
+func (product ProductV0) Migrate(category string) Product {
+	var uris []string
+	if err := json.Unmarshal([]byte(product.Images), &uris); err != nil && product.Images != "" {
+		uris = []string{product.Images}
+	}
+	var images []ProductImage
+	for _, uri := range uris {
+		images = append(images, ProductImage{URI: uri})
+	}
+	return Product{
+		ProductID:   product.ProductID,
+		Title:       product.Title,
+		Description: product.Description,
+		Category:    category,
+		Images:      images,
+		Owner:       product.Owner,
+		Selling:     product.Selling,
+		SellID:      product.SellID,
+	}
+}
+
+// CategoryName returns the registry name the free text category of the product would have, in
+// lower case with its words separated by dashes. It is not checked against the registry.
+func (product ProductV0) CategoryName() string {
+	words := strings.FieldsFunc(strings.ToLower(product.Category), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+	return strings.Join(words, "-")
+}
@@ -0,0 +1,184 @@
+package types
+
+import (
+	"encoding/hex"
+	"net/url"
+	"regexp"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+// Size limits of product metadata
+const (
+	MaxTitleLength          = 128
+	MaxDescriptionLength    = 4096
+	MaxCategoryLength       = 64
+	MaxImages               = 10
+	MaxImageURILength       = 512
+	MaxAttributes           = 32
+	MaxAttributeKeyLength   = 64
+	MaxAttributeValueLength = 256
+)
+
+// Types of product attributes
+const (
+	AttributeTypeString  = "string"
+	AttributeTypeInteger = "integer"
+	AttributeTypeDecimal = "decimal"
+	AttributeTypeBool    = "bool"
+)
+
+var (
+	// categoryRegexp matches category names, which are lower case words separated by dashes
+	categoryRegexp = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	// attributeKeyRegexp matches attribute keys, which are lower case words separated by underscores
+	attributeKeyRegexp = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+	// imageSchemes are the URI schemes images can be fetched with
+	imageSchemes = map[string]bool{"https": true, "http": true, "ipfs": true}
+)
+
+// ProductImage is an image of a product stored off chain, with the hash of its content so that
+// clients can check the image they fetch.
+type ProductImage struct {
+	URI string `json:"uri"`
+	// Hash is the hex encoded SHA-256 hash of the image content
+	Hash string `json:"hash"`
+}
+
+// ProductAttribute is a typed key/value property of a product, such as a size or a weight
+type ProductAttribute struct {
+	Key   string `json:"key"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// ProductMetadata is the description of a product given by its owner
+type ProductMetadata struct {
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	Category    string             `json:"category"`
+	Images      []ProductImage     `json:"images"`
+	Attributes  []ProductAttribute `json:"attributes"`
+}
+
+// NewProductMetadata returns a new ProductMetadata
+func NewProductMetadata(title, description, category string, images []ProductImage, attributes []ProductAttribute) ProductMetadata {
+	return ProductMetadata{
+		Title:       title,
+		Description: description,
+		Category:    category,
+		Images:      images,
+		Attributes:  attributes,
+	}
+}
+
+// Validate checks that the metadata is well formed and within the size limits
+func (metadata ProductMetadata) Validate() error {
+	return metadata.validate(true)
+}
+
+// ValidateUpdate checks the metadata a product is updated with like Validate, except that images
+// may have no hash. Images without a hash are the ones migrated from before structured metadata,
+// and the keeper checks that the product already has them.
+func (metadata ProductMetadata) ValidateUpdate() error {
+	return metadata.validate(false)
+}
+
+func (metadata ProductMetadata) validate(requireImageHashes bool) error {
+	if len(metadata.Title) == 0 {
+		return sdkerrors.Wrap(ErrInvalidMetadata, "title cannot be empty")
+	}
+	if len(metadata.Title) > MaxTitleLength {
+		return sdkerrors.Wrapf(ErrInvalidMetadata, "title is longer than %d bytes", MaxTitleLength)
+	}
+	if len(metadata.Description) > MaxDescriptionLength {
+		return sdkerrors.Wrapf(ErrInvalidMetadata, "description is longer than %d bytes", MaxDescriptionLength)
+	}
+	if len(metadata.Category) > MaxCategoryLength || !categoryRegexp.MatchString(metadata.Category) {
+		return sdkerrors.Wrapf(ErrInvalidMetadata, "invalid category %q", metadata.Category)
+	}
+
+	if len(metadata.Images) > MaxImages {
+		return sdkerrors.Wrapf(ErrInvalidMetadata, "more than %d images", MaxImages)
+	}
+	for _, image := range metadata.Images {
+		if !requireImageHashes && image.Hash == "" {
+			if err := image.ValidateURI(); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := image.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if len(metadata.Attributes) > MaxAttributes {
+		return sdkerrors.Wrapf(ErrInvalidMetadata, "more than %d attributes", MaxAttributes)
+	}
+	keys := make(map[string]bool, len(metadata.Attributes))
+	for _, attribute := range metadata.Attributes {
+		if err := attribute.Validate(); err != nil {
+			return err
+		}
+		if keys[attribute.Key] {
+			return sdkerrors.Wrapf(ErrInvalidMetadata, "duplicate attribute %s", attribute.Key)
+		}
+		keys[attribute.Key] = true
+	}
+	return nil
+}
+
+// Validate checks that the image has a fetchable URI and a SHA-256 hash
+func (image ProductImage) Validate() error {
+	if err := image.ValidateURI(); err != nil {
+		return err
+	}
+	hash, err := hex.DecodeString(image.Hash)
+	if err != nil || len(hash) != tmhash.Size {
+		return sdkerrors.Wrapf(ErrInvalidMetadata, "invalid hash %q of image %s", image.Hash, image.URI)
+	}
+	return nil
+}
+
+// ValidateURI checks that the image has a fetchable URI
+func (image ProductImage) ValidateURI() error {
+	if len(image.URI) > MaxImageURILength {
+		return sdkerrors.Wrapf(ErrInvalidMetadata, "image uri is longer than %d bytes", MaxImageURILength)
+	}
+	uri, err := url.Parse(image.URI)
+	if err != nil || !imageSchemes[uri.Scheme] || (uri.Host == "" && uri.Opaque == "") {
+		return sdkerrors.Wrapf(ErrInvalidMetadata, "invalid image uri %q", image.URI)
+	}
+	return nil
+}
+
+// Validate checks that the attribute key is well formed and that the value is of the attribute type
+func (attribute ProductAttribute) Validate() error {
+	if len(attribute.Key) > MaxAttributeKeyLength || !attributeKeyRegexp.MatchString(attribute.Key) {
+		return sdkerrors.Wrapf(ErrInvalidMetadata, "invalid attribute key %q", attribute.Key)
+	}
+	if len(attribute.Value) > MaxAttributeValueLength {
+		return sdkerrors.Wrapf(ErrInvalidMetadata, "value of attribute %s is longer than %d bytes", attribute.Key, MaxAttributeValueLength)
+	}
+
+	var err error
+	switch attribute.Type {
+	case AttributeTypeString:
+	case AttributeTypeInteger:
+		_, err = strconv.ParseInt(attribute.Value, 10, 64)
+	case AttributeTypeDecimal:
+		_, err = sdk.NewDecFromStr(attribute.Value)
+	case AttributeTypeBool:
+		_, err = strconv.ParseBool(attribute.Value)
+	default:
+		return sdkerrors.Wrapf(ErrInvalidMetadata, "unknown type %q of attribute %s", attribute.Type, attribute.Key)
+	}
+	if err != nil {
+		return sdkerrors.Wrapf(ErrInvalidMetadata, "value %q of attribute %s is not a %s", attribute.Value, attribute.Key, attribute.Type)
+	}
+	return nil
+}
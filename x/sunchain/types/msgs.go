@@ -1,6 +1,8 @@
 package types
 
 import (
+	"strings"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
@@ -36,8 +38,10 @@ func (msg MsgSetSourceChannel) Type() string { return "set_source_channel" }
 
 // ValidateBasic implements the sdk.Msg interface for MsgSetSourceChannel.
 func (msg MsgSetSourceChannel) ValidateBasic() error {
-	// TODO: Add validate basic
-	return nil
+	if msg.Signer.Empty() {
+		return sdkerrors.Wrapf(ErrInvalidBasicMsg, "MsgSetSourceChannel: Signer address must not be empty.")
+	}
+	return NewSourceChannel(msg.ChainName, msg.SourcePort, msg.SourceChannel).Validate()
 }
 
 // GetSigners implements the sdk.Msg interface for MsgSetSourceChannel.
@@ -93,28 +97,36 @@ func (msg MsgBuyGold) GetSignBytes() []byte {
 	return sdk.MustSortJSON(bz)
 }
 
-// MsgCreateProduct defines a SetProduct message
+// MsgCreateProduct defines a SetProduct message. The id of the product is assigned by the chain.
 type MsgCreateProduct struct {
-	ProductID   string         `json:"productID"`
-	Title       string         `json:"title"`
-	Description string         `json:"description"`
-	Category    string         `json:"category"`
-	Images      string         `json:"images"`
-	Signer      sdk.AccAddress `json:"signer"`
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	Category    string             `json:"category"`
+	Images      []ProductImage     `json:"images"`
+	Attributes  []ProductAttribute `json:"attributes"`
+	Signer      sdk.AccAddress     `json:"signer"`
+	// SellID and MinPrice put the product on sale once it is created, when MinPrice is set
+	SellID   string    `json:"sellID"`
+	MinPrice sdk.Coins `json:"minPrice"`
 }
 
 // NewMsgCreateProduct is a constructor function for MsgSetProduct
-func NewMsgCreateProduct(productID string, title string, description string, category string, images string, signer sdk.AccAddress) MsgCreateProduct {
+func NewMsgCreateProduct(metadata ProductMetadata, signer sdk.AccAddress) MsgCreateProduct {
 	return MsgCreateProduct{
-		ProductID:   productID,
-		Title:       title,
-		Description: description,
-		Category:    category,
-		Images:      images,
+		Title:       metadata.Title,
+		Description: metadata.Description,
+		Category:    metadata.Category,
+		Images:      metadata.Images,
+		Attributes:  metadata.Attributes,
 		Signer:      signer,
 	}
 }
 
+// Metadata returns the metadata of the product to create
+func (msg MsgCreateProduct) Metadata() ProductMetadata {
+	return NewProductMetadata(msg.Title, msg.Description, msg.Category, msg.Images, msg.Attributes)
+}
+
 // Route should return the name of the module
 func (msg MsgCreateProduct) Route() string { return RouterKey }
 
@@ -126,10 +138,13 @@ func (msg MsgCreateProduct) ValidateBasic() error {
 	if msg.Signer.Empty() {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Signer.String())
 	}
-	if len(msg.ProductID) == 0 || len(msg.Title) == 0 {
-		return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "ProductID and/or Title and/or Description cannot be empty")
+	if !msg.MinPrice.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, msg.MinPrice.String())
 	}
-	return nil
+	if msg.MinPrice.Empty() != (len(msg.SellID) == 0) {
+		return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "SellID and MinPrice must be given together")
+	}
+	return msg.Metadata().Validate()
 }
 
 // GetSignBytes encodes the message for signing
@@ -142,28 +157,35 @@ func (msg MsgCreateProduct) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Signer}
 }
 
-// MsgUpdateProduct defines a UpdateProduct message
+// MsgUpdateProduct defines a UpdateProduct message. It replaces the whole metadata of the product.
 type MsgUpdateProduct struct {
-	ProductID   string         `json:"productID"`
-	Title       string         `json:"title"`
-	Description string         `json:"description"`
-	Category    string         `json:"category"`
-	Images      string         `json:"images"`
-	Signer      sdk.AccAddress `json:"signer"`
+	ProductID   string             `json:"productID"`
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	Category    string             `json:"category"`
+	Images      []ProductImage     `json:"images"`
+	Attributes  []ProductAttribute `json:"attributes"`
+	Signer      sdk.AccAddress     `json:"signer"`
 }
 
 // NewMsgUpdateProduct is a constructor function for MsgSetProduct
-func NewMsgUpdateProduct(productID string, title string, description string, category string, images string, signer sdk.AccAddress) MsgUpdateProduct {
+func NewMsgUpdateProduct(productID string, metadata ProductMetadata, signer sdk.AccAddress) MsgUpdateProduct {
 	return MsgUpdateProduct{
 		ProductID:   productID,
-		Title:       title,
-		Description: description,
-		Category:    category,
-		Images:      images,
+		Title:       metadata.Title,
+		Description: metadata.Description,
+		Category:    metadata.Category,
+		Images:      metadata.Images,
+		Attributes:  metadata.Attributes,
 		Signer:      signer,
 	}
 }
 
+// Metadata returns the new metadata of the product
+func (msg MsgUpdateProduct) Metadata() ProductMetadata {
+	return NewProductMetadata(msg.Title, msg.Description, msg.Category, msg.Images, msg.Attributes)
+}
+
 // Route should return the name of the module
 func (msg MsgUpdateProduct) Route() string { return RouterKey }
 
@@ -175,10 +197,10 @@ func (msg MsgUpdateProduct) ValidateBasic() error {
 	if msg.Signer.Empty() {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Signer.String())
 	}
-	if len(msg.ProductID) == 0 || len(msg.Title) == 0 || len(msg.Description) == 0 {
-		return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "ProductID and/or Title and/or Description cannot be empty")
+	if len(msg.ProductID) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "ProductID cannot be empty")
 	}
-	return nil
+	return msg.Metadata().ValidateUpdate()
 }
 
 // GetSignBytes encodes the message for signing
@@ -543,16 +565,20 @@ func (msg MsgDecideSell) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Signer}
 }
 
-// MsgDecideSell defines a DecideSell message
+// MsgPayReservation defines a PayReservation message. MaxAmount is the most the buyer agrees to
+// pay, and is required to pay a sell priced in a fiat currency, whose amount is only known from the
+// quote at the time of the payment.
 type MsgPayReservation struct {
 	ReservationID string         `json:"reservationID"`
+	MaxAmount     sdk.Coins      `json:"maxAmount"`
 	Signer        sdk.AccAddress `json:"signer"`
 }
 
 // NewMsgPayReservation is a constructor function for MsgPayReservation
-func NewMsgPayReservation(reservationID string, signer sdk.AccAddress) MsgPayReservation {
+func NewMsgPayReservation(reservationID string, maxAmount sdk.Coins, signer sdk.AccAddress) MsgPayReservation {
 	return MsgPayReservation{
 		ReservationID: reservationID,
+		MaxAmount:     maxAmount,
 		Signer:        signer,
 	}
 }
@@ -571,6 +597,9 @@ func (msg MsgPayReservation) ValidateBasic() error {
 	if len(msg.ReservationID) == 0 {
 		return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "ReservationID cannot be empty")
 	}
+	if !msg.MaxAmount.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, msg.MaxAmount.String())
+	}
 	return nil
 }
 
@@ -624,3 +653,150 @@ func (msg MsgPayReservationByAtom) GetSignBytes() []byte {
 func (msg MsgPayReservationByAtom) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Signer}
 }
+
+// MsgCreateFiatSell defines a CreateFiatSell message
+type MsgCreateFiatSell struct {
+	SellID       string         `json:"sellID"`
+	ProductID    string         `json:"productID"`
+	Signer       sdk.AccAddress `json:"signer"`
+	Currency     string         `json:"currency"`
+	FiatMinPrice uint64         `json:"fiatMinPrice"`
+	Denom        string         `json:"denom"`
+}
+
+// NewMsgCreateFiatSell is a constructor function for MsgCreateFiatSell
+func NewMsgCreateFiatSell(sellID string, productID string, signer sdk.AccAddress, currency string, fiatMinPrice uint64, denom string) MsgCreateFiatSell {
+	return MsgCreateFiatSell{
+		SellID:       sellID,
+		ProductID:    productID,
+		Signer:       signer,
+		Currency:     currency,
+		FiatMinPrice: fiatMinPrice,
+		Denom:        denom,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgCreateFiatSell) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgCreateFiatSell) Type() string { return "create_fiat_sell" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgCreateFiatSell) ValidateBasic() error {
+	if msg.Signer.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Signer.String())
+	}
+	if len(msg.SellID) == 0 || len(msg.ProductID) == 0 || len(msg.Currency) == 0 || msg.FiatMinPrice == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "ProductID and/or SellID and/or Currency and/or FiatMinPrice cannot be empty")
+	}
+	if strings.Contains(msg.Currency, ":") {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "invalid currency %s", msg.Currency)
+	}
+	if err := sdk.ValidateDenom(msg.Denom); err != nil {
+		return sdkerrors.Wrap(ErrInvalidDenom, err.Error())
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgCreateFiatSell) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgCreateFiatSell) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Signer}
+}
+
+// MsgCreateFiatReservation defines a CreateFiatReservation message
+type MsgCreateFiatReservation struct {
+	ReservationID string         `json:"reservationID"`
+	SellID        string         `json:"sellID"`
+	Signer        sdk.AccAddress `json:"buyer"`
+	FiatPrice     uint64         `json:"fiatPrice"`
+}
+
+// NewMsgCreateFiatReservation is a constructor function for MsgCreateFiatReservation
+func NewMsgCreateFiatReservation(reservationID string, sellID string, signer sdk.AccAddress, fiatPrice uint64) MsgCreateFiatReservation {
+	return MsgCreateFiatReservation{
+		ReservationID: reservationID,
+		SellID:        sellID,
+		Signer:        signer,
+		FiatPrice:     fiatPrice,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgCreateFiatReservation) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgCreateFiatReservation) Type() string { return "create_fiat_reservation" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgCreateFiatReservation) ValidateBasic() error {
+	if msg.Signer.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Signer.String())
+	}
+	if len(msg.ReservationID) == 0 || len(msg.SellID) == 0 || msg.FiatPrice == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "SellID and/or ReservationID and/or FiatPrice cannot be empty")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgCreateFiatReservation) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgCreateFiatReservation) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Signer}
+}
+
+// MsgRequestQuote is a message for requesting the price of a coin in a reference currency
+type MsgRequestQuote struct {
+	Currency string         `json:"currency"`
+	Denom    string         `json:"denom"`
+	Signer   sdk.AccAddress `json:"signer"`
+}
+
+// NewMsgRequestQuote creates a new MsgRequestQuote instance.
+func NewMsgRequestQuote(currency string, denom string, signer sdk.AccAddress) MsgRequestQuote {
+	return MsgRequestQuote{
+		Currency: currency,
+		Denom:    denom,
+		Signer:   signer,
+	}
+}
+
+// Route implements the sdk.Msg interface for MsgRequestQuote.
+func (msg MsgRequestQuote) Route() string { return RouterKey }
+
+// Type implements the sdk.Msg interface for MsgRequestQuote.
+func (msg MsgRequestQuote) Type() string { return "request_quote" }
+
+// ValidateBasic implements the sdk.Msg interface for MsgRequestQuote.
+func (msg MsgRequestQuote) ValidateBasic() error {
+	if msg.Signer.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Signer.String())
+	}
+	if len(msg.Currency) == 0 || strings.Contains(msg.Currency, ":") {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "invalid currency %s", msg.Currency)
+	}
+	if err := sdk.ValidateDenom(msg.Denom); err != nil {
+		return sdkerrors.Wrap(ErrInvalidDenom, err.Error())
+	}
+	return nil
+}
+
+// GetSigners implements the sdk.Msg interface for MsgRequestQuote.
+func (msg MsgRequestQuote) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Signer}
+}
+
+// GetSignBytes implements the sdk.Msg interface for MsgRequestQuote.
+func (msg MsgRequestQuote) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
@@ -0,0 +1,91 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bandprotocol/bandchain/chain/x/oracle"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+const (
+	// BandChainName is the chain name used to register the channel to BandChain
+	BandChainName = "bandchain"
+	// OraclePort is the port this module uses to talk to BandChain
+	OraclePort = "sunchain"
+	// OrderClientIDPrefix is the prefix of client id of every gold order request
+	OrderClientIDPrefix = "Order"
+
+	// OrderOracleCallback is the callback kind that resolves gold orders
+	OrderOracleCallback = "order"
+
+	// OracleRequestTimeout is the timeout height of every oracle request packet
+	OracleRequestTimeout = uint64(1000000000) // Arbitrarily high timeout for now
+
+	// OracleRequestExpiry is the number of blocks a pending request waits for its response before
+	// it expires and its client id can be requested again
+	OracleRequestExpiry = int64(1000)
+)
+
+// GoldOracleScriptID is the oracle script on BandChain that returns the gold price
+var GoldOracleScriptID = oracle.OracleScriptID(3)
+
+// OracleCallback is called with the pending request and its response once BandChain answers
+type OracleCallback func(ctx sdk.Context, request PendingRequest, response oracle.OracleResponsePacketData) error
+
+// PendingRequest is an oracle request that was sent to BandChain and still waits for its response
+type PendingRequest struct {
+	ClientID       string                `json:"client_id"`
+	OracleScriptID oracle.OracleScriptID `json:"oracle_script_id"`
+	Calldata       []byte                `json:"calldata"`
+	Callback       string                `json:"callback"`
+	SourceChannel  string                `json:"source_channel"`
+	Sequence       uint64                `json:"sequence"`
+	RequestHeight  int64                 `json:"request_height"`
+}
+
+// NewPendingRequest creates a new PendingRequest instance.
+func NewPendingRequest(
+	clientID string, oracleScriptID oracle.OracleScriptID, calldata []byte, callback string,
+	sourceChannel string, sequence uint64, requestHeight int64,
+) PendingRequest {
+	return PendingRequest{
+		ClientID:       clientID,
+		OracleScriptID: oracleScriptID,
+		Calldata:       calldata,
+		Callback:       callback,
+		SourceChannel:  sourceChannel,
+		Sequence:       sequence,
+		RequestHeight:  requestHeight,
+	}
+}
+
+// ExpiryHeight returns the height the request stops waiting for its response at
+func (request PendingRequest) ExpiryHeight() int64 {
+	return request.RequestHeight + OracleRequestExpiry + 1
+}
+
+// IsExpired returns true if the request stopped waiting for its response at the given height
+func (request PendingRequest) IsExpired(height int64) bool {
+	return height >= request.ExpiryHeight()
+}
+
+// OrderClientID returns client id of oracle request for the given order
+func OrderClientID(orderID uint64) string {
+	return fmt.Sprintf("%s:%d", OrderClientIDPrefix, orderID)
+}
+
+// ParseOrderClientID returns order id from client id of oracle request
+func ParseOrderClientID(clientID string) (uint64, error) {
+	parts := strings.Split(clientID, ":")
+	if len(parts) != 2 || parts[0] != OrderClientIDPrefix {
+		return 0, sdkerrors.Wrapf(ErrUnknownClientID, "unknown client id %s", clientID)
+	}
+	orderID, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return 0, sdkerrors.Wrapf(ErrUnknownClientID, "invalid order id in client id %s", clientID)
+	}
+	return orderID, nil
+}
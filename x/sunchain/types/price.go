@@ -0,0 +1,58 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bandprotocol/bandchain/chain/x/oracle"
+)
+
+const (
+	// GoldPriceTWAPWindow is the number of seconds the gold price is averaged over when minting gold
+	GoldPriceTWAPWindow = int64(3600)
+
+	// MaxPricesPerQuery is the maximum number of gold prices returned by a price range query
+	MaxPricesPerQuery = 100
+)
+
+// Price is a gold price reported by BandChain and recorded at a block height
+type Price struct {
+	Px        uint64           `json:"px"`
+	RequestID oracle.RequestID `json:"request_id"`
+	Height    int64            `json:"height"`
+	Time      int64            `json:"time"`
+}
+
+// NewPrice creates a new Price instance.
+func NewPrice(px uint64, requestID oracle.RequestID, height int64, time int64) Price {
+	return Price{
+		Px:        px,
+		RequestID: requestID,
+		Height:    height,
+		Time:      time,
+	}
+}
+
+// implement fmt.Stringer
+func (price Price) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`
+	Px: %d
+	RequestID: %d
+	Height: %d
+	Time: %d`, price.Px, price.RequestID, price.Height, price.Time))
+}
+
+// TWAP is a time-weighted average gold price over a time range
+type TWAP struct {
+	Px   uint64 `json:"px"`
+	From int64  `json:"from"`
+	To   int64  `json:"to"`
+}
+
+// implement fmt.Stringer
+func (twap TWAP) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`
+	Px: %d
+	From: %d
+	To: %d`, twap.Px, twap.From, twap.To))
+}
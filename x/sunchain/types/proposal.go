@@ -0,0 +1,85 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+const (
+	// ProposalTypeCategoryChange defines the type for a CategoryChangeProposal
+	ProposalTypeCategoryChange = "CategoryChange"
+)
+
+// Assert CategoryChangeProposal implements govtypes.Content at compile-time
+var _ govtypes.Content = CategoryChangeProposal{}
+
+func init() {
+	govtypes.RegisterProposalType(ProposalTypeCategoryChange)
+	govtypes.RegisterProposalTypeCodec(CategoryChangeProposal{}, "sunchain/CategoryChangeProposal")
+}
+
+// CategoryChangeProposal adds, updates and removes categories of the product registry. Categories
+// are set in order before the removed ones are removed, and the whole change fails if any part does.
+type CategoryChangeProposal struct {
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Set         []Category `json:"set"`
+	Remove      []string   `json:"remove"`
+}
+
+// NewCategoryChangeProposal creates a new category change proposal.
+func NewCategoryChangeProposal(title, description string, set []Category, remove []string) CategoryChangeProposal {
+	return CategoryChangeProposal{title, description, set, remove}
+}
+
+// GetTitle returns the title of a category change proposal.
+func (ccp CategoryChangeProposal) GetTitle() string { return ccp.Title }
+
+// GetDescription returns the description of a category change proposal.
+func (ccp CategoryChangeProposal) GetDescription() string { return ccp.Description }
+
+// ProposalRoute returns the routing key of a category change proposal.
+func (ccp CategoryChangeProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of a category change proposal.
+func (ccp CategoryChangeProposal) ProposalType() string { return ProposalTypeCategoryChange }
+
+// ValidateBasic runs basic stateless validity checks
+func (ccp CategoryChangeProposal) ValidateBasic() error {
+	err := govtypes.ValidateAbstract(ccp)
+	if err != nil {
+		return err
+	}
+	if len(ccp.Set) == 0 && len(ccp.Remove) == 0 {
+		return sdkerrors.Wrap(ErrInvalidCategory, "proposal changes no category")
+	}
+	for _, category := range ccp.Set {
+		if err := category.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, name := range ccp.Remove {
+		if !categoryRegexp.MatchString(name) {
+			return sdkerrors.Wrapf(ErrInvalidCategory, "invalid name %q", name)
+		}
+	}
+	return nil
+}
+
+// String implements the Stringer interface.
+func (ccp CategoryChangeProposal) String() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(`Category Change Proposal:
+  Title:       %s
+  Description: %s
+  Set:
+`, ccp.Title, ccp.Description))
+	for _, category := range ccp.Set {
+		b.WriteString(fmt.Sprintf("    %s (parent: %s, listing fee: %s)\n", category.Name, category.Parent, category.ListingFee))
+	}
+	b.WriteString(fmt.Sprintf("  Remove: %s\n", strings.Join(ccp.Remove, ", ")))
+	return b.String()
+}
@@ -13,3 +13,9 @@ type QueryResSells []Sell
 
 // QueryResReservations ...
 type QueryResReservations []Reservation
+
+// QueryResCategories ...
+type QueryResCategories []Category
+
+// QueryResPrices ...
+type QueryResPrices []Price
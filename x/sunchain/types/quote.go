@@ -0,0 +1,104 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bandprotocol/bandchain/chain/x/oracle"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/trinhtan/cosmos-hackathon/x/sunchain/borsh"
+)
+
+const (
+	// QuoteClientIDPrefix is the prefix of client id of every quote request
+	QuoteClientIDPrefix = "Quote"
+
+	// QuoteOracleCallback is the callback kind that stores currency quotes
+	QuoteOracleCallback = "quote"
+
+	// QuoteMultiplier is the fixed point multiplier of quote rates
+	QuoteMultiplier = uint64(1000000)
+
+	// QuoteMaxAge is the number of blocks a quote can be used for payment after it was received
+	QuoteMaxAge = int64(100)
+)
+
+// Quote is the price of one unit of a coin denomination in a reference currency reported by BandChain
+type Quote struct {
+	Currency  string           `json:"currency"`
+	Denom     string           `json:"denom"`
+	Rate      uint64           `json:"rate"`
+	RequestID oracle.RequestID `json:"request_id"`
+	Height    int64            `json:"height"`
+}
+
+// NewQuote creates a new Quote instance.
+func NewQuote(currency string, denom string, rate uint64, requestID oracle.RequestID, height int64) Quote {
+	return Quote{
+		Currency:  currency,
+		Denom:     denom,
+		Rate:      rate,
+		RequestID: requestID,
+		Height:    height,
+	}
+}
+
+// implement fmt.Stringer
+func (quote Quote) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`
+	Currency: %s
+	Denom: %s
+	Rate: %d
+	RequestID: %d
+	Height: %d`, quote.Currency, quote.Denom, quote.Rate, quote.RequestID, quote.Height))
+}
+
+// IsFresh returns true if the quote can still be used for payment at the given height
+func (quote Quote) IsFresh(height int64) bool {
+	return height-quote.Height <= QuoteMaxAge
+}
+
+// CoinAmount converts a price in the quote currency to the amount of coins of the quote denom,
+// rounding up so that the seller never receives less than the fiat price.
+func (quote Quote) CoinAmount(fiatPrice uint64) sdk.Int {
+	numerator := sdk.NewIntFromUint64(fiatPrice).Mul(sdk.NewIntFromUint64(QuoteMultiplier))
+	rate := sdk.NewIntFromUint64(quote.Rate)
+	return numerator.Add(rate).SubRaw(1).Quo(rate)
+}
+
+// QuoteClientID returns client id of oracle request for the given currency and denom made at the
+// given height. The height tells apart the successive requests of a currency and denom, so that a
+// late response to an expired request can't answer the request which replaced it.
+func QuoteClientID(currency string, denom string, height int64) string {
+	return fmt.Sprintf("%s%d", QuoteClientIDsPrefix(currency, denom), height)
+}
+
+// QuoteClientIDsPrefix returns the prefix of the client ids of the oracle requests for the given
+// currency and denom
+func QuoteClientIDsPrefix(currency string, denom string) string {
+	return fmt.Sprintf("%s:%s:%s:", QuoteClientIDPrefix, currency, denom)
+}
+
+// ParseQuoteClientID returns currency and denom from client id of oracle request
+func ParseQuoteClientID(clientID string) (string, string, error) {
+	parts := strings.Split(clientID, ":")
+	if len(parts) != 4 || parts[0] != QuoteClientIDPrefix {
+		return "", "", sdkerrors.Wrapf(ErrUnknownClientID, "unknown client id %s", clientID)
+	}
+	if _, err := strconv.ParseInt(parts[3], 10, 64); err != nil {
+		return "", "", sdkerrors.Wrapf(ErrUnknownClientID, "invalid height in client id %s", clientID)
+	}
+	return parts[1], parts[2], nil
+}
+
+// EncodeQuoteCalldata encodes the calldata of the quote oracle script
+func EncodeQuoteCalldata(currency string, denom string) ([]byte, error) {
+	return borsh.Marshal(QuoteCalldata{
+		Currency:   currency,
+		Denom:      denom,
+		Multiplier: QuoteMultiplier,
+	})
+}
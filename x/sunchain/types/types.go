@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 )
 
 // MinNamePrice is Initial Starting Price for a name that was never previously owned
@@ -15,21 +16,39 @@ var MinProductPrice = sdk.Coins{sdk.NewInt64Coin("producttoken", 1)}
 
 // Product is struct that contains all the metadata of a product
 type Product struct {
-	ProductID   string         `json:"productID"`
-	Title       string         `json:"title"`
-	Description string         `json:"description"`
-	Category    string         `json:"category"`
-	Images      string         `json:"images"`
-	Owner       sdk.AccAddress `json:"owner"`
-	Selling     bool           `json:"selling"`
-	SellID      string         `json:"sellID"`
+	ProductID   string             `json:"productID"`
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	Category    string             `json:"category"`
+	Images      []ProductImage     `json:"images"`
+	Attributes  []ProductAttribute `json:"attributes"`
+	Owner       sdk.AccAddress     `json:"owner"`
+	Selling     bool               `json:"selling"`
+	SellID      string             `json:"sellID"`
 }
 
-//NewProduct returns a new product
+// NewProduct returns a new product
 func NewProduct() Product {
 	return Product{}
 }
 
+// NewProductID returns the id of the count-th product of the chain, created by creator with the
+// given metadata. The id is a hash of all of them formatted like a UUID, so it does not depend on
+// the client and cannot collide with the id of another product.
+func NewProductID(count uint64, creator sdk.AccAddress, metadata ProductMetadata) string {
+	hasher := tmhash.New()
+	hasher.Write(sdk.Uint64ToBigEndian(count))
+	hasher.Write(creator)
+	hasher.Write(sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(metadata)))
+	b := hasher.Sum(nil)
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// Metadata returns the metadata the owner gave to the product
+func (product Product) Metadata() ProductMetadata {
+	return NewProductMetadata(product.Title, product.Description, product.Category, product.Images, product.Attributes)
+}
+
 // implement fmt.Stringer
 func (product Product) String() string {
 	return strings.TrimSpace(fmt.Sprintf(`
@@ -37,25 +56,42 @@ func (product Product) String() string {
 	Title: %s
 	Description: %s
 	Category: %s
-	Images: %s
-	Owner: %s`, product.ProductID, product.Title, product.Description, product.Category, product.Images, product.Owner))
+	Images: %v
+	Attributes: %v
+	Owner: %s`, product.ProductID, product.Title, product.Description, product.Category, product.Images, product.Attributes, product.Owner))
 }
 
 // Sell is a struct contains all the metadata of a sell
 type Sell struct {
-	SellID    string         `json:"sellID"`
-	ProductID string         `json:"productID"`
-	Seller    sdk.AccAddress `json:"seller"`
-	MinPrice  sdk.Coins      `json:"minPrice"`
+	SellID       string         `json:"sellID"`
+	ProductID    string         `json:"productID"`
+	Seller       sdk.AccAddress `json:"seller"`
+	MinPrice     sdk.Coins      `json:"minPrice"`
+	Currency     string         `json:"currency"`
+	FiatMinPrice uint64         `json:"fiatMinPrice"`
+	Denom        string         `json:"denom"`
 }
 
-//NewSell returns a new sell
+// NewSell returns a new sell
 func NewSell() Sell {
 	return Sell{}
 }
 
+// IsFiatPriced returns true if the sell is priced in a reference currency instead of coins
+func (sell Sell) IsFiatPriced() bool {
+	return len(sell.Currency) != 0
+}
+
 // implement fmt.Stringer
 func (sell Sell) String() string {
+	if sell.IsFiatPriced() {
+		return strings.TrimSpace(fmt.Sprintf(`
+	SellID: %s
+	ProductID: %s
+	Seller: %s
+	FiatMinPrice: %d%s
+	Denom: %s`, sell.SellID, sell.ProductID, sell.Seller, sell.FiatMinPrice, sell.Currency, sell.Denom))
+	}
 	return strings.TrimSpace(fmt.Sprintf(`
 	SellID: %s
 	ProductID: %s
@@ -69,19 +105,54 @@ type Reservation struct {
 	SellID        string         `json:"sellID"`
 	Buyer         sdk.AccAddress `json:"buyer"`
 	Price         sdk.Coins      `json:"price"`
+	FiatPrice     uint64         `json:"fiatPrice"`
 	Decide        bool           `json:"decide"`
 }
 
-//NewReservation returns a new Reservation
+// NewReservation returns a new Reservation
 func NewReservation() Reservation {
 	return Reservation{}
 }
 
 // implement fmt.Stringer
 func (reservation Reservation) String() string {
+	if reservation.FiatPrice != 0 {
+		return strings.TrimSpace(fmt.Sprintf(`
+	ReservationID: %s
+	SellID: %s
+	Buyer: %s
+	FiatPrice: %d`, reservation.ReservationID, reservation.SellID, reservation.Buyer, reservation.FiatPrice))
+	}
 	return strings.TrimSpace(fmt.Sprintf(`
 	ReservationID: %s
 	SellID: %s
 	Buyer: %s
 	Price: %s`, reservation.ReservationID, reservation.SellID, reservation.Buyer, reservation.Price))
 }
+
+// Settlement is a struct contains the record of a paid reservation
+type Settlement struct {
+	SellID        string         `json:"sellID"`
+	ReservationID string         `json:"reservationID"`
+	ProductID     string         `json:"productID"`
+	Seller        sdk.AccAddress `json:"seller"`
+	Buyer         sdk.AccAddress `json:"buyer"`
+	Amount        sdk.Coins      `json:"amount"`
+	Currency      string         `json:"currency"`
+	FiatPrice     uint64         `json:"fiatPrice"`
+	Quote         *Quote         `json:"quote"`
+	Height        int64          `json:"height"`
+}
+
+// implement fmt.Stringer
+func (settlement Settlement) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`
+	SellID: %s
+	ReservationID: %s
+	ProductID: %s
+	Seller: %s
+	Buyer: %s
+	Amount: %s
+	Height: %d`, settlement.SellID, settlement.ReservationID, settlement.ProductID,
+		settlement.Seller, settlement.Buyer, settlement.Amount, settlement.Height))
+}